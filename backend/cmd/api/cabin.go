@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mikahozz/gohome/integrations/cabin"
+	"github.com/rs/zerolog/log"
+)
+
+// getCabinDays serves the booking status of each day, by default for the next
+// 365 days, in the form the cabin bookings view expects.
+func getCabinDays(service *cabin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		days := 365
+		if daysStr := r.PathValue("days"); daysStr != "" {
+			var err error
+			days, err = strconv.Atoi(daysStr)
+			if err != nil || days < 1 || days > 730 {
+				http.Error(w, "Invalid days. Use a number between 1 and 730.", http.StatusBadRequest)
+				return
+			}
+		}
+		bookings, err := service.Days(r.Context(), days)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching cabin bookings", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(bookings)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching cabin bookings", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// cabinBookings lists the bookings on GET, by default the active ones for the
+// next year, and books the cabin on POST.
+func cabinBookings(service *cabin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			bookCabin(service, w, r)
+			return
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		from := service.Today()
+		if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+			var err error
+			from, err = cabin.ParseDate(fromStr)
+			if err != nil {
+				http.Error(w, "Invalid from date format. Use YYYY-MM-DD.", http.StatusBadRequest)
+				return
+			}
+		}
+		to := from.AddDays(365)
+		if toStr := r.URL.Query().Get("to"); toStr != "" {
+			var err error
+			to, err = cabin.ParseDate(toStr)
+			if err != nil {
+				http.Error(w, "Invalid to date format. Use YYYY-MM-DD.", http.StatusBadRequest)
+				return
+			}
+		}
+		if to.Before(from) {
+			http.Error(w, "Invalid date range. The to date must not be before from.", http.StatusBadRequest)
+			return
+		}
+		includeCancelled := false
+		if cancelled := r.URL.Query().Get("cancelled"); cancelled != "" {
+			var err error
+			includeCancelled, err = strconv.ParseBool(cancelled)
+			if err != nil {
+				http.Error(w, "Invalid cancelled. Use true or false.", http.StatusBadRequest)
+				return
+			}
+		}
+
+		bookings, err := service.List(r.Context(), from, to, includeCancelled)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching cabin bookings", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(bookings)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching cabin bookings", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// bookCabin books the cabin. A booking overlapping others is rejected with
+// 409 and the conflicting bookings.
+func bookCabin(service *cabin.Service, w http.ResponseWriter, r *http.Request) {
+	var booking cabin.Booking
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&booking); err != nil {
+		http.Error(w, "Invalid booking. Use JSON with name, from, to and note, dates as YYYY-MM-DD.", http.StatusBadRequest)
+		return
+	}
+	booked, err := service.Book(r.Context(), booking)
+	var conflict *cabin.ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		body, err := json.Marshal(struct {
+			Error     string          `json:"error"`
+			Conflicts []cabin.Booking `json:"conflicts"`
+		}{cabin.ErrConflict.Error(), conflict.Conflicts})
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in booking the cabin", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write(body)
+		return
+	case errors.Is(err, cabin.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, cabin.ErrInvalidBooking):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		log.Err(err).Msg("")
+		http.Error(w, "Error occurred in booking the cabin", http.StatusInternalServerError)
+		return
+	}
+	json, err := json.Marshal(booked)
+	if err != nil {
+		log.Err(err).Msg("")
+		http.Error(w, "Error occurred in booking the cabin", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/api/cabinbookings/%d", booked.ID))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(json)
+}
+
+// cabinBooking serves a booking on GET and cancels it on DELETE.
+func cabinBooking(service *cabin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid booking id", http.StatusBadRequest)
+			return
+		}
+		var booking cabin.Booking
+		switch r.Method {
+		case http.MethodGet:
+			booking, err = service.Get(r.Context(), id)
+		case http.MethodDelete:
+			_, err = service.Cancel(r.Context(), id)
+		default:
+			w.Header().Set("Allow", "GET, DELETE")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch {
+		case errors.Is(err, cabin.ErrNotFound):
+			http.Error(w, fmt.Sprintf("Unknown cabin booking %d", id), http.StatusNotFound)
+			return
+		case err != nil:
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in handling cabin booking %d", id), http.StatusInternalServerError)
+			return
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json, err := json.Marshal(booking)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in handling cabin booking %d", id), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getCabinICal serves the bookings from a year back to two years ahead as an
+// iCalendar feed to subscribe to.
+func getCabinICal(service *cabin.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		today := service.Today()
+		bookings, err := service.List(r.Context(), today.AddDays(-365), today.AddDays(730), true)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching cabin bookings", http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		if err := cabin.WriteICal(&buf, bookings); err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in writing cabin bookings calendar", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="cabin-bookings.ics"`)
+		w.Write(buf.Bytes())
+	}
+}
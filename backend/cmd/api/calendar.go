@@ -0,0 +1,400 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/cal"
+	"github.com/mikahozz/gohome/integrations/holidays"
+	"github.com/rs/zerolog/log"
+)
+
+// calendarsParam returns the calendars listed in the comma separated
+// calendars parameter.
+func calendarsParam(r *http.Request) []string {
+	var calendars []string
+	for _, name := range strings.Split(r.URL.Query().Get("calendars"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			calendars = append(calendars, name)
+		}
+	}
+	return calendars
+}
+
+// maxCalendarRange is the longest time range calendar events are served for.
+const maxCalendarRange = 366 * 24 * time.Hour
+
+// parseCalendarRange reads the start and end query parameters as RFC3339 times
+// or as dates in loc, an end date including the whole day. Instead of end the
+// range can be given in days. The range defaults to the week from now and may
+// not exceed maxCalendarRange.
+func parseCalendarRange(r *http.Request, loc *time.Location) (time.Time, time.Time, error) {
+	parse := func(value string) (time.Time, bool, error) {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, false, nil
+		}
+		t, err := time.ParseInLocation(time.DateOnly, value, loc)
+		return t, true, err
+	}
+
+	start := time.Now()
+	if startStr := r.URL.Query().Get("start"); startStr != "" {
+		var err error
+		start, _, err = parse(startStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid start format, use RFC3339 or YYYY-MM-DD")
+		}
+	}
+	end := start.AddDate(0, 0, 7)
+	endStr := r.URL.Query().Get("end")
+	daysStr := r.URL.Query().Get("days")
+	switch {
+	case endStr != "" && daysStr != "":
+		return time.Time{}, time.Time{}, errors.New("use either end or days, not both")
+	case endStr != "":
+		var isDate bool
+		var err error
+		end, isDate, err = parse(endStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid end format, use RFC3339 or YYYY-MM-DD")
+		}
+		if isDate {
+			end = end.AddDate(0, 0, 1)
+		}
+	case daysStr != "":
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 1 {
+			return time.Time{}, time.Time{}, errors.New("invalid days, use a positive number")
+		}
+		end = start.AddDate(0, 0, days)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, errors.New("invalid time range, the end must be after start")
+	}
+	if end.Sub(start) > maxCalendarRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("time range too long, maximum is %d days", int(maxCalendarRange.Hours()/24))
+	}
+	return start, end, nil
+}
+
+// cancelledParam reports whether the cancelled parameter asks for cancelled
+// events too.
+func cancelledParam(r *http.Request) (bool, error) {
+	cancelled := r.URL.Query().Get("cancelled")
+	if cancelled == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(cancelled)
+}
+
+// holidayCalendar is the name of the virtual calendar of Finnish public
+// holidays and flag days served with the calendar events.
+const holidayCalendar = "Holidays"
+
+// calendarEventsWithHolidays returns the events of the named calendars from
+// the source, or of all calendars, with the holidays of holidayCalendar when
+// it is named or no calendars are.
+func calendarEventsWithHolidays(source cal.EventSource, start, end time.Time, includeCancelled bool, calendars []string, loc *time.Location) ([]cal.Event, error) {
+	var named []string
+	withHolidays := len(calendars) == 0
+	for _, name := range calendars {
+		if strings.EqualFold(name, holidayCalendar) {
+			withHolidays = true
+			continue
+		}
+		named = append(named, name)
+	}
+	events := []cal.Event{}
+	if len(calendars) == 0 || len(named) > 0 {
+		var err error
+		events, err = source.Events(start, end, includeCancelled, named...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !withHolidays {
+		return events, nil
+	}
+	return mergeHolidays(events, start.In(loc), end), nil
+}
+
+// mergeHolidays adds the holidays between start and end to the events, in
+// order of start.
+func mergeHolidays(events []cal.Event, start, end time.Time) []cal.Event {
+	events = append(events, holidayEvents(start, end)...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+// holidayEvents returns the holidays between start and end as all-day events
+// on the days of start's location. Like the calendar events they are served
+// with their dates, which don't depend on the location.
+func holidayEvents(start, end time.Time) []cal.Event {
+	events := []cal.Event{}
+	onDay := make(map[time.Time]int)
+	for _, h := range holidays.Between(start, end) {
+		day := time.Date(h.Date.Year(), h.Date.Month(), h.Date.Day(), 0, 0, 0, 0, start.Location())
+		var categories []string
+		if h.Off() {
+			categories = append(categories, "Holiday")
+		}
+		if h.Flag {
+			categories = append(categories, "Flag day")
+		}
+		onDay[day]++
+		events = append(events, cal.Event{
+			Uid:         fmt.Sprintf("holiday-%s-%d@gohome", h.Date.Format(time.DateOnly), onDay[day]),
+			Start:       day,
+			End:         day.AddDate(0, 0, 1),
+			Summary:     h.Name,
+			Description: h.NameFi,
+			AllDay:      true,
+			Categories:  categories,
+			Calendar:    holidayCalendar,
+			ReadOnly:    true,
+		})
+	}
+	return events
+}
+
+// getHolidays serves the Finnish public holidays, days off and flag days of
+// the year parameter, by default of the current year in loc.
+func getHolidays(loc *time.Location) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		year := time.Now().In(loc).Year()
+		if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+			var err error
+			year, err = strconv.Atoi(yearStr)
+			if err != nil || year < 1992 || year > 9999 {
+				http.Error(w, "Invalid year. Use a year from 1992 on, e.g. 2025.", http.StatusBadRequest)
+				return
+			}
+		}
+		json, err := json.Marshal(holidays.Year(year))
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of holidays", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// calendarEvents serves on GET the events of the calendars listed in the
+// comma separated calendars parameter, or of all calendars, in the range
+// given by parseCalendarRange, from the source with the holidays of
+// holidayCalendar. Cancelled events are included with cancelled=true. POST
+// is handled by create.
+func calendarEvents(calConfig *cal.Config, source cal.EventSource, create http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			create(w, r)
+			return
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		includeCancelled, err := cancelledParam(r)
+		if err != nil {
+			http.Error(w, "Invalid cancelled. Use true or false.", http.StatusBadRequest)
+			return
+		}
+		start, end, err := parseCalendarRange(r, calConfig.BaseTimezone)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		events, err := calendarEventsWithHolidays(source, start, end, includeCancelled, calendarsParam(r), calConfig.BaseTimezone)
+		if errors.Is(err, cal.ErrUnknownCalendar) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, cal.ErrNotSynced) {
+			http.Error(w, "Calendars not synced yet", http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred fetching calendar events", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(events)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of calendar events", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// createCalendarEvent adds the event to the calendar named in it, or to the
+// first calendar.
+func createCalendarEvent(calEditor *cal.Editor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var event cal.Event
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&event); err != nil {
+			http.Error(w, "Invalid event. Use JSON with calendar, summary, start, end and allDay, times as RFC3339.", http.StatusBadRequest)
+			return
+		}
+		created, err := calEditor.Create(r.Context(), event.Calendar, event)
+		if !writeCalendarEditError(w, err, "Error occurred in creating the calendar event") {
+			return
+		}
+		json, err := json.Marshal(created)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in creating the calendar event", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/events/"+url.PathEscape(created.Uid))
+		if created.ETag != "" {
+			w.Header().Set("ETag", strconv.Quote(created.ETag))
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(json)
+	}
+}
+
+// calendarEvent changes an event on PUT and removes it on DELETE. With the
+// recurrenceId parameter, the RFC3339 original start of an instance, only
+// that instance of a recurring event is changed or removed. The etag of the
+// event in If-Match makes the change fail with 412 if the event has been
+// changed since.
+func calendarEvent(calEditor *cal.Editor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		uid := r.PathValue("uid")
+		var recurrenceID time.Time
+		if ridStr := r.URL.Query().Get("recurrenceId"); ridStr != "" {
+			var err error
+			recurrenceID, err = time.Parse(time.RFC3339, ridStr)
+			if err != nil {
+				http.Error(w, "Invalid recurrenceId format. Use RFC3339.", http.StatusBadRequest)
+				return
+			}
+		}
+		etag, err := strconv.Unquote(r.Header.Get("If-Match"))
+		if err != nil {
+			etag = r.Header.Get("If-Match")
+		}
+
+		var updated cal.Event
+		switch r.Method {
+		case http.MethodPut:
+			var event cal.Event
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&event); err != nil {
+				http.Error(w, "Invalid event. Use JSON with summary, start, end and allDay, times as RFC3339.", http.StatusBadRequest)
+				return
+			}
+			updated, err = calEditor.Update(r.Context(), uid, recurrenceID, event, etag)
+		case http.MethodDelete:
+			err = calEditor.Delete(r.Context(), uid, recurrenceID, etag)
+		default:
+			w.Header().Set("Allow", "PUT, DELETE")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !writeCalendarEditError(w, err, fmt.Sprintf("Error occurred in changing calendar event %s", uid)) {
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json, err := json.Marshal(updated)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in changing calendar event %s", uid), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if updated.ETag != "" {
+			w.Header().Set("ETag", strconv.Quote(updated.ETag))
+		}
+		w.Write(json)
+	}
+}
+
+// writeCalendarEditError writes the response for an error of a calendar
+// change and reports whether there was none.
+func writeCalendarEditError(w http.ResponseWriter, err error, message string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, cal.ErrInvalidEvent), errors.Is(err, cal.ErrUnknownCalendar):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, cal.ErrReadOnly):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, cal.ErrEventNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, cal.ErrConflict):
+		http.Error(w, "The event has been changed since. Fetch it again and retry.", http.StatusPreconditionFailed)
+	case errors.Is(err, cal.ErrNotSynced):
+		http.Error(w, "Calendars not synced yet", http.StatusServiceUnavailable)
+	default:
+		log.Err(err).Msg("")
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+	return false
+}
+
+// mockCalendarEvent refuses changes to the mock calendar events.
+func mockCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "Calendar events can't be changed with mock data", http.StatusNotImplemented)
+}
+
+// getCalendarDays serves the events like getCalendarEvents, split into a
+// segment for each day so multi-day events show on every day they last.
+func getCalendarDays(calConfig *cal.Config, source cal.EventSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		includeCancelled, err := cancelledParam(r)
+		if err != nil {
+			http.Error(w, "Invalid cancelled. Use true or false.", http.StatusBadRequest)
+			return
+		}
+		start, end, err := parseCalendarRange(r, calConfig.BaseTimezone)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		events, err := calendarEventsWithHolidays(source, start, end, includeCancelled, calendarsParam(r), calConfig.BaseTimezone)
+		if errors.Is(err, cal.ErrUnknownCalendar) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, cal.ErrNotSynced) {
+			http.Error(w, "Calendars not synced yet", http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred fetching calendar events", http.StatusInternalServerError)
+			return
+		}
+		segments := cal.SplitDays(events, start, end, calConfig.BaseTimezone)
+		json, err := json.Marshal(segments)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of calendar days", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
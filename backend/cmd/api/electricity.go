@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/solar"
+	"github.com/mikahozz/gohome/integrations/spot"
+	"github.com/rs/zerolog/log"
+)
+
+// spotPriceSource values solar production at the spot prices.
+func spotPriceSource(start, end time.Time) ([]spot.SpotPrice, error) {
+	prices, err := spot.GetPrices(start, end, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	return prices.Prices, nil
+}
+
+func getSpotPrices() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startStr := r.URL.Query().Get("start")
+		endStr := r.URL.Query().Get("end")
+		timeFormat := r.URL.Query().Get("timeFormat")
+
+		// Default to UTC if timeFormat is not specified
+		if timeFormat == "" {
+			timeFormat = "utc"
+		}
+
+		// Get the location based on timeFormat
+		var location *time.Location
+		var err error
+		switch timeFormat {
+		case "utc":
+			location = time.UTC
+		case "local":
+			location = time.Local
+		default:
+			location, err = time.LoadLocation(timeFormat)
+			if err != nil {
+				log.Error().Err(err).Msg("Invalid timezone format")
+				http.Error(w, "Invalid timezone format", http.StatusBadRequest)
+				return
+			}
+		}
+
+		start, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Invalid start time format. Use RFC3339.", http.StatusBadRequest)
+			return
+		}
+
+		end, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Invalid end time format. Use RFC3339.", http.StatusBadRequest)
+			return
+		}
+
+		log.Info().Msgf("Getting spot prices for %s to %s in %s format", start, end, timeFormat)
+		prices, err := spot.GetPrices(start, end, location)
+		if err != nil {
+			log.Error().Err(err).Msg("Error getting spot prices")
+			http.Error(w, "Error occurred fetching spot prices", http.StatusInternalServerError)
+			return
+		}
+
+		json, err := json.Marshal(prices.Prices)
+		if err != nil {
+			log.Error().Err(err).Msg("Error marshalling spot prices to JSON")
+			http.Error(w, "Error occurred in JSON conversion of spot prices", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getSolarCurrent serves the latest production. A stale or missing sample is
+// 503, the inverter does not answer at night.
+func getSolarCurrent(service *solar.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current, err := service.Current(r.Context())
+		if errors.Is(err, solar.ErrNoSamples) || errors.Is(err, solar.ErrStale) {
+			http.Error(w, "No current solar production", http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar production", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(current)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar production", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// parseRange reads the from and to query parameters in RFC3339. The range
+// defaults to the span before now and may not exceed maxRange.
+func parseRange(r *http.Request, span, maxRange time.Duration) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to time format, use RFC3339")
+		}
+	}
+	from := to.Add(-span)
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid from time format, use RFC3339")
+		}
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, errors.New("invalid time range, the to time must be after from")
+	}
+	if to.Sub(from) > maxRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("time range too long, maximum is %d days", int(maxRange.Hours()/24))
+	}
+	return from, to, nil
+}
+
+// getSolarHistory serves the recorded samples, by default for the last 24
+// hours.
+func getSolarHistory(service *solar.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseRange(r, 24*time.Hour, solar.MaxHistoryRange)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		history, err := service.History(r.Context(), from, to)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar history", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(history)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar history", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getSolarSelfConsumption serves the hourly production, export and
+// self-consumption valued at the spot price, by default for the last 24
+// hours.
+func getSolarSelfConsumption(service *solar.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseRange(r, 24*time.Hour, 31*24*time.Hour)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		summary, err := service.SelfConsumption(r.Context(), from, to)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar self-consumption", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(summary)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching solar self-consumption", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+	"github.com/mikahozz/gohome/mock"
+	"github.com/rs/zerolog/log"
+)
+
+func getIndoorSensors(registry *indoor.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sensors, err := registry.Sensors(r.Context())
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching indoor sensors", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(sensors)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in fetching indoor sensors", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// indoorReading serves the latest reading of the sensor on GET and records a
+// reading on POST. A posted reading without a time is recorded at now.
+func indoorReading(registry *indoor.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sensor := r.PathValue("sensor")
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var reading indoor.Reading
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&reading); err != nil {
+				http.Error(w, "Invalid reading. Use JSON with time, temperature, humidity and battery.", http.StatusBadRequest)
+				return
+			}
+			if reading.Time.IsZero() {
+				reading.Time = time.Now()
+			}
+			err := registry.Record(r.Context(), sensor, reading)
+			switch {
+			case errors.Is(err, indoor.ErrUnknownSensor):
+				http.Error(w, fmt.Sprintf("Unknown indoor sensor %s", sensor), http.StatusNotFound)
+			case errors.Is(err, indoor.ErrInvalidReading):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			case err != nil:
+				log.Err(err).Msg("")
+				http.Error(w, fmt.Sprintf("Error occurred in recording reading of %s", sensor), http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		reading, err := registry.Latest(r.Context(), sensor)
+		switch {
+		case errors.Is(err, indoor.ErrUnknownSensor):
+			http.Error(w, fmt.Sprintf("Unknown indoor sensor %s", sensor), http.StatusNotFound)
+			return
+		case errors.Is(err, indoor.ErrNoReadings):
+			http.Error(w, fmt.Sprintf("No readings from %s", sensor), http.StatusNotFound)
+			return
+		case err != nil:
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching reading of %s", sensor), http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(reading)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching reading of %s", sensor), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getIndoorHistory serves the readings of the sensor, by default for the last
+// 24 hours.
+func getIndoorHistory(registry *indoor.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sensor := r.PathValue("sensor")
+		to := time.Now().UTC()
+		if toStr := r.URL.Query().Get("to"); toStr != "" {
+			var err error
+			to, err = time.Parse(time.RFC3339, toStr)
+			if err != nil {
+				http.Error(w, "Invalid to time format. Use RFC3339.", http.StatusBadRequest)
+				return
+			}
+		}
+		from := to.Add(-24 * time.Hour)
+		if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+			var err error
+			from, err = time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				http.Error(w, "Invalid from time format. Use RFC3339.", http.StatusBadRequest)
+				return
+			}
+		}
+		if !to.After(from) {
+			http.Error(w, "Invalid time range. The to time must be after from.", http.StatusBadRequest)
+			return
+		}
+		if to.Sub(from) > indoor.MaxHistoryRange {
+			http.Error(w, "Time range too long. Maximum is 31 days.", http.StatusBadRequest)
+			return
+		}
+
+		history, err := registry.History(r.Context(), sensor, from, to)
+		if errors.Is(err, indoor.ErrUnknownSensor) {
+			http.Error(w, fmt.Sprintf("Unknown indoor sensor %s", sensor), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching history of %s", sensor), http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(history)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching history of %s", sensor), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// sseKeepAlive is how often a comment is sent on an idle event stream so
+// proxies keep the connection open.
+const sseKeepAlive = 30 * time.Second
+
+// streamIndoor sends the readings as server-sent "reading" events as they are
+// recorded. The sensor parameter limits the stream to one sensor.
+func streamIndoor(registry *indoor.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sensor := r.URL.Query().Get("sensor")
+		if sensor != "" {
+			if _, err := registry.Sensor(r.Context(), sensor); errors.Is(err, indoor.ErrUnknownSensor) {
+				http.Error(w, fmt.Sprintf("Unknown indoor sensor %s", sensor), http.StatusNotFound)
+				return
+			}
+		}
+		updates, cancel := registry.Subscribe()
+		defer cancel()
+
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		if err := rc.Flush(); err != nil {
+			log.Err(err).Msg("Streaming not supported")
+			return
+		}
+
+		keepAlive := time.NewTicker(sseKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+			case u := <-updates:
+				if sensor != "" && u.Sensor != sensor {
+					continue
+				}
+				json, err := json.Marshal(u)
+				if err != nil {
+					log.Err(err).Msg("")
+					continue
+				}
+				fmt.Fprintf(w, "event: reading\ndata: %s\n\n", json)
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// mockIndoorStream sends the mock reading every 10 seconds.
+func mockIndoorStream() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			reading, err := mock.IndoorDevUpstairs()
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: reading\ndata: {\"sensor\":\"dev_upstairs\",%s\n\n", strings.TrimPrefix(reading, "{"))
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
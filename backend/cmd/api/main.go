@@ -1,17 +1,24 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/mikahozz/gohome/config"
+	"github.com/mikahozz/gohome/db"
+	"github.com/mikahozz/gohome/integrations/cabin"
 	"github.com/mikahozz/gohome/integrations/cal"
 	"github.com/mikahozz/gohome/integrations/fmi"
-	"github.com/mikahozz/gohome/integrations/spot"
-	"github.com/mikahozz/gohome/integrations/sun"
+	"github.com/mikahozz/gohome/integrations/indoor"
+	"github.com/mikahozz/gohome/integrations/mqtt"
+	"github.com/mikahozz/gohome/integrations/solar"
+	"github.com/mikahozz/gohome/integrations/warnings"
+	"github.com/mikahozz/gohome/integrations/weather"
 	"github.com/mikahozz/gohome/mock"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -23,32 +30,166 @@ const port = ":6001"
 type handlers struct {
 	weatherNow     http.HandlerFunc
 	weatherFore    http.HandlerFunc
-	indoorTemp     http.HandlerFunc
+	weatherHistory http.HandlerFunc
+	weatherDaily   http.HandlerFunc
+	weatherMonthly http.HandlerFunc
+	stations       http.HandlerFunc
+	warnings       http.HandlerFunc
+	lightning      http.HandlerFunc
+	indoorSensors  http.HandlerFunc
+	indoorReading  http.HandlerFunc
+	indoorHistory  http.HandlerFunc
+	indoorStream   http.HandlerFunc
+	cabinDays      http.HandlerFunc
+	cabinBookings  http.HandlerFunc
+	cabinBooking   http.HandlerFunc
+	cabinICal      http.HandlerFunc
+	solarCurrent   http.HandlerFunc
+	solarHistory   http.HandlerFunc
+	solarSelfUse   http.HandlerFunc
 	spotPrices     http.HandlerFunc
 	calendarEvents http.HandlerFunc
+	calendarEvent  http.HandlerFunc
+	calendarDays   http.HandlerFunc
+	holidays       http.HandlerFunc
 	sunData        http.HandlerFunc
 }
 
 // Create real data handlers
 func createRealHandlers() handlers {
+	locations, err := weather.LoadLocations()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid weather location configuration")
+	}
+	heatingBase := fmi.DefaultHeatingBase
+	if base := os.Getenv("HEATING_BASE_TEMPERATURE"); base != "" {
+		heatingBase, err = strconv.ParseFloat(base, 64)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid HEATING_BASE_TEMPERATURE")
+		}
+	}
+	indoorStore, cabinStore, solarStore := openStores()
+	sensors, err := indoor.LoadSensors()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid indoor sensor configuration")
+	}
+	indoorRegistry := indoor.NewRegistry(indoorStore)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := indoorRegistry.Register(ctx, sensors...); err != nil {
+		log.Error().Err(err).Msg("Registering indoor sensors failed")
+	}
+	mqttConfig, err := mqtt.LoadConfig(sensors)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid MQTT configuration")
+	}
+	if mqttConfig.Enabled() {
+		mqtt.NewSubscriber(mqttConfig, indoorRegistry).Start()
+	}
+	cabinService := cabin.NewService(cabinStore, cabin.LoadMembers()...)
+	solarConfig, err := solar.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid solar configuration")
+	}
+	if solarConfig.Enabled() {
+		reader := solar.NewSunSpecReader(solar.NewModbusClient(solarConfig.Address, 0), solarConfig.InverterUnit, solarConfig.MeterUnit)
+		go solar.NewPoller(reader, solarStore, solarConfig.PollInterval).Run(context.Background())
+	}
+	solarService := solar.NewService(solarStore, spotPriceSource, solarConfig.MaxAge())
+	calConfig, err := cal.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid calendar configuration")
+	}
+	calStore := cal.NewStore(calConfig)
+	calEditor := cal.NewEditor(calConfig, calStore)
+	if len(calConfig.Sources) > 0 {
+		go cal.NewSyncer(calConfig, calStore, calConfig.SyncInterval).Run(context.Background())
+	}
+	fmiProvider := weather.NewFMIProvider(nil)
+	providers := weather.NewRegistry(fmiProvider, weather.NewMetNoProvider(nil))
 	return handlers{
-		weatherNow:     getWeatherData("101004", fmi.Observations),
-		weatherFore:    getWeatherData("Tapanila,Helsinki", fmi.Forecast),
-		indoorTemp:     jsonResponse(mock.IndoorDevUpstairs),
+		weatherNow:     getWeatherData(providers, locations, fmi.Observations),
+		weatherFore:    getWeatherForecast(providers, fmiProvider, locations),
+		weatherHistory: getWeatherHistory(fmiProvider, locations),
+		weatherDaily:   getDailyWeather(fmiProvider, locations, heatingBase, false),
+		weatherMonthly: getDailyWeather(fmiProvider, locations, heatingBase, true),
+		stations:       getNearestStations(fmi.NewStationCatalogue(fmi.NewDefaultHTTPClient(), fmi.StationsEndpoint, 24*time.Hour)),
+		warnings:       getWeatherWarnings(warnings.NewWarningService(warnings.NewDefaultHTTPClient(), warnings.FeedURL, 10*time.Minute), locations),
+		lightning:      getLightning(fmiProvider, locations),
+		indoorSensors:  getIndoorSensors(indoorRegistry),
+		indoorReading:  indoorReading(indoorRegistry),
+		indoorHistory:  getIndoorHistory(indoorRegistry),
+		indoorStream:   streamIndoor(indoorRegistry),
+		cabinDays:      getCabinDays(cabinService),
+		cabinBookings:  cabinBookings(cabinService),
+		cabinBooking:   cabinBooking(cabinService),
+		cabinICal:      getCabinICal(cabinService),
+		solarCurrent:   getSolarCurrent(solarService),
+		solarHistory:   getSolarHistory(solarService),
+		solarSelfUse:   getSolarSelfConsumption(solarService),
 		spotPrices:     getSpotPrices(),
-		calendarEvents: getCalendarEvents(),
+		calendarEvents: calendarEvents(calConfig, calStore, createCalendarEvent(calEditor)),
+		calendarEvent:  calendarEvent(calEditor),
+		calendarDays:   getCalendarDays(calConfig, calStore),
+		holidays:       getHolidays(calConfig.BaseTimezone),
 		sunData:        getSunData(),
 	}
 }
 
+// openStores opens the stores of indoor readings, cabin bookings and solar
+// production in the database. Without a configured database they are kept
+// in memory, and lost on restart.
+func openStores() (indoor.Store, cabin.Store, solar.Store) {
+	if !db.Configured() {
+		log.Warn().Msg("POSTGRES_DB not set, indoor readings, cabin bookings and solar production are kept in memory")
+		return indoor.NewMemoryStore(), cabin.NewMemoryStore(), solar.NewMemoryStore()
+	}
+	conn, err := db.Open()
+	if conn == nil {
+		log.Error().Err(err).Msg("Invalid database configuration, indoor readings, cabin bookings and solar production are kept in memory")
+		return indoor.NewMemoryStore(), cabin.NewMemoryStore(), solar.NewMemoryStore()
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Database not available, indoor readings, cabin bookings and solar production fail until it is")
+	}
+	return indoor.NewPostgresStore(conn), cabin.NewPostgresStore(conn), solar.NewPostgresStore(conn)
+}
+
 // Create mock data handlers
 func createMockHandlers() handlers {
+	cabinService := mock.CabinBookings()
+	solarService := mock.Solar()
+	// The mock calendar is split into days like the synced one
+	calConfig, err := cal.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid calendar configuration")
+	}
+	calendar := mock.Calendar(calConfig.BaseTimezone)
 	return handlers{
 		weatherNow:     jsonResponse(mock.OutdoorWeathernNow),
 		weatherFore:    jsonResponse(mock.OutdoorWeatherFore),
-		indoorTemp:     jsonResponse(mock.IndoorDevUpstairs),
+		weatherHistory: jsonResponse(mock.OutdoorWeatherHistory),
+		weatherDaily:   jsonResponse(mock.OutdoorWeatherDaily),
+		weatherMonthly: jsonResponse(mock.OutdoorWeatherMonthly),
+		stations:       jsonResponse(mock.WeatherStations),
+		warnings:       jsonResponse(mock.WeatherWarnings),
+		lightning:      jsonResponse(mock.WeatherLightning),
+		indoorSensors:  jsonResponse(mock.IndoorSensors),
+		indoorReading:  jsonResponse(mock.IndoorDevUpstairs),
+		indoorHistory:  jsonResponse(mock.IndoorHistory),
+		indoorStream:   mockIndoorStream(),
+		cabinDays:      getCabinDays(cabinService),
+		cabinBookings:  cabinBookings(cabinService),
+		cabinBooking:   cabinBooking(cabinService),
+		cabinICal:      getCabinICal(cabinService),
+		solarCurrent:   jsonResponse(mock.SolarCurrent),
+		solarHistory:   getSolarHistory(solarService),
+		solarSelfUse:   getSolarSelfConsumption(solarService),
 		spotPrices:     jsonResponse(mock.ElectricityPrices),
-		calendarEvents: jsonResponse(mock.Events),
+		calendarEvents: calendarEvents(calConfig, calendar, mockCalendarEvent),
+		calendarEvent:  mockCalendarEvent,
+		calendarDays:   getCalendarDays(calConfig, calendar),
+		holidays:       getHolidays(calConfig.BaseTimezone),
 		sunData:        getSunData(), // We use hard code Helsinki data for now
 	}
 }
@@ -66,172 +207,104 @@ func jsonResponse(f func() (string, error)) func(http.ResponseWriter, *http.Requ
 	}
 }
 
-func getWeatherData(place string, requestType fmi.RequestType) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		weather, err := fmi.GetWeatherData(fmi.StationId(place), requestType)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, fmt.Sprintf("Error occurred in fetching weather data for %s", place), http.StatusInternalServerError)
-			return
-		}
-		json, err := json.Marshal(weather.WeatherData)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, fmt.Sprintf("Error occurred in fetching weather data for %s", place), http.StatusInternalServerError)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(json)
-	}
-}
+func printEndpoints() {
+	fmt.Println("\nAvailable endpoints:")
+	fmt.Println("-------------------")
+	fmt.Printf("GET /weathernow                  - Current weather observations\n")
+	fmt.Printf("    curl http://localhost:6001/api/weathernow\n")
 
-func getCalendarEvents() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		from := cal.DateOffset{}
-		to := cal.DateOffset{Days: 7}
-		events, err := cal.GetFamilyCalendarEvents(from, to)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, "Error occurred fetching calendar events", http.StatusInternalServerError)
-			return
-		}
-		json, err := json.Marshal(events)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, "Error occurred in json conversion of calendar events", http.StatusInternalServerError)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(json)
-	}
-}
+	fmt.Printf("GET /weatherfore                 - Weather forecast (params: model, parameters, hours; FMI only)\n")
+	fmt.Printf("    curl http://localhost:6001/api/weatherfore\n")
 
-func getSpotPrices() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		startStr := r.URL.Query().Get("start")
-		endStr := r.URL.Query().Get("end")
-		timeFormat := r.URL.Query().Get("timeFormat")
+	fmt.Printf("GET /api/weather/{location}/now  - Current weather observations for a configured location\n")
+	fmt.Printf("    curl http://localhost:6001/api/weather/home/now\n")
 
-		// Default to UTC if timeFormat is not specified
-		if timeFormat == "" {
-			timeFormat = "utc"
-		}
+	fmt.Printf("GET /api/weather/{location}/forecast - Weather forecast for a configured location (params: model, parameters, hours; FMI only)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/home/forecast?model=ecmwf&parameters=extended&hours=120\"\n")
 
-		// Get the location based on timeFormat
-		var location *time.Location
-		var err error
-		switch timeFormat {
-		case "utc":
-			location = time.UTC
-		case "local":
-			location = time.Local
-		default:
-			location, err = time.LoadLocation(timeFormat)
-			if err != nil {
-				log.Error().Err(err).Msg("Invalid timezone format")
-				http.Error(w, "Invalid timezone format", http.StatusBadRequest)
-				return
-			}
-		}
+	fmt.Printf("GET /api/weather/history         - Past observations, default last 7 days (params: from, to, timestep, parameters)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/history?from=2025-03-01T00:00:00Z&to=2025-03-08T00:00:00Z&parameters=t2m\"\n")
 
-		start, err := time.Parse(time.RFC3339, startStr)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, "Invalid start time format. Use RFC3339.", http.StatusBadRequest)
-			return
-		}
+	fmt.Printf("GET /api/weather/daily           - Daily temperature, precipitation, snow and heating degree days (params: from, to, base)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/daily?from=2025-01-01&to=2025-01-31\"\n")
 
-		end, err := time.Parse(time.RFC3339, endStr)
-		if err != nil {
-			log.Err(err).Msg("")
-			http.Error(w, "Invalid end time format. Use RFC3339.", http.StatusBadRequest)
-			return
-		}
+	fmt.Printf("GET /api/weather/monthly         - Monthly aggregates of the daily values (params: from, to, base)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/monthly?from=2024-07-01&to=2025-06-30\"\n")
 
-		log.Info().Msgf("Getting spot prices for %s to %s in %s format", start, end, timeFormat)
-		prices, err := spot.GetPrices(start, end, location)
-		if err != nil {
-			log.Error().Err(err).Msg("Error getting spot prices")
-			http.Error(w, "Error occurred fetching spot prices", http.StatusInternalServerError)
-			return
-		}
+	fmt.Printf("GET /api/weather/stations        - Nearest FMI weather stations (params: lat, lon, n)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/stations?lat=60.2626&lon=25.0308&n=5\"\n")
 
-		json, err := json.Marshal(prices.Prices)
-		if err != nil {
-			log.Error().Err(err).Msg("Error marshalling spot prices to JSON")
-			http.Error(w, "Error occurred in JSON conversion of spot prices", http.StatusInternalServerError)
-			return
-		}
+	fmt.Printf("GET /api/weather/warnings        - Weather warnings in effect for the configured locations (params: lang)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/warnings?lang=en\"\n")
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(json)
-	}
-}
+	fmt.Printf("GET /api/weather/{location}/warnings - Weather warnings in effect for a configured location (params: lang)\n")
+	fmt.Printf("    curl http://localhost:6001/api/weather/home/warnings\n")
 
-func getSunData() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Parse date parameters - only using YYYY-MM-DD format
-		startStr := r.URL.Query().Get("start")
-		endStr := r.URL.Query().Get("end")
+	fmt.Printf("GET /api/weather/lightning       - Lightning strikes near the default location (params: radius, minutes)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/weather/lightning?radius=30&minutes=60\"\n")
 
-		// Parse start date (required)
-		start, err := time.Parse("2006-01-02", startStr)
-		if err != nil {
-			log.Err(err).Msg("Invalid start date format")
-			http.Error(w, "Invalid start date format. Use YYYY-MM-DD (e.g., 2025-03-08).", http.StatusBadRequest)
-			return
-		}
+	fmt.Printf("GET /api/weather/{location}/lightning - Lightning strikes near a configured location (params: radius, minutes)\n")
+	fmt.Printf("    curl http://localhost:6001/api/weather/home/lightning\n")
 
-		// Parse end date (optional)
-		var end time.Time
-		if endStr != "" {
-			end, err = time.Parse("2006-01-02", endStr)
-			if err != nil {
-				log.Err(err).Msg("Invalid end date format")
-				http.Error(w, "Invalid end date format. Use YYYY-MM-DD (e.g., 2025-03-10).", http.StatusBadRequest)
-				return
-			}
-		}
+	fmt.Printf("GET /api/indoor                  - Registered indoor sensors\n")
+	fmt.Printf("    curl http://localhost:6001/api/indoor\n")
 
-		// Load sun data
-		sunData, err := sun.NewSunData()
+	fmt.Printf("GET /api/indoor/{sensor}         - Latest indoor reading of a sensor\n")
+	fmt.Printf("    curl http://localhost:6001/api/indoor/dev_upstairs\n")
 
-		if err != nil {
-			log.Error().Err(err).Msg("Error loading sun data")
-			http.Error(w, "Error occurred in loading sun data", http.StatusInternalServerError)
-			return
-		}
+	fmt.Printf("POST /api/indoor/{sensor}        - Record an indoor reading (body: time, temperature, humidity, battery)\n")
+	fmt.Printf("    curl -X POST -d '{\"temperature\":22.5,\"humidity\":27.4}' http://localhost:6001/api/indoor/dev_upstairs\n")
 
-		// Get data for the single date (ignoring year, only returns one day)
-		dailySunData := sunData.GetSunDataForDateRange(start, end)
-		json, err := json.Marshal(dailySunData)
-		if err != nil {
-			log.Error().Err(err).Msg("Error marshalling sun data to JSON")
-			http.Error(w, "Error occurred in JSON conversion of sun data", http.StatusInternalServerError)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(json)
-	}
-}
+	fmt.Printf("GET /api/indoor/stream           - Live indoor readings as server-sent events (params: sensor)\n")
+	fmt.Printf("    curl -N http://localhost:6001/api/indoor/stream\n")
 
-func printEndpoints() {
-	fmt.Println("\nAvailable endpoints:")
-	fmt.Println("-------------------")
-	fmt.Printf("GET /weathernow                  - Current weather observations\n")
-	fmt.Printf("    curl http://localhost:6001/api/weathernow\n")
+	fmt.Printf("GET /api/indoor/{sensor}/history - Indoor readings of a sensor (params: from, to)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/indoor/dev_upstairs/history?from=2025-03-20T00:00:00Z&to=2025-03-21T00:00:00Z\"\n")
 
-	fmt.Printf("GET /weatherfore                 - Weather forecast\n")
-	fmt.Printf("    curl http://localhost:6001/api/weatherfore\n")
+	fmt.Printf("GET /api/cabinbookings/days/{days} - Cabin booking status of each day from the start of this week\n")
+	fmt.Printf("    curl http://localhost:6001/api/cabinbookings/days/365\n")
 
-	fmt.Printf("GET /indoor/dev_upstairs         - Indoor temperature\n")
-	fmt.Printf("    curl http://localhost:6001/api/indoor/dev_upstairs\n")
+	fmt.Printf("GET /api/cabinbookings           - Cabin bookings (params: from, to, cancelled)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/cabinbookings?from=2025-06-01&to=2025-08-31\"\n")
+
+	fmt.Printf("POST /api/cabinbookings          - Book the cabin (body: name, from, to, note)\n")
+	fmt.Printf("    curl -X POST -d '{\"name\":\"Mika\",\"from\":\"2025-06-20\",\"to\":\"2025-06-22\"}' http://localhost:6001/api/cabinbookings\n")
+
+	fmt.Printf("DELETE /api/cabinbookings/{id}   - Cancel a cabin booking\n")
+	fmt.Printf("    curl -X DELETE http://localhost:6001/api/cabinbookings/1\n")
+
+	fmt.Printf("GET /api/cabinbookings/ical      - Cabin bookings as an iCalendar feed\n")
+	fmt.Printf("    curl http://localhost:6001/api/cabinbookings/ical\n")
+
+	fmt.Printf("GET /api/electricity/current     - Current solar production and grid power\n")
+	fmt.Printf("    curl http://localhost:6001/api/electricity/current\n")
+
+	fmt.Printf("GET /api/electricity/solar/history - Recorded solar samples (params: from, to)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/electricity/solar/history?from=2025-03-20T00:00:00Z&to=2025-03-21T00:00:00Z\"\n")
+
+	fmt.Printf("GET /api/electricity/solar/selfconsumption - Hourly solar production and self-consumption at spot prices (params: from, to)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/electricity/solar/selfconsumption?from=2025-03-20T00:00:00Z&to=2025-03-21T00:00:00Z\"\n")
 
 	fmt.Printf("GET /electricity/prices          - Spot prices for time range (params: start, end, timeFormat)\n")
 	fmt.Printf("    curl \"http://localhost:6001/api/electricity/prices?start=2024-03-20T00:00:00Z&end=2024-03-21T00:00:00Z&timeFormat=Europe/Helsinki\"\n")
 
-	fmt.Printf("GET /api/events                  - Calendar events for next 7 days\n")
-	fmt.Printf("    curl http://localhost:6001/api/events\n")
+	fmt.Printf("GET /api/events                  - Calendar events, next 7 days by default (params: start, end, days, calendars, cancelled)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/events?calendars=Family,Elise&start=2025-03-01&days=14\"\n")
+
+	fmt.Printf("POST /api/events                 - Add a calendar event (body: calendar, summary, start, end, allDay, location, description, categories, status)\n")
+	fmt.Printf("    curl -X POST -d '{\"calendar\":\"Family\",\"summary\":\"Dentist\",\"start\":\"2025-09-04T15:00:00+03:00\",\"end\":\"2025-09-04T16:00:00+03:00\"}' http://localhost:6001/api/events\n")
+
+	fmt.Printf("PUT /api/events/{uid}            - Change a calendar event, or one instance of it (params: recurrenceId, header: If-Match)\n")
+	fmt.Printf("    curl -X PUT -H 'If-Match: \"etag\"' -d '{\"summary\":\"Dentist\",\"start\":\"2025-09-04T16:00:00+03:00\",\"end\":\"2025-09-04T17:00:00+03:00\"}' http://localhost:6001/api/events/uid\n")
+
+	fmt.Printf("DELETE /api/events/{uid}         - Remove a calendar event, or one instance of it (params: recurrenceId, header: If-Match)\n")
+	fmt.Printf("    curl -X DELETE \"http://localhost:6001/api/events/uid?recurrenceId=2025-09-01T17:00:00%%2B03:00\"\n")
+
+	fmt.Printf("GET /api/events/days             - Calendar events split by day, next 7 days by default (params: start, end, days, calendars, cancelled)\n")
+	fmt.Printf("    curl http://localhost:6001/api/events/days\n")
+
+	fmt.Printf("GET /api/holidays                - Finnish public holidays, days off and flag days, also in /api/events as calendar Holidays (params: year)\n")
+	fmt.Printf("    curl \"http://localhost:6001/api/holidays?year=2025\"\n")
 
 	fmt.Printf("GET /api/sun                    - Sunset and runrise info for date range (params: start, end)\n")
 	fmt.Printf("    curl \"http://localhost:6001/api/sun?start=2025-03-20&end=2025-03-21\"\n")
@@ -256,17 +329,46 @@ func main() {
 	}
 
 	// Choose handlers based on mock flag
-	h := createRealHandlers()
+	var h handlers
 	if *useMock {
 		h = createMockHandlers()
+	} else {
+		h = createRealHandlers()
 	}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/weathernow", h.weatherNow)
-	mux.HandleFunc("/api/indoor/dev_upstairs", h.indoorTemp)
+	mux.HandleFunc("/api/indoor", h.indoorSensors)
+	mux.HandleFunc("/api/indoor/stream", h.indoorStream)
+	mux.HandleFunc("/api/indoor/{sensor}", h.indoorReading)
+	mux.HandleFunc("/api/indoor/{sensor}/history", h.indoorHistory)
 	mux.HandleFunc("/api/weatherfore", h.weatherFore)
+	mux.HandleFunc("/api/weather/stations", h.stations)
+	mux.HandleFunc("/api/weather/history", h.weatherHistory)
+	mux.HandleFunc("/api/weather/{location}/history", h.weatherHistory)
+	mux.HandleFunc("/api/weather/daily", h.weatherDaily)
+	mux.HandleFunc("/api/weather/{location}/daily", h.weatherDaily)
+	mux.HandleFunc("/api/weather/monthly", h.weatherMonthly)
+	mux.HandleFunc("/api/weather/{location}/monthly", h.weatherMonthly)
+	mux.HandleFunc("/api/weather/warnings", h.warnings)
+	mux.HandleFunc("/api/weather/{location}/warnings", h.warnings)
+	mux.HandleFunc("/api/weather/lightning", h.lightning)
+	mux.HandleFunc("/api/weather/{location}/lightning", h.lightning)
+	mux.HandleFunc("/api/weather/{location}/now", h.weatherNow)
+	mux.HandleFunc("/api/weather/{location}/forecast", h.weatherFore)
+	mux.HandleFunc("/api/cabinbookings", h.cabinBookings)
+	mux.HandleFunc("/api/cabinbookings/days", h.cabinDays)
+	mux.HandleFunc("/api/cabinbookings/days/{days}", h.cabinDays)
+	mux.HandleFunc("/api/cabinbookings/ical", h.cabinICal)
+	mux.HandleFunc("/api/cabinbookings/{id}", h.cabinBooking)
 	mux.HandleFunc("/api/electricity/prices", h.spotPrices)
+	mux.HandleFunc("/api/electricity/current", h.solarCurrent)
+	mux.HandleFunc("/api/electricity/solar/history", h.solarHistory)
+	mux.HandleFunc("/api/electricity/solar/selfconsumption", h.solarSelfUse)
 	mux.HandleFunc("/api/events", h.calendarEvents)
+	mux.HandleFunc("/api/events/days", h.calendarDays)
+	mux.HandleFunc("/api/events/{uid}", h.calendarEvent)
+	mux.HandleFunc("/api/holidays", h.holidays)
 	mux.HandleFunc("/api/sun", h.sunData)
 
 	// Start server in a goroutine
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+	"github.com/mikahozz/gohome/integrations/sun"
+	"github.com/mikahozz/gohome/integrations/warnings"
+	"github.com/mikahozz/gohome/integrations/weather"
+	"github.com/rs/zerolog/log"
+)
+
+// resolveLocation returns the weather location named in the path, or the
+// default location when the path names none. An unknown location is
+// answered with 404 and ok false.
+func resolveLocation(w http.ResponseWriter, r *http.Request, locations *weather.Locations) (weather.Location, bool) {
+	name := r.PathValue("location")
+	if name == "" {
+		return locations.Default(), true
+	}
+	loc, ok := locations.Get(name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown weather location %s", name), http.StatusNotFound)
+	}
+	return loc, ok
+}
+
+// getWeatherData serves the location named in the path, or the default
+// location for the legacy /api/weathernow and /api/weatherfore routes.
+func getWeatherData(providers *weather.Registry, locations *weather.Locations, requestType fmi.RequestType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc, ok := resolveLocation(w, r, locations)
+		if !ok {
+			return
+		}
+		provider, err := providers.ForLocation(loc)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("No weather provider for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		var data []fmi.WeatherData
+		switch requestType {
+		case fmi.Observations:
+			data, err = provider.Observations(loc)
+		case fmi.Forecast:
+			data, err = provider.Forecast(loc)
+		default:
+			err = fmt.Errorf("invalid requestType: %v", requestType)
+		}
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching weather data for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(data)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching weather data for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+const maxHistoryRange = 31 * 24 * time.Hour
+
+// getWeatherForecast serves the forecast like getWeatherData. When a model,
+// parameters or horizon is requested the forecast comes from the FMI model
+// provider, for locations FMI can serve. If FMI fails the location's
+// providers serve the forecast without the requested options.
+func getWeatherForecast(providers *weather.Registry, models weather.ModelForecastProvider, locations *weather.Locations) http.HandlerFunc {
+	fallback := getWeatherData(providers, locations, fmi.Forecast)
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		if !params.Has("model") && !params.Has("parameters") && !params.Has("hours") {
+			fallback(w, r)
+			return
+		}
+		loc, ok := resolveLocation(w, r, locations)
+		if !ok {
+			return
+		}
+		query := fmi.ForecastQuery{
+			Model:      fmi.ForecastModel(params.Get("model")),
+			Parameters: fmi.ParseParameters(params.Get("parameters")),
+		}
+		if hoursStr := params.Get("hours"); hoursStr != "" {
+			hours, err := strconv.Atoi(hoursStr)
+			if err != nil || hours < 1 {
+				http.Error(w, "Invalid hours. Use a positive number of hours.", http.StatusBadRequest)
+				return
+			}
+			query.Horizon = time.Duration(hours) * time.Hour
+		}
+		if err := query.Validate(); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid forecast query: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		data, err := models.ModelForecast(loc, query)
+		if errors.Is(err, weather.ErrNotSupported) {
+			http.Error(w, fmt.Sprintf("The model, parameters and hours options need an FMI location, not available for %s", loc.Name), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("event", "forecast_model_failed").Str("location", loc.Name).Msg("serving the forecast without the model options")
+			fallback(w, r)
+			return
+		}
+		json, err := json.Marshal(data)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching weather forecast for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getWeatherHistory serves past observations for the location, by default the
+// last 7 days at one hour steps.
+func getWeatherHistory(provider weather.HistoryProvider, locations *weather.Locations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc, ok := resolveLocation(w, r, locations)
+		if !ok {
+			return
+		}
+
+		query := fmi.ObservationQuery{
+			EndTime:  time.Now().UTC().Truncate(time.Hour),
+			Timestep: time.Hour,
+		}
+		if toStr := r.URL.Query().Get("to"); toStr != "" {
+			to, err := time.Parse(time.RFC3339, toStr)
+			if err != nil {
+				http.Error(w, "Invalid to time format. Use RFC3339.", http.StatusBadRequest)
+				return
+			}
+			query.EndTime = to
+		}
+		query.StartTime = query.EndTime.AddDate(0, 0, -7)
+		if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+			from, err := time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				http.Error(w, "Invalid from time format. Use RFC3339.", http.StatusBadRequest)
+				return
+			}
+			query.StartTime = from
+		}
+		if query.EndTime.Sub(query.StartTime) > maxHistoryRange {
+			http.Error(w, "Time range too long. Maximum is 31 days.", http.StatusBadRequest)
+			return
+		}
+		if stepStr := r.URL.Query().Get("timestep"); stepStr != "" {
+			step, err := strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				http.Error(w, "Invalid timestep. Use minutes (e.g., 60).", http.StatusBadRequest)
+				return
+			}
+			query.Timestep = time.Duration(step) * time.Minute
+		}
+		if params := r.URL.Query().Get("parameters"); params != "" {
+			query.Parameters = strings.Split(params, ",")
+		}
+		if err := query.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, err := provider.History(loc, query)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching weather history for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(data)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching weather history for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+const maxDailyRange = 3 * 366 * 24 * time.Hour
+
+// getDailyWeather serves daily or monthly statistics with heating degree days.
+// Dates are in YYYY-MM-DD format and default to the last 30 days.
+func getDailyWeather(provider weather.DailyProvider, locations *weather.Locations, defaultBase float64, monthly bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc, ok := resolveLocation(w, r, locations)
+		if !ok {
+			return
+		}
+
+		now := time.Now().UTC()
+		end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		if toStr := r.URL.Query().Get("to"); toStr != "" {
+			to, err := time.Parse(time.DateOnly, toStr)
+			if err != nil {
+				http.Error(w, "Invalid to date format. Use YYYY-MM-DD (e.g., 2025-03-10).", http.StatusBadRequest)
+				return
+			}
+			end = to
+		}
+		start := end.AddDate(0, 0, -30)
+		if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+			from, err := time.Parse(time.DateOnly, fromStr)
+			if err != nil {
+				http.Error(w, "Invalid from date format. Use YYYY-MM-DD (e.g., 2025-03-08).", http.StatusBadRequest)
+				return
+			}
+			start = from
+		}
+		if !end.After(start) || end.Sub(start) > maxDailyRange {
+			http.Error(w, "Invalid date range. From must be before to and the range at most 3 years.", http.StatusBadRequest)
+			return
+		}
+		base := defaultBase
+		if baseStr := r.URL.Query().Get("base"); baseStr != "" {
+			var err error
+			base, err = strconv.ParseFloat(baseStr, 64)
+			if err != nil {
+				http.Error(w, "Invalid base temperature (e.g., 17).", http.StatusBadRequest)
+				return
+			}
+		}
+
+		days, err := provider.Daily(loc, start, end, base)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching daily weather for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		var result interface{} = days
+		if monthly {
+			result = fmi.MonthlyAggregates(days)
+		}
+		json, err := json.Marshal(result)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred in fetching daily weather for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+func getNearestStations(catalogue *fmi.StationCatalogue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+		if err != nil || lat < -90 || lat > 90 {
+			http.Error(w, "Invalid lat. Use decimal degrees (e.g., 60.2626).", http.StatusBadRequest)
+			return
+		}
+		lon, err := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+		if err != nil || lon < -180 || lon > 180 {
+			http.Error(w, "Invalid lon. Use decimal degrees (e.g., 25.0308).", http.StatusBadRequest)
+			return
+		}
+		n := 5
+		if nStr := r.URL.Query().Get("n"); nStr != "" {
+			n, err = strconv.Atoi(nStr)
+			if err != nil || n < 1 || n > 50 {
+				http.Error(w, "Invalid n. Use a number between 1 and 50.", http.StatusBadRequest)
+				return
+			}
+		}
+
+		stations, err := catalogue.Nearest(lat, lon, n)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred fetching weather stations", http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(stations)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of weather stations", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getLightning serves the lightning strikes near the location named in the
+// path, or near the default location.
+func getLightning(provider weather.LightningProvider, locations *weather.Locations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc, ok := resolveLocation(w, r, locations)
+		if !ok {
+			return
+		}
+		var err error
+		radius := 30.0
+		if radiusStr := r.URL.Query().Get("radius"); radiusStr != "" {
+			radius, err = strconv.ParseFloat(radiusStr, 64)
+			if err != nil || radius <= 0 || radius > fmi.MaxLightningRadiusKm {
+				http.Error(w, fmt.Sprintf("Invalid radius. Use kilometers between 0 and %.0f.", fmi.MaxLightningRadiusKm), http.StatusBadRequest)
+				return
+			}
+		}
+		minutes := 60
+		if minutesStr := r.URL.Query().Get("minutes"); minutesStr != "" {
+			minutes, err = strconv.Atoi(minutesStr)
+			if err != nil || minutes < 1 || time.Duration(minutes)*time.Minute > fmi.MaxLightningRange {
+				http.Error(w, fmt.Sprintf("Invalid minutes. Use a number between 1 and %d.", int(fmi.MaxLightningRange/time.Minute)), http.StatusBadRequest)
+				return
+			}
+		}
+
+		report, err := provider.Lightning(loc, radius, minutes, time.Now())
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, fmt.Sprintf("Error occurred fetching lightning for %s", loc.Name), http.StatusInternalServerError)
+			return
+		}
+		json, err := json.Marshal(report)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of lightning", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+// getWeatherWarnings serves the warnings in effect for the location named in
+// the path, or for all configured locations. A warning covering several
+// locations is listed once with all of them.
+func getWeatherWarnings(source warnings.Source, locations *weather.Locations) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		locs := locations.All()
+		if r.PathValue("location") != "" {
+			loc, ok := resolveLocation(w, r, locations)
+			if !ok {
+				return
+			}
+			locs = []weather.Location{loc}
+		}
+		lang := r.URL.Query().Get("lang")
+		switch lang {
+		case "":
+			lang = "fi"
+		case "fi", "sv", "en":
+		default:
+			http.Error(w, "Invalid lang. Use fi, sv or en.", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now()
+		active := []warnings.Warning{}
+		byID := map[string]int{}
+		for _, loc := range locs {
+			area := warnings.Area{Name: loc.Name, Municipality: loc.Municipality, Lat: loc.Lat, Lon: loc.Lon}
+			found, err := source.Active(area, now, lang)
+			if err != nil {
+				log.Err(err).Msg("")
+				http.Error(w, "Error occurred fetching weather warnings", http.StatusInternalServerError)
+				return
+			}
+			for _, warning := range found {
+				i, ok := byID[warning.ID]
+				if !ok {
+					i = len(active)
+					byID[warning.ID] = i
+					active = append(active, warning)
+				}
+				active[i].Locations = append(active[i].Locations, loc.Name)
+			}
+		}
+		json, err := json.Marshal(active)
+		if err != nil {
+			log.Err(err).Msg("")
+			http.Error(w, "Error occurred in json conversion of weather warnings", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
+
+func getSunData() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Parse date parameters - only using YYYY-MM-DD format
+		startStr := r.URL.Query().Get("start")
+		endStr := r.URL.Query().Get("end")
+
+		// Parse start date (required)
+		start, err := time.Parse("2006-01-02", startStr)
+		if err != nil {
+			log.Err(err).Msg("Invalid start date format")
+			http.Error(w, "Invalid start date format. Use YYYY-MM-DD (e.g., 2025-03-08).", http.StatusBadRequest)
+			return
+		}
+
+		// Parse end date (optional)
+		var end time.Time
+		if endStr != "" {
+			end, err = time.Parse("2006-01-02", endStr)
+			if err != nil {
+				log.Err(err).Msg("Invalid end date format")
+				http.Error(w, "Invalid end date format. Use YYYY-MM-DD (e.g., 2025-03-10).", http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Load sun data
+		sunData, err := sun.NewSunData()
+
+		if err != nil {
+			log.Error().Err(err).Msg("Error loading sun data")
+			http.Error(w, "Error occurred in loading sun data", http.StatusInternalServerError)
+			return
+		}
+
+		// Get data for the single date (ignoring year, only returns one day)
+		dailySunData := sunData.GetSunDataForDateRange(start, end)
+		json, err := json.Marshal(dailySunData)
+		if err != nil {
+			log.Error().Err(err).Msg("Error marshalling sun data to JSON")
+			http.Error(w, "Error occurred in JSON conversion of sun data", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(json)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mikahozz/gohome/integrations/holidays"
 	"github.com/rs/zerolog/log"
 )
 
@@ -29,9 +30,36 @@ func (rc *RealClock) After(d time.Duration) <-chan time.Time {
 type FilterType string
 
 const (
-	FilterDate FilterType = "date"
+	FilterDate        FilterType = "date"
+	FilterWarning     FilterType = "warning"      // passes while a matching weather warning is in effect
+	FilterNoWarning   FilterType = "no_warning"   // passes while none is, e.g. skip irrigation during storms
+	FilterLightning   FilterType = "lightning"    // passes while lightning strikes nearby
+	FilterNoLightning FilterType = "no_lightning" // passes while there is no lightning nearby
+	FilterSurplus     FilterType = "surplus"      // passes while solar export is in surplus
+	FilterNoSurplus   FilterType = "no_surplus"   // passes while it is not
+	FilterCalendar    FilterType = "calendar"     // passes on days with a matching calendar event, e.g. school days
+	FilterNoCalendar  FilterType = "no_calendar"  // passes on days without one, e.g. skip days away
+	FilterHoliday     FilterType = "holiday"      // passes on Finnish public holidays and days off, by default by holidays.Condition
+	FilterNoHoliday   FilterType = "no_holiday"   // passes on other days
+	FilterWeekday     FilterType = "weekday"      // passes Monday to Friday, needs no condition
+	FilterWeekend     FilterType = "weekend"      // passes on Saturday and Sunday, needs no condition
 )
 
+// conditionFilters are the filters on a Condition, by the state of the
+// condition they pass on.
+var conditionFilters = map[FilterType]bool{
+	FilterWarning:     true,
+	FilterNoWarning:   false,
+	FilterLightning:   true,
+	FilterNoLightning: false,
+	FilterSurplus:     true,
+	FilterNoSurplus:   false,
+	FilterCalendar:    true,
+	FilterNoCalendar:  false,
+	FilterHoliday:     true,
+	FilterNoHoliday:   false,
+}
+
 type AndOrType string
 
 const (
@@ -39,8 +67,41 @@ const (
 	OR  AndOrType = "or"
 )
 
+// Trigger fires a schedule daily at Time or, with a Condition, whenever the
+// condition turns to When. A condition trigger fires once per change and
+// also at start if the condition already is When, so the action leaves
+// things in a known state. Debouncing is up to the condition.
 type Trigger struct {
-	Time func() time.Time
+	Time      func() time.Time
+	Condition Condition
+	When      bool
+}
+
+// Condition is a state the scheduler follows, e.g. *solar.Surplus.
+type Condition interface {
+	Active(now time.Time) (bool, error)
+}
+
+// Shared evaluates a condition once per evaluation cycle for the schedules
+// following it, e.g. the ON and OFF schedules of a *solar.Surplus, whose
+// debouncing state would advance on every check.
+type Shared struct {
+	Condition Condition
+
+	mu     sync.Mutex
+	at     time.Time
+	active bool
+	err    error
+}
+
+func (c *Shared) Active(now time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.at.IsZero() || !now.Equal(c.at) {
+		c.active, c.err = c.Condition.Active(now)
+		c.at = now
+	}
+	return c.active, c.err
 }
 
 type Comparator string
@@ -55,6 +116,7 @@ type Filter struct {
 	Type       FilterType
 	Date       time.Time
 	Comparator Comparator
+	Condition  Condition // for the condition filters, e.g. warnings.Condition; optional for the holiday filters
 }
 
 type DailySchedule struct {
@@ -63,8 +125,10 @@ type DailySchedule struct {
 	Trigger       Trigger
 	FilterLogic   AndOrType
 	Filters       []Filter
+	Location      *time.Location // zone of the days the filters check, by default that of now
 	Action        func(context.Context) error
 	LastTriggered time.Time
+	fired         bool // condition trigger has fired since the condition turned to When
 }
 
 type Scheduler struct {
@@ -92,8 +156,14 @@ func NewSchedulerWithClock(clock Clock) *Scheduler {
 	}
 }
 
-// AddSchedule adds a schedule to the scheduler
+// AddSchedule adds a schedule to the scheduler. A condition filter without a
+// condition is logged and never passes.
 func (s *Scheduler) AddSchedule(schedule *DailySchedule) {
+	for _, filter := range schedule.Filters {
+		if _, ok := conditionFilters[filter.Type]; ok && filter.Condition == nil && !holidayFilter(filter.Type) {
+			log.Error().Str("event", "filter_without_condition").Str("schedule", schedule.Name).Str("filter", string(filter.Type)).Msg("the filter never passes")
+		}
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.schedules = append(s.schedules, schedule)
@@ -131,10 +201,19 @@ func (s *Scheduler) run() {
 // evaluate checks all schedules and executes matching ones
 func (s *Scheduler) evaluate(now time.Time) {
 	s.mu.RLock()
-	schedules := make([]*DailySchedule, len(s.schedules))
-	copy(schedules, s.schedules)
+	all := make([]*DailySchedule, len(s.schedules))
+	copy(all, s.schedules)
 	s.mu.RUnlock()
 
+	schedules := make([]*DailySchedule, 0, len(all))
+	for _, sch := range all {
+		if sch.Trigger.Condition != nil {
+			s.evaluateCondition(sch, now)
+			continue
+		}
+		schedules = append(schedules, sch)
+	}
+
 	// Track, per category, the latest trigger time that has already fired today.
 	triggeredMax := make(map[string]time.Time)
 	for _, sch := range schedules {
@@ -187,23 +266,7 @@ func (s *Scheduler) evaluate(now time.Time) {
 		isWinner := candidates[catKey] == sch && eligible[sch]
 		if isWinner {
 			s.logScheduleTrigger(sch, now)
-			go func(sch *DailySchedule) {
-				start := s.clock.Now()
-				s.logActionStart(sch, start)
-				defer func() {
-					if r := recover(); r != nil {
-						s.logActionPanic(sch, r)
-					}
-				}()
-				if err := sch.Action(s.ctx); err != nil {
-					log.Error().Err(err).Str("event", "action_error").Str("schedule", sch.Name).Msg("action failed; will retry next cycle")
-				} else {
-					s.mu.Lock()
-					sch.LastTriggered = now
-					s.mu.Unlock()
-					s.logActionFinish(sch, start)
-				}
-			}(sch)
+			go s.execute(sch, now, nil)
 			continue
 		}
 		// Derive skip reason
@@ -226,6 +289,62 @@ func (s *Scheduler) evaluate(now time.Time) {
 	}
 }
 
+// evaluateCondition fires a condition triggered schedule when its condition
+// has turned to the wanted state, and re-arms it when the condition leaves
+// it.
+func (s *Scheduler) evaluateCondition(sch *DailySchedule, now time.Time) {
+	active, err := sch.Trigger.Condition.Active(now)
+	if err != nil {
+		log.Warn().Err(err).Str("event", "condition_failed").Str("schedule", sch.Name).Msg("skipping until the condition is available")
+		return
+	}
+	s.mu.Lock()
+	if active != sch.Trigger.When {
+		sch.fired = false
+	}
+	fire := active == sch.Trigger.When && !sch.fired
+	s.mu.Unlock()
+	if !fire {
+		return
+	}
+	if !s.filtersPass(sch, now) {
+		s.logScheduleSkip(sch, now, time.Time{}, "filters_not_passed")
+		return
+	}
+	// Marked before running so a slow action is not started twice, a failed
+	// one is retried next cycle
+	s.mu.Lock()
+	sch.fired = true
+	s.mu.Unlock()
+	s.logScheduleTrigger(sch, now)
+	go s.execute(sch, now, func() { sch.fired = false })
+}
+
+// execute runs the action of the schedule and records when it succeeded.
+// failed is called with the lock held if the action fails.
+func (s *Scheduler) execute(sch *DailySchedule, now time.Time, failed func()) {
+	start := s.clock.Now()
+	s.logActionStart(sch, start)
+	defer func() {
+		if r := recover(); r != nil {
+			s.logActionPanic(sch, r)
+		}
+	}()
+	if err := sch.Action(s.ctx); err != nil {
+		log.Error().Err(err).Str("event", "action_error").Str("schedule", sch.Name).Msg("action failed; will retry next cycle")
+		if failed != nil {
+			s.mu.Lock()
+			failed()
+			s.mu.Unlock()
+		}
+		return
+	}
+	s.mu.Lock()
+	sch.LastTriggered = now
+	s.mu.Unlock()
+	s.logActionFinish(sch, start)
+}
+
 // shouldTrigger checks if the trigger condition is met
 func (s *Scheduler) shouldTrigger(schedule *DailySchedule, now time.Time) bool {
 	if hasTriggeredThisPeriod(schedule, now) {
@@ -251,6 +370,9 @@ func (s *Scheduler) filtersPass(schedule *DailySchedule, now time.Time) bool {
 	if len(schedule.Filters) == 0 {
 		return true
 	}
+	if schedule.Location != nil {
+		now = now.In(schedule.Location)
+	}
 
 	if schedule.FilterLogic == OR {
 		for _, filter := range schedule.Filters {
@@ -270,7 +392,12 @@ func (s *Scheduler) filtersPass(schedule *DailySchedule, now time.Time) bool {
 	return true
 }
 
-// filterPass checks if a single filter passes
+func holidayFilter(t FilterType) bool {
+	return t == FilterHoliday || t == FilterNoHoliday
+}
+
+// filterPass checks if a single filter passes. Days are those of now's
+// location.
 func (s *Scheduler) filterPass(filter Filter, now time.Time) bool {
 	switch filter.Type {
 	case FilterDate:
@@ -286,14 +413,43 @@ func (s *Scheduler) filterPass(filter Filter, now time.Time) bool {
 		default:
 			log.Info().Msg("No filter matched for: " + filter.Date.String())
 		}
+	case FilterWeekday, FilterWeekend:
+		weekend := now.Weekday() == time.Saturday || now.Weekday() == time.Sunday
+		return weekend == (filter.Type == FilterWeekend)
+	default:
+		want, ok := conditionFilters[filter.Type]
+		if !ok {
+			break
+		}
+		condition := filter.Condition
+		if condition == nil && holidayFilter(filter.Type) {
+			condition = holidays.Condition{}
+		}
+		if condition == nil {
+			// Fail closed: a filter without a condition is a configuration
+			// error, not missing data
+			return false
+		}
+		active, err := condition.Active(now)
+		if err != nil {
+			// Fail open: unavailable data counts as the condition not being
+			// in effect, e.g. no warning or no lightning
+			log.Warn().Err(err).Str("event", "filter_condition_failed").Str("filter", string(filter.Type)).Msg("treating the condition as inactive")
+			active = false
+		}
+		return active == want
 	}
 	return true
 }
 
 // --- Logging helpers (centralized formatting) ---
 func (s *Scheduler) logScheduleAdded(schedule *DailySchedule) {
-	trigInfo := schedule.Trigger.Time().Format(time.RFC3339)
-	evt := log.Info().Str("event", "schedule_added").Str("name", schedule.Name).Str("trigger_time", trigInfo).Int("filters", len(schedule.Filters))
+	evt := log.Info().Str("event", "schedule_added").Str("name", schedule.Name).Int("filters", len(schedule.Filters))
+	if schedule.Trigger.Condition != nil {
+		evt = evt.Bool("trigger_when", schedule.Trigger.When)
+	} else {
+		evt = evt.Str("trigger_time", schedule.Trigger.Time().Format(time.RFC3339))
+	}
 	if schedule.Category != "" {
 		evt = evt.Str("category", schedule.Category)
 	}
@@ -3,9 +3,15 @@ package main
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mikahozz/gohome/integrations/cal"
+	"github.com/mikahozz/gohome/integrations/fmi"
+	"github.com/mikahozz/gohome/integrations/holidays"
+	"github.com/mikahozz/gohome/integrations/solar"
+	"github.com/mikahozz/gohome/integrations/warnings"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -264,3 +270,368 @@ func TestTimezoneTrigger(t *testing.T) {
 		t.Fatalf("expected LastTriggered to be set")
 	}
 }
+
+type fakeWarnings struct {
+	alerts []warnings.Alert
+	err    error
+}
+
+func (f *fakeWarnings) InEffect(area warnings.Area, now time.Time) ([]warnings.Alert, error) {
+	return f.alerts, f.err
+}
+
+func TestWarningFilters(t *testing.T) {
+	now := time.Now()
+	storm := &fakeWarnings{alerts: []warnings.Alert{{Identifier: "wind-1", Infos: []warnings.Info{
+		{Language: "fi-FI", Event: "Tuulivaroitus maa-alueille", Severity: "Moderate"},
+		{Language: "en-GB", Event: "Wind warning for land areas", Severity: "Moderate"},
+	}}}}
+	calm := &fakeWarnings{}
+	down := &fakeWarnings{err: assert.AnError}
+	wind := warnings.Match{Events: []string{"wind"}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"irrigate when calm", Filter{Type: FilterNoWarning, Condition: warnings.Condition{Source: calm, Match: wind}}, true},
+		{"skip irrigation in storm", Filter{Type: FilterNoWarning, Condition: warnings.Condition{Source: storm, Match: wind}}, false},
+		{"other warnings don't matter", Filter{Type: FilterNoWarning, Condition: warnings.Condition{Source: storm, Match: warnings.Match{Events: []string{"rain"}}}}, true},
+		{"warning in effect", Filter{Type: FilterWarning, Condition: warnings.Condition{Source: storm, Match: wind}}, true},
+		{"no warning in effect", Filter{Type: FilterWarning, Condition: warnings.Condition{Source: calm, Match: wind}}, false},
+		{"feed down counts as no warning", Filter{Type: FilterNoWarning, Condition: warnings.Condition{Source: down, Match: wind}}, true},
+		{"filter without a condition never passes", Filter{Type: FilterNoWarning}, false},
+	}
+	s := NewScheduler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.filterPass(tt.filter, now))
+		})
+	}
+}
+
+type fakeLightning struct {
+	strikes int
+	err     error
+}
+
+func (f *fakeLightning) GetLightning(q fmi.LightningQuery) (fmi.LightningReport, error) {
+	return fmi.LightningReport{Count: f.strikes}, f.err
+}
+
+func TestLightningFilters(t *testing.T) {
+	now := time.Now()
+	condition := func(source fmi.LightningSource) *fmi.LightningCondition {
+		return &fmi.LightningCondition{Source: source, Lat: 60.2626, Lon: 25.0308, RadiusKm: 20, Window: 30 * time.Minute}
+	}
+	storm := condition(&fakeLightning{strikes: 3})
+	calm := condition(&fakeLightning{})
+	down := condition(&fakeLightning{err: assert.AnError})
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"plug off during lightning", Filter{Type: FilterLightning, Condition: storm}, true},
+		{"plug stays on when calm", Filter{Type: FilterLightning, Condition: calm}, false},
+		{"plug back on when calm", Filter{Type: FilterNoLightning, Condition: calm}, true},
+		{"plug not back on during lightning", Filter{Type: FilterNoLightning, Condition: storm}, false},
+		{"data unavailable counts as no lightning", Filter{Type: FilterLightning, Condition: down}, false},
+	}
+	s := NewScheduler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.filterPass(tt.filter, now))
+		})
+	}
+}
+
+// fakeCondition reports the states in turn, repeating the last one.
+type fakeCondition struct {
+	mu     sync.Mutex
+	states []bool
+	err    error
+}
+
+func (f *fakeCondition) Active(now time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := f.states[0]
+	if len(f.states) > 1 {
+		f.states = f.states[1:]
+	}
+	return state, f.err
+}
+
+func (f *fakeCondition) set(states ...bool) {
+	f.mu.Lock()
+	f.states = states
+	f.mu.Unlock()
+}
+
+func TestConditionTrigger(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	executed := []string{}
+	fail := false
+	act := func(name string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				return assert.AnError
+			}
+			executed = append(executed, name)
+			return nil
+		}
+	}
+	surplus := &fakeCondition{states: []bool{false}}
+	s := NewScheduler()
+	s.AddSchedule(&DailySchedule{Name: "Load ON", Trigger: Trigger{Condition: surplus, When: true}, Action: act("on")})
+	s.AddSchedule(&DailySchedule{Name: "Load OFF", Trigger: Trigger{Condition: surplus, When: false}, Action: act("off")})
+	evaluate := func(states ...bool) {
+		surplus.set(states...)
+		s.evaluate(now)
+		time.Sleep(50 * time.Millisecond)
+	}
+	failing := func(f bool) {
+		mu.Lock()
+		fail = f
+		mu.Unlock()
+	}
+	got := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, executed...)
+	}
+
+	// Off once at start, not again while it stays off
+	evaluate(false)
+	evaluate(false)
+	assert.Equal(t, []string{"off"}, got())
+
+	evaluate(true)
+	evaluate(true)
+	assert.Equal(t, []string{"off", "on"}, got())
+
+	// A failed action is retried next cycle
+	failing(true)
+	evaluate(false)
+	failing(false)
+	evaluate(false)
+	evaluate(false)
+	assert.Equal(t, []string{"off", "on", "off"}, got())
+
+	// Unavailable condition changes nothing
+	surplus.mu.Lock()
+	surplus.err = assert.AnError
+	surplus.mu.Unlock()
+	evaluate(true)
+	assert.Equal(t, []string{"off", "on", "off"}, got())
+}
+
+func TestSharedCondition(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	executed := []string{}
+	act := func(name string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			executed = append(executed, name)
+			return nil
+		}
+	}
+	// A condition whose state changes on every check, like the debouncing of
+	// solar.Surplus, is checked once per cycle for both schedules
+	surplus := &fakeCondition{states: []bool{true, false, false}}
+	shared := &Shared{Condition: surplus}
+	s := NewScheduler()
+	s.AddSchedule(&DailySchedule{Name: "Load ON", Trigger: Trigger{Condition: shared, When: true}, Action: act("on")})
+	s.AddSchedule(&DailySchedule{Name: "Load OFF", Trigger: Trigger{Condition: shared, When: false}, Action: act("off")})
+
+	s.evaluate(now)
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"on"}, executed)
+	mu.Unlock()
+	s.evaluate(now.Add(time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"on", "off"}, executed)
+	mu.Unlock()
+}
+
+func TestConditionTriggerFilters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var executed atomic.Int32
+	s := NewScheduler()
+	s.AddSchedule(&DailySchedule{
+		Name:    "Load ON in summer",
+		Trigger: Trigger{Condition: &fakeCondition{states: []bool{true}}, When: true},
+		Filters: []Filter{{Type: FilterDate, Comparator: GreaterThan, Date: now}},
+		Action: func(ctx context.Context) error {
+			executed.Add(1)
+			return nil
+		},
+	})
+	s.evaluate(now)
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, executed.Load())
+	s.evaluate(now.Add(time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), executed.Load())
+}
+
+func TestSurplusFilters(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := solar.NewMemoryStore()
+	for m := 0; m <= 15; m++ {
+		grid := -2000.0
+		store.Save(context.Background(), solar.Sample{Time: now.Add(time.Duration(m-15) * time.Minute), PowerW: 3000, GridW: &grid})
+	}
+	surplus := func(source solar.HistorySource) *solar.Surplus {
+		return &solar.Surplus{Source: source, OnAboveW: 1500, OnFor: 10 * time.Minute, OffFor: 5 * time.Minute}
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"exporting", Filter{Type: FilterSurplus, Condition: surplus(store)}, true},
+		{"not exporting", Filter{Type: FilterNoSurplus, Condition: surplus(store)}, false},
+		{"no production", Filter{Type: FilterNoSurplus, Condition: surplus(solar.NewMemoryStore())}, true},
+		{"filter without a condition never passes", Filter{Type: FilterNoSurplus}, false},
+	}
+	s := NewScheduler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.filterPass(tt.filter, now))
+		})
+	}
+}
+
+// memoryCalendar serves events from memory, or fails with err.
+type memoryCalendar struct {
+	events []cal.Event
+	err    error
+}
+
+func (m *memoryCalendar) Events(start, end time.Time, includeCancelled bool, calendars ...string) ([]cal.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var found []cal.Event
+	for _, e := range m.events {
+		if e.Start.Before(end) && e.End.After(start) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func TestCalendarFilters(t *testing.T) {
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, zone)
+	source := &memoryCalendar{events: []cal.Event{
+		{Summary: "Cabin trip", Start: monday.AddDate(0, 0, 4), End: monday.AddDate(0, 0, 7), AllDay: true, Categories: []string{"Away"}},
+		{Summary: "Lessons", Start: monday.Add(8 * time.Hour), End: monday.Add(14 * time.Hour), Categories: []string{"School"}},
+	}}
+	view := cal.NewView(source, time.Minute)
+	away := cal.DayCondition{View: view, Match: cal.Match{Categories: []string{"Away"}, AllDay: true}, Location: zone}
+	school := cal.DayCondition{View: view, Match: cal.Match{Categories: []string{"School"}}, Location: zone}
+	down := cal.DayCondition{View: cal.NewView(&memoryCalendar{err: assert.AnError}, time.Minute), Location: zone}
+	morning := monday.Add(6*time.Hour + 45*time.Minute)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		now    time.Time
+		want   bool
+	}{
+		{"at home", Filter{Type: FilterNoCalendar, Condition: away}, morning, true},
+		{"skip days away", Filter{Type: FilterNoCalendar, Condition: away}, morning.AddDate(0, 0, 5), false},
+		{"away", Filter{Type: FilterCalendar, Condition: away}, morning.AddDate(0, 0, 4), true},
+		{"school day", Filter{Type: FilterCalendar, Condition: school}, morning, true},
+		{"no school", Filter{Type: FilterCalendar, Condition: school}, morning.AddDate(0, 0, 1), false},
+		{"unavailable calendar counts as no event", Filter{Type: FilterCalendar, Condition: down}, morning, false},
+		{"unavailable calendar passes no event", Filter{Type: FilterNoCalendar, Condition: down}, morning, true},
+	}
+	s := NewScheduler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.filterPass(tt.filter, tt.now))
+		})
+	}
+}
+
+func TestCalendarTrigger(t *testing.T) {
+	start := time.Date(2025, 9, 5, 18, 0, 0, 0, zone)
+	source := &memoryCalendar{events: []cal.Event{
+		{Summary: "Sauna", Start: start, End: start.Add(2 * time.Hour), Categories: []string{"sauna"}},
+	}}
+	sauna := cal.EventCondition{View: cal.NewView(source, time.Minute), Match: cal.Match{Categories: []string{"sauna"}}, Before: 15 * time.Minute}
+	var mu sync.Mutex
+	executed := []string{}
+	act := func(name string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			executed = append(executed, name)
+			return nil
+		}
+	}
+	s := NewScheduler()
+	s.AddSchedule(&DailySchedule{Name: "Sauna ON", Trigger: Trigger{Condition: sauna, When: true}, Action: act("on")})
+	s.AddSchedule(&DailySchedule{Name: "Sauna OFF", Trigger: Trigger{Condition: sauna, When: false}, Action: act("off")})
+	got := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, executed...)
+	}
+
+	for _, at := range []time.Duration{-30 * time.Minute, -16 * time.Minute, -15 * time.Minute, time.Hour, 2 * time.Hour} {
+		s.evaluate(start.Add(at))
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Equal(t, []string{"off", "on", "off"}, got())
+}
+
+func TestHolidayFilters(t *testing.T) {
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 6, 45, 0, 0, zone)
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		now    time.Time
+		want   bool
+	}{
+		{"weekday", Filter{Type: FilterWeekday}, at(time.June, 18), true},
+		{"saturday", Filter{Type: FilterWeekday}, at(time.June, 21), false},
+		{"weekend", Filter{Type: FilterWeekend}, at(time.June, 22), true},
+		{"midsummer eve", Filter{Type: FilterHoliday}, at(time.June, 20), true},
+		{"working day", Filter{Type: FilterNoHoliday}, at(time.June, 18), true},
+		{"flag day is no holiday", Filter{Type: FilterNoHoliday}, at(time.June, 4), true},
+		{"christmas eve", Filter{Type: FilterNoHoliday}, at(time.December, 24), false},
+		{"midsummer day", Filter{Type: FilterNoHoliday}, at(time.June, 21), false},
+		{"holiday condition", Filter{Type: FilterHoliday, Condition: holidays.Condition{Location: zone}}, time.Date(2025, 12, 5, 23, 30, 0, 0, time.UTC), true},
+		{"day of now", Filter{Type: FilterHoliday}, time.Date(2025, 12, 5, 23, 30, 0, 0, time.UTC), false},
+	}
+	s := NewScheduler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.filterPass(tt.filter, tt.now))
+		})
+	}
+
+	// Morning lights on working days only, on the days of the schedule's
+	// zone even when the clock is in UTC
+	morning := &DailySchedule{Filters: []Filter{{Type: FilterWeekday}, {Type: FilterNoHoliday}}, Location: zone}
+	for day, want := range map[int]bool{18: true, 19: true, 20: false, 21: false, 23: true} {
+		assert.Equal(t, want, s.filtersPass(morning, at(time.June, day).UTC()), "June %d", day)
+	}
+	// Independence Day, a Friday, starts in Helsinki while it is Thursday in UTC
+	assert.False(t, s.filtersPass(morning, time.Date(2024, 12, 5, 22, 30, 0, 0, time.UTC)))
+}
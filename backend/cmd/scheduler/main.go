@@ -5,7 +5,11 @@ import (
 	"os"
 	"time"
 
+	"github.com/mikahozz/gohome/config"
+	"github.com/mikahozz/gohome/db"
+	"github.com/mikahozz/gohome/integrations/cal"
 	"github.com/mikahozz/gohome/integrations/shelly"
+	"github.com/mikahozz/gohome/integrations/solar"
 	"github.com/mikahozz/gohome/integrations/sun"
 	"github.com/rs/zerolog/log"
 )
@@ -34,6 +38,94 @@ func getSunsetTimeToday() time.Time {
 	return data.Sunset
 }
 
+// addSurplusSchedules switches the load on the Shelly plug at
+// SHELLY_SURPLUS_BASE_URL on while solar export is in surplus, configured by
+// solar.LoadSurplus from the production recorded by the API.
+func addSurplusSchedules(scheduler *Scheduler) {
+	baseURL := os.Getenv("SHELLY_SURPLUS_BASE_URL")
+	if baseURL == "" {
+		return
+	}
+	conn, err := db.Open()
+	if conn == nil {
+		log.Fatal().Err(err).Msg("Invalid database configuration")
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Database not available, surplus is off until it is")
+	}
+	surplus, err := solar.LoadSurplus(solar.NewPostgresStore(conn))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid solar surplus configuration")
+	}
+	if surplus == nil {
+		log.Warn().Str("event", "surplus_not_configured").Msg("SHELLY_SURPLUS_BASE_URL set without SOLAR_SURPLUS_ON_W")
+		return
+	}
+	// The ON and OFF schedules see the same check of the surplus
+	condition := &Shared{Condition: surplus}
+	load := shelly.NewShellyClient(baseURL, nil)
+	set := func(on bool) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := load.Set(ctx, on, true, 10*time.Second)
+			return err
+		}
+	}
+	scheduler.AddSchedule(&DailySchedule{
+		Name:    "Surplus load ON",
+		Trigger: Trigger{Condition: condition, When: true},
+		Action:  set(true),
+	})
+	scheduler.AddSchedule(&DailySchedule{
+		Name:    "Surplus load OFF",
+		Trigger: Trigger{Condition: condition, When: false},
+		Action:  set(false),
+	})
+}
+
+// loadCalendarView syncs the calendars configured by cal.LoadConfig in the
+// background for the calendar filters and triggers, or returns nil if none
+// are configured.
+func loadCalendarView() *cal.View {
+	cfg, err := cal.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid calendar configuration")
+	}
+	if len(cfg.Sources) == 0 {
+		return nil
+	}
+	store := cal.NewStore(cfg)
+	go cal.NewSyncer(cfg, store, cfg.SyncInterval).Run(context.Background())
+	return cal.NewView(store, 5*time.Minute)
+}
+
+// addSaunaSchedules heats the sauna on the Shelly plug at
+// SHELLY_SAUNA_BASE_URL from 15 minutes before an event in the sauna
+// category until the event ends.
+func addSaunaSchedules(scheduler *Scheduler, view *cal.View) {
+	baseURL := os.Getenv("SHELLY_SAUNA_BASE_URL")
+	if baseURL == "" || view == nil {
+		return
+	}
+	sauna := cal.EventCondition{View: view, Match: cal.Match{Categories: []string{"sauna"}}, Before: 15 * time.Minute}
+	plug := shelly.NewShellyClient(baseURL, nil)
+	set := func(on bool) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := plug.Set(ctx, on, true, 10*time.Second)
+			return err
+		}
+	}
+	scheduler.AddSchedule(&DailySchedule{
+		Name:    "Sauna ON before a sauna event",
+		Trigger: Trigger{Condition: sauna, When: true},
+		Action:  set(true),
+	})
+	scheduler.AddSchedule(&DailySchedule{
+		Name:    "Sauna OFF after a sauna event",
+		Trigger: Trigger{Condition: sauna, When: false},
+		Action:  set(false),
+	})
+}
+
 func main() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -45,7 +137,22 @@ func main() {
 		}
 	}()
 
+	// The helpers below read their configuration from the environment
+	config.LoadEnv()
 	scheduler := NewScheduler()
+	view := loadCalendarView()
+	// Morning lights only on working days, and not on days away marked with
+	// an all-day event in the Away category
+	morningFilters := []Filter{
+		{Type: FilterWeekday},
+		{Type: FilterNoHoliday},
+	}
+	if view != nil {
+		morningFilters = append(morningFilters, Filter{
+			Type:      FilterNoCalendar,
+			Condition: cal.DayCondition{View: view, Match: cal.Match{Categories: []string{"Away"}, AllDay: true}, Location: zone},
+		})
+	}
 	scheduler.AddSchedule(&DailySchedule{
 		Name:     "Night lights ON at sunset",
 		Category: "night_lights",
@@ -75,7 +182,9 @@ func main() {
 				return time.Date(now.Year(), now.Month(), now.Day(), 6, 45, 0, 0, zone)
 			},
 		},
-		Action: func(ctx context.Context) error { return shelly.TurnOn(ctx) },
+		Filters:  morningFilters,
+		Location: zone,
+		Action:   func(ctx context.Context) error { return shelly.TurnOn(ctx) },
 	})
 	scheduler.AddSchedule(&DailySchedule{
 		Name:     "Morning lights OFF at sunrise",
@@ -85,6 +194,8 @@ func main() {
 		},
 		Action: func(ctx context.Context) error { return shelly.TurnOff(ctx) },
 	})
+	addSurplusSchedules(scheduler)
+	addSaunaSchedules(scheduler, view)
 	scheduler.Start()
 	defer scheduler.Stop()
 
@@ -0,0 +1,65 @@
+// Package db opens the PostgreSQL database the services share. The schema
+// is created by the scripts in db/init when the database container starts.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// ConnString builds the connection string from the environment:
+//
+//	POSTGRES_HOST=localhost
+//	POSTGRES_PORT=5432
+//	POSTGRES_DB=gohome
+//	POSTGRES_SSLMODE=disable
+//	DB_APP_USER=app
+//	DB_APP_PASSWORD=secret
+func ConnString() string {
+	host := envOr("POSTGRES_HOST", "localhost")
+	port := envOr("POSTGRES_PORT", "5432")
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(os.Getenv("DB_APP_USER"), os.Getenv("DB_APP_PASSWORD")),
+		Host:     host + ":" + port,
+		Path:     os.Getenv("POSTGRES_DB"),
+		RawQuery: "sslmode=" + envOr("POSTGRES_SSLMODE", "disable"),
+	}
+	return u.String()
+}
+
+// Configured reports whether a database is configured with POSTGRES_DB.
+func Configured() bool {
+	return os.Getenv("POSTGRES_DB") != ""
+}
+
+// Open opens the database and checks the connection. The returned pool is
+// usable even when the check fails, connections are retried on use.
+func Open() (*sql.DB, error) {
+	db, err := sql.Open("postgres", ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	db.SetMaxOpenConns(10)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		return db, fmt.Errorf("connect to database: %w", err)
+	}
+	return db, nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
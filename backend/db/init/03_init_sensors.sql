@@ -0,0 +1,14 @@
+-- Registered indoor sensors. Their readings are stored in measurements with
+-- the sensor id as sensor_id.
+CREATE TABLE sensors (
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    room VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_sensors_updated_at
+    BEFORE UPDATE ON sensors
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,22 @@
+-- Cabin bookings. Days are inclusive, and active bookings may not overlap.
+-- Cancelled bookings are kept with their cancellation time.
+CREATE TABLE cabin_bookings (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date),
+    EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&)
+        WHERE (cancelled_at IS NULL)
+);
+
+CREATE INDEX idx_cabin_bookings_dates ON cabin_bookings (start_date, end_date);
+
+CREATE TRIGGER update_cabin_bookings_updated_at
+    BEFORE UPDATE ON cabin_bookings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
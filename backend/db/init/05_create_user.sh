@@ -7,4 +7,7 @@ GRANT CONNECT ON DATABASE $POSTGRES_DB TO $DB_APP_USER;
 GRANT USAGE ON SCHEMA public TO $DB_APP_USER;
 GRANT SELECT, INSERT, UPDATE ON measurements TO $DB_APP_USER;
 GRANT USAGE, SELECT ON SEQUENCE measurements_id_seq TO $DB_APP_USER;
+GRANT SELECT, INSERT, UPDATE ON sensors TO $DB_APP_USER;
+GRANT SELECT, INSERT, UPDATE ON cabin_bookings TO $DB_APP_USER;
+GRANT USAGE, SELECT ON SEQUENCE cabin_bookings_id_seq TO $DB_APP_USER;
 EOSQL
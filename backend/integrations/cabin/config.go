@@ -0,0 +1,21 @@
+package cabin
+
+import (
+	"os"
+	"strings"
+)
+
+// LoadMembers reads the family members the cabin can be booked for:
+//
+//	CABIN_MEMBERS=Mika,Anna,Elise
+//
+// Without CABIN_MEMBERS any name is accepted.
+func LoadMembers() []string {
+	var members []string
+	for _, name := range strings.Split(os.Getenv("CABIN_MEMBERS"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			members = append(members, name)
+		}
+	}
+	return members
+}
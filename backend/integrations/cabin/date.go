@@ -0,0 +1,68 @@
+package cabin
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Date is a calendar day, serialized as YYYY-MM-DD. It is stored as midnight
+// UTC so dates compare and subtract without time zone effects.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the year, month and day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the day of t in t's location.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(time.DateOnly)
+}
+
+func (d Date) AddDays(n int) Date {
+	return Date{d.AddDate(0, 0, n)}
+}
+
+func (d Date) Before(o Date) bool {
+	return d.Time.Before(o.Time)
+}
+
+func (d Date) After(o Date) bool {
+	return d.Time.After(o.Time)
+}
+
+// DaysUntil returns the number of days from d to o, negative if o is earlier.
+func (d Date) DaysUntil(o Date) int {
+	return int(o.Sub(d.Time).Hours() / 24)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
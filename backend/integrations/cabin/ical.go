@@ -0,0 +1,45 @@
+package cabin
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/emersion/go-ical"
+)
+
+const prodID = "-//gohome//Cabin bookings//EN"
+
+// WriteICal writes the bookings as an iCalendar feed of all-day events.
+// Cancelled bookings are included with the CANCELLED status so subscribed
+// calendars drop them.
+func WriteICal(w io.Writer, bookings []Booking) error {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, prodID)
+	cal.Props.SetText("X-WR-CALNAME", "Cabin bookings")
+	for _, b := range bookings {
+		cal.Children = append(cal.Children, bookingEvent(b).Component)
+	}
+	return ical.NewEncoder(w).Encode(cal)
+}
+
+func bookingEvent(b Booking) *ical.Event {
+	event := ical.NewEvent()
+	event.Props.SetText(ical.PropUID, fmt.Sprintf("cabin-booking-%d@gohome", b.ID))
+	event.Props.SetDateTime(ical.PropDateTimeStamp, b.Updated.UTC())
+	event.Props.SetDateTime(ical.PropCreated, b.Created.UTC())
+	event.Props.SetDateTime(ical.PropLastModified, b.Updated.UTC())
+	// All-day events end on the day after the last day
+	event.Props.SetDate(ical.PropDateTimeStart, b.From.Time)
+	event.Props.SetDate(ical.PropDateTimeEnd, b.To.AddDays(1).Time)
+	event.Props.SetText(ical.PropSummary, "Cabin: "+b.Name)
+	if b.Note != "" {
+		event.Props.SetText(ical.PropDescription, b.Note)
+	}
+	if b.Cancelled != nil {
+		event.SetStatus(ical.EventCancelled)
+	} else {
+		event.SetStatus(ical.EventConfirmed)
+	}
+	return event
+}
@@ -0,0 +1,44 @@
+package cabin
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteICal(t *testing.T) {
+	created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	cancelled := created.Add(time.Hour)
+	bookings := []Booking{
+		{ID: 1, Name: "Mika", From: date("2024-06-14"), To: date("2024-06-16"), Note: "Juhannus", Created: created, Updated: created},
+		{ID: 2, Name: "Anna", From: date("2024-07-01"), To: date("2024-07-01"), Created: created, Updated: cancelled, Cancelled: &cancelled},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteICal(&buf, bookings))
+
+	cal, err := ical.NewDecoder(&buf).Decode()
+	require.NoError(t, err)
+	events := cal.Events()
+	require.Len(t, events, 2)
+
+	first := events[0]
+	uid, _ := first.Props.Text(ical.PropUID)
+	assert.Equal(t, "cabin-booking-1@gohome", uid)
+	summary, _ := first.Props.Text(ical.PropSummary)
+	assert.Equal(t, "Cabin: Mika", summary)
+	assert.Equal(t, ical.ValueDate, first.Props.Get(ical.PropDateTimeStart).ValueType())
+	start, err := first.DateTimeStart(time.UTC)
+	require.NoError(t, err)
+	end, err := first.DateTimeEnd(time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, date("2024-06-14").Time, start)
+	assert.Equal(t, date("2024-06-17").Time, end, "end is exclusive")
+
+	status, err := events[1].Status()
+	require.NoError(t, err)
+	assert.Equal(t, ical.EventCancelled, status)
+}
@@ -0,0 +1,74 @@
+// Package cabin keeps the family's cabin bookings. A booking reserves the
+// cabin for one family member over a range of days, and bookings may not
+// overlap.
+package cabin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound       = errors.New("booking not found")
+	ErrConflict       = errors.New("booking conflicts with an existing booking")
+	ErrInvalidBooking = errors.New("invalid booking")
+)
+
+// MaxBookingDays is the longest booking accepted.
+const MaxBookingDays = 60
+
+// Booking reserves the cabin from From to To, both days included. Cancelled
+// bookings are kept with the cancellation time.
+type Booking struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"` // Family member the cabin is booked for
+	From      Date       `json:"from"`
+	To        Date       `json:"to"`
+	Note      string     `json:"note,omitempty"`
+	Created   time.Time  `json:"created"`
+	Updated   time.Time  `json:"updated"`
+	Cancelled *time.Time `json:"cancelled,omitempty"`
+}
+
+// Days returns the number of days booked.
+func (b Booking) Days() int {
+	return b.From.DaysUntil(b.To) + 1
+}
+
+// Covers reports whether the booking includes the day.
+func (b Booking) Covers(d Date) bool {
+	return !d.Before(b.From) && !d.After(b.To)
+}
+
+// ConflictError lists the bookings a new booking overlaps with.
+type ConflictError struct {
+	Conflicts []Booking
+}
+
+func (e *ConflictError) Error() string {
+	var days []string
+	for _, b := range e.Conflicts {
+		days = append(days, fmt.Sprintf("%s %s-%s", b.Name, b.From, b.To))
+	}
+	return fmt.Sprintf("%v: %s", ErrConflict, strings.Join(days, ", "))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// Store persists bookings.
+type Store interface {
+	// Insert stores a new booking and returns it with its ID. It returns
+	// ErrConflict if an active booking overlaps it.
+	Insert(ctx context.Context, b Booking) (Booking, error)
+	// Get returns ErrNotFound for unknown IDs.
+	Get(ctx context.Context, id int64) (Booking, error)
+	// Cancel marks the booking cancelled at the time, ErrNotFound for unknown IDs.
+	Cancel(ctx context.Context, id int64, at time.Time) (Booking, error)
+	// List returns the bookings overlapping from-to, ordered by their first day.
+	List(ctx context.Context, from, to Date, includeCancelled bool) ([]Booking, error)
+}
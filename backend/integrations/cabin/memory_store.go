@@ -0,0 +1,82 @@
+package cabin
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps bookings in memory, for tests and for running without a
+// database.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	nextID   int64
+	bookings map[int64]Booking
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{nextID: 1, bookings: map[int64]Booking{}}
+}
+
+func (m *MemoryStore) Insert(ctx context.Context, b Booking) (Booking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, other := range m.bookings {
+		if other.Cancelled == nil && overlaps(b, other) {
+			return Booking{}, ErrConflict
+		}
+	}
+	b.ID = m.nextID
+	m.nextID++
+	m.bookings[b.ID] = b
+	return b, nil
+}
+
+func (m *MemoryStore) Get(ctx context.Context, id int64) (Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	b, ok := m.bookings[id]
+	if !ok {
+		return Booking{}, ErrNotFound
+	}
+	return b, nil
+}
+
+func (m *MemoryStore) Cancel(ctx context.Context, id int64, at time.Time) (Booking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.bookings[id]
+	if !ok {
+		return Booking{}, ErrNotFound
+	}
+	if b.Cancelled == nil {
+		b.Cancelled = &at
+		b.Updated = at
+		m.bookings[id] = b
+	}
+	return b, nil
+}
+
+func (m *MemoryStore) List(ctx context.Context, from, to Date, includeCancelled bool) ([]Booking, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	window := Booking{From: from, To: to}
+	bookings := []Booking{}
+	for _, b := range m.bookings {
+		if (includeCancelled || b.Cancelled == nil) && overlaps(b, window) {
+			bookings = append(bookings, b)
+		}
+	}
+	sort.Slice(bookings, func(i, j int) bool {
+		if !bookings[i].From.Equal(bookings[j].From.Time) {
+			return bookings[i].From.Before(bookings[j].From)
+		}
+		return bookings[i].ID < bookings[j].ID
+	})
+	return bookings, nil
+}
+
+func overlaps(a, b Booking) bool {
+	return !a.From.After(b.To) && !b.From.After(a.To)
+}
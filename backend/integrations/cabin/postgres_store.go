@@ -0,0 +1,111 @@
+package cabin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresStore keeps the bookings in the cabin_bookings table. An exclusion
+// constraint on the table rejects overlapping active bookings, so two
+// concurrent requests cannot book the same days.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// exclusionViolation is the Postgres error code of a failed EXCLUDE constraint.
+const exclusionViolation = "23P01"
+
+const bookingColumns = `id, name, start_date, end_date, note, created_at, updated_at, cancelled_at`
+
+func (p *PostgresStore) Insert(ctx context.Context, b Booking) (Booking, error) {
+	row := p.db.QueryRowContext(ctx, `
+		INSERT INTO cabin_bookings (name, start_date, end_date, note) VALUES ($1, $2, $3, $4)
+		RETURNING `+bookingColumns,
+		b.Name, b.From.String(), b.To.String(), b.Note)
+	inserted, err := scanBooking(row)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == exclusionViolation {
+		return Booking{}, ErrConflict
+	}
+	if err != nil {
+		return Booking{}, fmt.Errorf("insert booking: %w", err)
+	}
+	return inserted, nil
+}
+
+func (p *PostgresStore) Get(ctx context.Context, id int64) (Booking, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+bookingColumns+` FROM cabin_bookings WHERE id = $1`, id)
+	b, err := scanBooking(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Booking{}, ErrNotFound
+	}
+	if err != nil {
+		return Booking{}, fmt.Errorf("query booking %d: %w", id, err)
+	}
+	return b, nil
+}
+
+func (p *PostgresStore) Cancel(ctx context.Context, id int64, at time.Time) (Booking, error) {
+	row := p.db.QueryRowContext(ctx, `
+		UPDATE cabin_bookings SET cancelled_at = COALESCE(cancelled_at, $2)
+		WHERE id = $1 RETURNING `+bookingColumns, id, at)
+	b, err := scanBooking(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Booking{}, ErrNotFound
+	}
+	if err != nil {
+		return Booking{}, fmt.Errorf("cancel booking %d: %w", id, err)
+	}
+	return b, nil
+}
+
+func (p *PostgresStore) List(ctx context.Context, from, to Date, includeCancelled bool) ([]Booking, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+bookingColumns+` FROM cabin_bookings
+		WHERE start_date <= $2 AND end_date >= $1 AND ($3 OR cancelled_at IS NULL)
+		ORDER BY start_date, id`, from.String(), to.String(), includeCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("query bookings: %w", err)
+	}
+	defer rows.Close()
+	bookings := []Booking{}
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan booking: %w", err)
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBooking(s scanner) (Booking, error) {
+	var (
+		b         Booking
+		from, to  time.Time
+		cancelled sql.NullTime
+	)
+	if err := s.Scan(&b.ID, &b.Name, &from, &to, &b.Note, &b.Created, &b.Updated, &cancelled); err != nil {
+		return b, err
+	}
+	b.From, b.To = DateOf(from), DateOf(to)
+	b.Created, b.Updated = b.Created.UTC(), b.Updated.UTC()
+	if cancelled.Valid {
+		t := cancelled.Time.UTC()
+		b.Cancelled = &t
+	}
+	return b, nil
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package cabin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikahozz/gohome/config"
+	"github.com/mikahozz/gohome/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore(t *testing.T) {
+	config.LoadEnv()
+	conn, err := db.Open()
+	if err != nil {
+		t.Skipf("database not available: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	s := NewService(NewPostgresStore(conn))
+
+	// Far in the future to stay clear of real bookings
+	from := date("2099-06-14")
+	b, err := s.Book(ctx, Booking{Name: "Integration test", From: from, To: from.AddDays(2), Note: "test"})
+	require.NoError(t, err)
+
+	got, err := s.Get(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, from, got.From)
+	assert.Equal(t, from.AddDays(2), got.To)
+	assert.Equal(t, "test", got.Note)
+
+	// The exclusion constraint rejects overlaps even past the service check
+	_, err = NewPostgresStore(conn).Insert(ctx, Booking{Name: "Other", From: from.AddDays(2), To: from.AddDays(3)})
+	assert.ErrorIs(t, err, ErrConflict)
+
+	cancelled, err := s.Cancel(ctx, b.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, cancelled.Cancelled)
+	list, err := s.List(ctx, from, from.AddDays(2), false)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = s.Get(ctx, -1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,153 @@
+package cabin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var helsinki, _ = time.LoadLocation("Europe/Helsinki")
+
+// Service books the cabin for the family members.
+type Service struct {
+	store   Store
+	members []string
+	now     func() time.Time
+}
+
+// NewService returns a service booking for the members. Without members any
+// name is accepted.
+func NewService(store Store, members ...string) *Service {
+	return &Service{store: store, members: members, now: time.Now}
+}
+
+func (s *Service) Members() []string {
+	return s.members
+}
+
+// Validate checks the booking before it is stored.
+func (s *Service) Validate(b Booking) error {
+	if strings.TrimSpace(b.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBooking)
+	}
+	if len(s.members) > 0 && !slices.Contains(s.members, b.Name) {
+		return fmt.Errorf("%w: %q is not a family member", ErrInvalidBooking, b.Name)
+	}
+	if b.From.IsZero() || b.To.IsZero() {
+		return fmt.Errorf("%w: from and to are required", ErrInvalidBooking)
+	}
+	if b.To.Before(b.From) {
+		return fmt.Errorf("%w: to %s is before from %s", ErrInvalidBooking, b.To, b.From)
+	}
+	if b.Days() > MaxBookingDays {
+		return fmt.Errorf("%w: %d days, at most %d allowed", ErrInvalidBooking, b.Days(), MaxBookingDays)
+	}
+	if len(b.Note) > 500 {
+		return fmt.Errorf("%w: note is too long", ErrInvalidBooking)
+	}
+	return nil
+}
+
+// Book stores a new booking. Bookings overlapping active bookings are
+// rejected with a ConflictError listing them.
+func (s *Service) Book(ctx context.Context, b Booking) (Booking, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	if err := s.Validate(b); err != nil {
+		return Booking{}, err
+	}
+	if err := s.checkConflicts(ctx, b); err != nil {
+		return Booking{}, err
+	}
+	now := s.now().UTC()
+	b.ID, b.Created, b.Updated, b.Cancelled = 0, now, now, nil
+	booked, err := s.store.Insert(ctx, b)
+	if errors.Is(err, ErrConflict) {
+		// Booked by someone else since the check
+		if err := s.checkConflicts(ctx, b); err != nil {
+			return Booking{}, err
+		}
+	}
+	if err != nil {
+		return Booking{}, err
+	}
+	return booked, nil
+}
+
+func (s *Service) checkConflicts(ctx context.Context, b Booking) error {
+	conflicts, err := s.store.List(ctx, b.From, b.To, false)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// Cancel cancels the booking. Cancelling again keeps the first cancellation.
+func (s *Service) Cancel(ctx context.Context, id int64) (Booking, error) {
+	return s.store.Cancel(ctx, id, s.now().UTC())
+}
+
+func (s *Service) Get(ctx context.Context, id int64) (Booking, error) {
+	return s.store.Get(ctx, id)
+}
+
+// List returns the bookings overlapping from-to.
+func (s *Service) List(ctx context.Context, from, to Date, includeCancelled bool) ([]Booking, error) {
+	return s.store.List(ctx, from, to, includeCancelled)
+}
+
+// Today returns the current day in Finland, where the cabin is.
+func (s *Service) Today() Date {
+	return DateOf(s.now().In(helsinki))
+}
+
+// Day is the booking status of a single day.
+type Day struct {
+	Date      Date       `json:"date"`
+	Booked    bool       `json:"booked"`
+	Name      string     `json:"name,omitempty"`
+	BookingID int64      `json:"booking_id,omitempty"`
+	Updated   *time.Time `json:"updated,omitempty"` // Last booking or cancellation touching the day
+}
+
+// Days is the booking calendar in the form of the former cabin bookings
+// service.
+type Days struct {
+	Bookings    []Day     `json:"bookings"`
+	LastUpdated time.Time `json:"lastupdated"`
+}
+
+// Days returns the status of n days starting from the Monday of the current
+// week, so the calendar always starts with full weeks.
+func (s *Service) Days(ctx context.Context, n int) (Days, error) {
+	today := s.Today()
+	from := today.AddDays(-((int(today.Weekday()) + 6) % 7))
+	to := from.AddDays(n - 1)
+	bookings, err := s.store.List(ctx, from, to, true)
+	if err != nil {
+		return Days{}, err
+	}
+	days := Days{Bookings: make([]Day, 0, n), LastUpdated: s.now().UTC()}
+	for d := from; !d.After(to); d = d.AddDays(1) {
+		day := Day{Date: d}
+		for _, b := range bookings {
+			if !b.Covers(d) {
+				continue
+			}
+			if day.Updated == nil || b.Updated.After(*day.Updated) {
+				updated := b.Updated
+				day.Updated = &updated
+			}
+			if b.Cancelled == nil {
+				day.Booked, day.Name, day.BookingID = true, b.Name, b.ID
+			}
+		}
+		days.Bookings = append(days.Bookings, day)
+	}
+	return days, nil
+}
@@ -0,0 +1,158 @@
+package cabin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func newTestService(now time.Time) *Service {
+	s := NewService(NewMemoryStore(), "Mika", "Anna")
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestBook(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC))
+
+	b, err := s.Book(ctx, Booking{Name: " Mika ", From: date("2024-06-14"), To: date("2024-06-16"), Note: "Juhannus"})
+	require.NoError(t, err)
+	assert.NotZero(t, b.ID)
+	assert.Equal(t, "Mika", b.Name)
+	assert.Equal(t, 3, b.Days())
+
+	got, err := s.Get(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, b, got)
+
+	// The next day is free
+	_, err = s.Book(ctx, Booking{Name: "Anna", From: date("2024-06-17"), To: date("2024-06-17")})
+	require.NoError(t, err)
+}
+
+func TestBookConflict(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC))
+	first, err := s.Book(ctx, Booking{Name: "Mika", From: date("2024-06-14"), To: date("2024-06-16")})
+	require.NoError(t, err)
+
+	_, err = s.Book(ctx, Booking{Name: "Anna", From: date("2024-06-10"), To: date("2024-06-14")})
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, ErrConflict)
+	require.Len(t, conflict.Conflicts, 1)
+	assert.Equal(t, first.ID, conflict.Conflicts[0].ID)
+
+	// Cancelled days can be booked again
+	_, err = s.Cancel(ctx, first.ID)
+	require.NoError(t, err)
+	_, err = s.Book(ctx, Booking{Name: "Anna", From: date("2024-06-10"), To: date("2024-06-14")})
+	assert.NoError(t, err)
+}
+
+func TestBookInvalid(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC))
+	for name, b := range map[string]Booking{
+		"no name":        {From: date("2024-06-14"), To: date("2024-06-14")},
+		"not a member":   {Name: "Naapuri", From: date("2024-06-14"), To: date("2024-06-14")},
+		"no dates":       {Name: "Mika"},
+		"to before from": {Name: "Mika", From: date("2024-06-14"), To: date("2024-06-13")},
+		"too long":       {Name: "Mika", From: date("2024-06-01"), To: date("2024-09-01")},
+	} {
+		_, err := s.Book(ctx, b)
+		assert.ErrorIs(t, err, ErrInvalidBooking, name)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	s := newTestService(now)
+	b, err := s.Book(ctx, Booking{Name: "Mika", From: date("2024-06-14"), To: date("2024-06-16")})
+	require.NoError(t, err)
+
+	cancelled, err := s.Cancel(ctx, b.ID)
+	require.NoError(t, err)
+	require.NotNil(t, cancelled.Cancelled)
+	assert.True(t, now.Equal(*cancelled.Cancelled))
+
+	// Cancelling again keeps the first cancellation
+	s.now = func() time.Time { return now.Add(time.Hour) }
+	again, err := s.Cancel(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(*again.Cancelled))
+
+	_, err = s.Cancel(ctx, 999)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	active, err := s.List(ctx, date("2024-06-01"), date("2024-06-30"), false)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	all, err := s.List(ctx, date("2024-06-01"), date("2024-06-30"), true)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestDays(t *testing.T) {
+	ctx := context.Background()
+	// Wednesday evening in UTC is already Thursday in Finland
+	now := time.Date(2024, 6, 5, 22, 0, 0, 0, time.UTC)
+	s := newTestService(now)
+	assert.Equal(t, date("2024-06-06"), s.Today())
+
+	b, err := s.Book(ctx, Booking{Name: "Anna", From: date("2024-06-08"), To: date("2024-06-09")})
+	require.NoError(t, err)
+	cancelled, err := s.Book(ctx, Booking{Name: "Mika", From: date("2024-06-11"), To: date("2024-06-11")})
+	require.NoError(t, err)
+	_, err = s.Cancel(ctx, cancelled.ID)
+	require.NoError(t, err)
+
+	days, err := s.Days(ctx, 14)
+	require.NoError(t, err)
+	require.Len(t, days.Bookings, 14)
+	assert.True(t, now.Equal(days.LastUpdated))
+
+	// Starts from Monday of the current week
+	assert.Equal(t, date("2024-06-03"), days.Bookings[0].Date)
+	assert.Equal(t, time.Monday, days.Bookings[0].Date.Weekday())
+
+	saturday := days.Bookings[5]
+	assert.Equal(t, date("2024-06-08"), saturday.Date)
+	assert.True(t, saturday.Booked)
+	assert.Equal(t, "Anna", saturday.Name)
+	assert.Equal(t, b.ID, saturday.BookingID)
+	assert.NotNil(t, saturday.Updated)
+
+	// A cancelled day is free but shows it was updated
+	tuesday := days.Bookings[8]
+	assert.False(t, tuesday.Booked)
+	assert.Empty(t, tuesday.Name)
+	assert.NotNil(t, tuesday.Updated)
+
+	assert.Nil(t, days.Bookings[0].Updated)
+}
+
+func TestDateJSON(t *testing.T) {
+	var d Date
+	require.NoError(t, d.UnmarshalJSON([]byte(`"2024-06-14"`)))
+	assert.Equal(t, NewDate(2024, time.June, 14), d)
+	b, err := d.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"2024-06-14"`, string(b))
+	assert.Error(t, d.UnmarshalJSON([]byte(`"14.6.2024"`)))
+	assert.Equal(t, 2, d.DaysUntil(d.AddDays(2)))
+}
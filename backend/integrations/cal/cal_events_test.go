@@ -1,16 +1,27 @@
+//go:build integration
+
 package cal
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/mikahozz/gohome/config"
 )
 
 func TestGetFamilyCalendarEventsIntegration(t *testing.T) {
-	// from := DateOffset{Months: -6}
-	// to := DateOffset{Months: 6}
-	from := DateOffset{Days: 0}
-	to := DateOffset{Days: 7}
-	events, err := GetFamilyCalendarEvents(from, to)
+	config.LoadEnv()
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed with error: %v", err)
+	}
+	if len(cfg.Sources) == 0 {
+		t.Skip("no calendars configured")
+	}
+	start := time.Now()
+	end := start.AddDate(0, 0, 7)
+	events, err := GetFamilyCalendarEvents(context.Background(), cfg, start, end, false)
 	if err != nil {
 		t.Fatalf("GetFamilyCalendarEvents failed with error: %v", err)
 	}
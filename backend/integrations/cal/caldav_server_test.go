@@ -0,0 +1,310 @@
+package cal
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/emersion/go-ical"
+	webdav "github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend is an in-memory CalDAV account with the principal at /{user}/
+// and the calendars under /{user}/calendars/. With syncTokens set it also
+// serves the getctag and sync-token of the calendars and sync-collection
+// REPORTs, which the go-webdav handler does not support.
+type testBackend struct {
+	mu         sync.Mutex
+	user       string
+	calendars  []caldav.Calendar
+	objects    map[string][]caldav.CalendarObject
+	syncTokens bool
+	// Each change bumps the version, sync tokens older than minToken are
+	// refused as expired
+	version  int
+	changes  []testChange
+	minToken int
+	// The objects downloaded and the sync-collection REPORTs made
+	gets    int
+	reports int
+}
+
+type testChange struct {
+	version int
+	path    string
+}
+
+type queryPathKey struct{}
+
+// newTestServer serves a CalDAV account that requires basic auth with the
+// user name as the password.
+func newTestServer(t *testing.T, user string) (*httptest.Server, *testBackend) {
+	t.Helper()
+	b := &testBackend{user: user, objects: make(map[string][]caldav.CalendarObject)}
+	handler := &caldav.Handler{Backend: b}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != user || p != user {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		// The handler does not pass the If-Match of a DELETE to the backend
+		if r.Method == http.MethodDelete {
+			b.mu.Lock()
+			ok := b.matches(r.URL.Path, webdav.ConditionalMatch(r.Header.Get("If-Match")), "")
+			b.mu.Unlock()
+			if !ok {
+				http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+				return
+			}
+		}
+		if b.syncTokens && r.Method == "PROPFIND" && bytes.Contains(body, []byte("getctag")) {
+			b.serveCollectionState(w, r.URL.Path)
+			return
+		}
+		if b.syncTokens && r.Method == "REPORT" && bytes.Contains(body, []byte("sync-collection")) {
+			b.serveSyncCollection(t, w, r.URL.Path, body)
+			return
+		}
+		// The handler does not pass the calendar of a calendar-query to the
+		// backend, so it is passed in the context.
+		if r.Method == "REPORT" {
+			r = r.WithContext(context.WithValue(r.Context(), queryPathKey{}, r.URL.Path))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, b
+}
+
+// addCalendar adds a calendar with the given display name and returns its path.
+func (b *testBackend) addCalendar(name string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := "/" + b.user + "/calendars/" + strings.ToLower(name) + "/"
+	b.calendars = append(b.calendars, caldav.Calendar{Path: p, Name: name, SupportedComponentSet: []string{ical.CompEvent}})
+	return p
+}
+
+// addObject stores the iCalendar data under the calendar at calPath,
+// replacing the object of the same name.
+func (b *testBackend) addObject(t *testing.T, calPath, name, data string) {
+	t.Helper()
+	cal, err := ical.NewDecoder(strings.NewReader(strings.ReplaceAll(data, "\n", "\r\n"))).Decode()
+	require.NoError(t, err)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.put(calPath+name, cal)
+}
+
+// removeObject removes the object of the given name from the calendar at
+// calPath.
+func (b *testBackend) removeObject(calPath, name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(calPath + name)
+}
+
+// put stores an object with a new etag. The caller holds the lock.
+func (b *testBackend) put(p string, cal *ical.Calendar) {
+	b.version++
+	b.changes = append(b.changes, testChange{version: b.version, path: p})
+	calPath := path.Dir(p) + "/"
+	obj := caldav.CalendarObject{Path: p, ETag: fmt.Sprintf("%s-%d", path.Base(p), b.version), Data: cal}
+	for i, o := range b.objects[calPath] {
+		if o.Path == p {
+			b.objects[calPath][i] = obj
+			return
+		}
+	}
+	b.objects[calPath] = append(b.objects[calPath], obj)
+}
+
+// remove removes an object and reports whether it existed. The caller holds
+// the lock.
+func (b *testBackend) remove(p string) bool {
+	calPath := path.Dir(p) + "/"
+	objects := b.objects[calPath]
+	for i, o := range objects {
+		if o.Path == p {
+			b.objects[calPath] = append(objects[:i], objects[i+1:]...)
+			b.version++
+			b.changes = append(b.changes, testChange{version: b.version, path: p})
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the object at p meets the If-Match and
+// If-None-Match preconditions of a write. The caller holds the lock.
+func (b *testBackend) matches(p string, ifMatch, ifNoneMatch webdav.ConditionalMatch) bool {
+	var etag string
+	for _, o := range b.objects[path.Dir(p)+"/"] {
+		if o.Path == p {
+			etag = o.ETag
+		}
+	}
+	if ifNoneMatch.IsWildcard() && etag != "" {
+		return false
+	}
+	if ifMatch.IsWildcard() {
+		return etag != ""
+	}
+	if ifMatch.IsSet() {
+		want, err := ifMatch.ETag()
+		return err == nil && want == etag
+	}
+	return true
+}
+
+// serveCollectionState answers a PROPFIND for the getctag and sync-token of
+// a calendar. The ctag is the version of the latest change in the calendar.
+func (b *testBackend) serveCollectionState(w http.ResponseWriter, calPath string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ctag := 0
+	for _, c := range b.changes {
+		if path.Dir(c.path)+"/" == calPath {
+			ctag = c.version
+		}
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+  <d:response>
+    <d:href>%s</d:href>
+    <d:propstat>
+      <d:prop><cs:getctag>"ctag-%d"</cs:getctag><d:sync-token>https://example.com/sync/%d</d:sync-token></d:prop>
+      <d:status>HTTP/1.1 200 OK</d:status>
+    </d:propstat>
+  </d:response>
+</d:multistatus>`, calPath, ctag, b.version)
+}
+
+// serveSyncCollection answers a sync-collection REPORT with the objects of a
+// calendar changed or removed since the version of the sync token.
+func (b *testBackend) serveSyncCollection(t *testing.T, w http.ResponseWriter, calPath string, body []byte) {
+	var query struct {
+		SyncToken string `xml:"sync-token"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &query))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reports++
+	since, err := strconv.Atoi(strings.TrimPrefix(query.SyncToken, "https://example.com/sync/"))
+	if err != nil || since < b.minToken {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><d:error xmlns:d="DAV:"><d:valid-sync-token/></d:error>`)
+		return
+	}
+	changed := make(map[string]bool)
+	for _, c := range b.changes {
+		if c.version > since && path.Dir(c.path)+"/" == calPath {
+			changed[c.path] = true
+		}
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:">`)
+	for p := range changed {
+		etag := ""
+		for _, o := range b.objects[calPath] {
+			if o.Path == p {
+				etag = o.ETag
+			}
+		}
+		if etag == "" {
+			fmt.Fprintf(w, `<d:response><d:href>%s</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>`, p)
+			continue
+		}
+		fmt.Fprintf(w, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:getetag>"%s"</d:getetag></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`, p, etag)
+	}
+	fmt.Fprintf(w, `<d:sync-token>https://example.com/sync/%d</d:sync-token></d:multistatus>`, b.version)
+}
+
+func (b *testBackend) CurrentUserPrincipal(ctx context.Context) (string, error) {
+	return "/" + b.user + "/", nil
+}
+
+func (b *testBackend) CalendarHomeSetPath(ctx context.Context) (string, error) {
+	return "/" + b.user + "/calendars/", nil
+}
+
+func (b *testBackend) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]caldav.Calendar(nil), b.calendars...), nil
+}
+
+func (b *testBackend) GetCalendar(ctx context.Context, p string) (*caldav.Calendar, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, c := range b.calendars {
+		if path.Clean(c.Path) == path.Clean(p) {
+			return &c, nil
+		}
+	}
+	return nil, webdav.NewHTTPError(http.StatusNotFound, nil)
+}
+
+func (b *testBackend) GetCalendarObject(ctx context.Context, p string, req *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gets++
+	for _, objects := range b.objects {
+		for _, o := range objects {
+			if o.Path == p {
+				return &o, nil
+			}
+		}
+	}
+	return nil, webdav.NewHTTPError(http.StatusNotFound, nil)
+}
+
+func (b *testBackend) ListCalendarObjects(ctx context.Context, p string, req *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]caldav.CalendarObject(nil), b.objects[p]...), nil
+}
+
+func (b *testBackend) QueryCalendarObjects(ctx context.Context, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+	p, _ := ctx.Value(queryPathKey{}).(string)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return caldav.Filter(query, b.objects[p])
+}
+
+func (b *testBackend) PutCalendarObject(ctx context.Context, p string, cal *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.matches(p, opts.IfMatch, opts.IfNoneMatch) {
+		return "", webdav.NewHTTPError(http.StatusPreconditionFailed, nil)
+	}
+	b.put(p, cal)
+	return p, nil
+}
+
+func (b *testBackend) DeleteCalendarObject(ctx context.Context, p string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.remove(p) {
+		return webdav.NewHTTPError(http.StatusNotFound, nil)
+	}
+	return nil
+}
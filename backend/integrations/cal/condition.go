@@ -0,0 +1,160 @@
+package cal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EventSource serves the events of the calendars, e.g. a *Store.
+type EventSource interface {
+	Events(start, end time.Time, includeCancelled bool, calendars ...string) ([]Event, error)
+}
+
+// The events a View loads around the time asked for, so the conditions of a
+// day and the events starting soon are served from one load
+const (
+	viewBefore = 24 * time.Hour
+	viewAfter  = 48 * time.Hour
+)
+
+// View caches the events of the named calendars, or of all calendars, for the
+// conditions checked by the scheduler every minute. The events are loaded
+// again after ttl has passed, and a failed load keeps serving the previously
+// loaded events. Cancelled events are left out.
+type View struct {
+	source    EventSource
+	calendars []string
+	ttl       time.Duration
+
+	mu       sync.Mutex
+	events   []Event
+	from, to time.Time
+	loadedAt time.Time
+}
+
+func NewView(source EventSource, ttl time.Duration, calendars ...string) *View {
+	return &View{source: source, calendars: calendars, ttl: ttl}
+}
+
+// Events returns the events overlapping start and end, as loaded at now.
+func (v *View) Events(now, start, end time.Time) ([]Event, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	covered := !v.loadedAt.IsZero() && !start.Before(v.from) && !end.After(v.to)
+	if !covered || now.Sub(v.loadedAt) >= v.ttl || now.Before(v.loadedAt) {
+		from, to := now.Add(-viewBefore), now.Add(viewAfter)
+		if start.Before(from) {
+			from = start
+		}
+		if end.After(to) {
+			to = end
+		}
+		events, err := v.source.Events(from, to, false, v.calendars...)
+		switch {
+		case err == nil:
+			v.events, v.from, v.to, v.loadedAt = events, from, to, now
+		case !covered:
+			return nil, err
+		default:
+			log.Debug().Err(err).Str("event", "cal_view_failed").Msg("serving the previously loaded events")
+		}
+	}
+	var found []Event
+	for _, e := range v.events {
+		if overlaps(e.Start, e.End, start, end) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+// Match selects events by their categories and summary, matched case
+// insensitively. An event matches if it has any of the Categories, and its
+// summary contains Summary. Empty fields match any event. With AllDay only
+// all-day events match.
+type Match struct {
+	Categories []string
+	Summary    string
+	AllDay     bool
+}
+
+func (m Match) Matches(e Event) bool {
+	if m.AllDay && !e.AllDay {
+		return false
+	}
+	if m.Summary != "" && !strings.Contains(strings.ToLower(e.Summary), strings.ToLower(m.Summary)) {
+		return false
+	}
+	if len(m.Categories) == 0 {
+		return true
+	}
+	for _, want := range m.Categories {
+		for _, category := range e.Categories {
+			if strings.EqualFold(category, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DayCondition is a day with a matching event, such as a day away with an
+// all-day event in the Away category, or a school day with lessons in the
+// school calendar. Days are in Location, by default in the location of now.
+type DayCondition struct {
+	View     *View
+	Match    Match
+	Location *time.Location
+}
+
+// Active reports whether a matching event takes place on the day of now.
+func (c DayCondition) Active(now time.Time) (bool, error) {
+	if c.View == nil {
+		return false, fmt.Errorf("calendar day condition has no view")
+	}
+	if c.Location != nil {
+		now = now.In(c.Location)
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	events, err := c.View.Events(now, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return false, err
+	}
+	for _, e := range events {
+		if c.Match.Matches(e) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EventCondition is a matching event going on, from Before ahead of its
+// start until its end, e.g. heating the sauna 15 minutes before an event
+// tagged sauna.
+type EventCondition struct {
+	View   *View
+	Match  Match
+	Before time.Duration
+}
+
+// Active reports whether a matching event starts within Before of now or is
+// going on at now.
+func (c EventCondition) Active(now time.Time) (bool, error) {
+	if c.View == nil {
+		return false, fmt.Errorf("calendar event condition has no view")
+	}
+	events, err := c.View.Events(now, now, now.Add(c.Before+time.Nanosecond))
+	if err != nil {
+		return false, err
+	}
+	for _, e := range events {
+		if c.Match.Matches(e) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
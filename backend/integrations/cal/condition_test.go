@@ -0,0 +1,143 @@
+package cal
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySource is an in-memory calendar that counts its loads.
+type memorySource struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+	loads  int
+}
+
+func (m *memorySource) Events(start, end time.Time, includeCancelled bool, calendars ...string) ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	var found []Event
+	for _, e := range m.events {
+		if overlaps(e.Start, e.End, start, end) && (includeCancelled || !e.Cancelled()) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func TestViewCaches(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	source := &memorySource{events: []Event{
+		{Summary: "Sauna", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+		{Summary: "Cancelled sauna", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Status: StatusCancelled},
+		{Summary: "Next week", Start: now.AddDate(0, 0, 7), End: now.AddDate(0, 0, 7).Add(time.Hour)},
+	}}
+	view := NewView(source, 10*time.Minute)
+
+	events, err := view.Events(now, now, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Sauna", events[0].Summary)
+
+	// Served from the cache until the ttl has passed
+	_, err = view.Events(now.Add(5*time.Minute), now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, source.loads)
+	_, err = view.Events(now.Add(10*time.Minute), now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, source.loads)
+
+	// A range outside the loaded one is loaded
+	events, err = view.Events(now.Add(10*time.Minute), now.AddDate(0, 0, 7), now.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 3, source.loads)
+
+	// A failed load keeps serving the loaded events, unless the range is not
+	// loaded
+	source.err = assert.AnError
+	events, err = view.Events(now.Add(time.Hour), now, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	_, err = view.Events(now.Add(time.Hour), now.AddDate(0, 0, -7), now)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMatch(t *testing.T) {
+	trip := Event{Summary: "Cabin trip", AllDay: true, Categories: []string{"Away", "Family"}}
+	sauna := Event{Summary: "Sauna evening", Categories: []string{"sauna"}}
+	tests := []struct {
+		name  string
+		match Match
+		event Event
+		want  bool
+	}{
+		{"any event", Match{}, sauna, true},
+		{"category", Match{Categories: []string{"Sauna"}}, sauna, true},
+		{"any of the categories", Match{Categories: []string{"Holiday", "away"}}, trip, true},
+		{"other category", Match{Categories: []string{"Away"}}, sauna, false},
+		{"all-day", Match{Categories: []string{"Away"}, AllDay: true}, trip, true},
+		{"not all-day", Match{AllDay: true}, sauna, false},
+		{"summary", Match{Summary: "SAUNA"}, sauna, true},
+		{"other summary", Match{Summary: "sauna"}, trip, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.match.Matches(tt.event))
+		})
+	}
+}
+
+func TestDayCondition(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, helsinki)
+	source := &memorySource{events: []Event{
+		{Summary: "Cabin trip", Start: monday.AddDate(0, 0, 4), End: monday.AddDate(0, 0, 7), AllDay: true, Categories: []string{"Away"}},
+		{Summary: "Dentist", Start: monday.Add(15 * time.Hour), End: monday.Add(16 * time.Hour), Categories: []string{"Away"}},
+	}}
+	away := DayCondition{View: NewView(source, time.Hour), Match: Match{Categories: []string{"Away"}, AllDay: true}, Location: helsinki}
+
+	for day, want := range map[int]bool{0: false, 3: false, 4: true, 6: true, 7: false} {
+		// Early in the morning, in UTC still the day before
+		active, err := away.Active(monday.AddDate(0, 0, day).Add(time.Hour).UTC())
+		require.NoError(t, err)
+		assert.Equal(t, want, active, "day %d", day)
+	}
+
+	_, err = DayCondition{}.Active(monday)
+	assert.Error(t, err)
+}
+
+func TestEventCondition(t *testing.T) {
+	start := time.Date(2025, 9, 5, 18, 0, 0, 0, time.UTC)
+	source := &memorySource{events: []Event{
+		{Summary: "Sauna", Start: start, End: start.Add(2 * time.Hour), Categories: []string{"Sauna"}},
+		{Summary: "Dinner", Start: start.Add(-time.Hour), End: start.Add(time.Hour)},
+	}}
+	sauna := EventCondition{View: NewView(source, time.Minute), Match: Match{Categories: []string{"sauna"}}, Before: 15 * time.Minute}
+
+	tests := []struct {
+		at   time.Duration
+		want bool
+	}{
+		{-16 * time.Minute, false},
+		{-15 * time.Minute, true},
+		{0, true},
+		{119 * time.Minute, true},
+		{2 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		active, err := sauna.Active(start.Add(tt.at))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, active, "at %s", tt.at)
+	}
+}
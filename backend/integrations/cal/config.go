@@ -1,50 +1,221 @@
 package cal
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"time"
-
-	"github.com/joho/godotenv"
 )
 
-type Config struct {
-	username     string
-	password     string
-	calUrl       string
-	calName      string
-	baseTimezone *time.Location
+var ErrUnknownCalendar = errors.New("unknown calendar")
+
+// DefaultColors are given in order to the sources configured without a color.
+var DefaultColors = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7"}
+
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Source is a calendar on a CalDAV account, or an iCalendar feed subscribed
+// to by its ICS URL. Name is the display name the events are tagged with,
+// Calendar the name of the calendar on the server. Feeds are read-only and
+// have no account.
+type Source struct {
+	Name     string
+	Color    string
+	Owner    string
+	URL      string
+	Username string
+	Password string
+	Calendar string
+	ICS      string
 }
 
-var config Config
+// Subscription reports whether the source is an iCalendar feed.
+func (s Source) Subscription() bool {
+	return s.ICS != ""
+}
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		panic(fmt.Sprintf("error loading .env file: %v", err))
+func (s Source) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("calendar source without a name")
 	}
-	config.username = os.ExpandEnv("$CAL_USERNAME")
-	if config.username == "" {
-		panic("CAL_USERNAME env not set")
+	if s.Color != "" && !colorPattern.MatchString(s.Color) {
+		return fmt.Errorf("calendar source %q has an invalid color %q, expected #rgb or #rrggbb", s.Name, s.Color)
 	}
-	config.password = os.ExpandEnv("$CAL_PASSWORD")
-	if config.password == "" {
-		panic("CAL_PASSWORD env not set")
+	if s.Subscription() {
+		if u, err := url.Parse(s.ICS); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("calendar source %q has an invalid ICS URL %q, expected http, https or webcal", s.Name, s.ICS)
+		}
+		return nil
 	}
-	config.calUrl = os.ExpandEnv("$CAL_URL")
-	if config.calUrl == "" {
-		panic("CAL_URL env not set")
+	if s.URL == "" {
+		return fmt.Errorf("calendar source %q needs a URL", s.Name)
 	}
-	config.calName = os.ExpandEnv("$CAL_NAME")
-	if config.calName == "" {
-		panic("CAL_NAME env not set")
+	if s.Calendar == "" {
+		return fmt.Errorf("calendar source %q needs a calendar name", s.Name)
 	}
-	zone := os.ExpandEnv("$CAL_BASE_TIMEZONE")
+	return nil
+}
+
+// account identifies the CalDAV account a source is on, sources on the same
+// account share a connection.
+func (s Source) account() string {
+	return s.URL + "\x00" + s.Username
+}
+
+type Config struct {
+	Sources      []Source
+	BaseTimezone *time.Location
+	SyncInterval time.Duration
+}
+
+// Select returns the sources with the given display names, matched case
+// insensitively, or all sources when no names are given.
+func (c *Config) Select(names ...string) ([]Source, error) {
+	if len(names) == 0 {
+		return c.Sources, nil
+	}
+	var selected []Source
+	for _, name := range names {
+		found := false
+		for _, s := range c.Sources {
+			if strings.EqualFold(s.Name, name) {
+				selected = append(selected, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCalendar, name)
+		}
+	}
+	return selected, nil
+}
+
+// LoadConfig reads the calendar sources from the environment:
+//
+//	CAL_BASE_TIMEZONE=Europe/Helsinki
+//	CAL_SYNC_INTERVAL=5m
+//	CAL_URL=https://caldav.icloud.com
+//	CAL_USERNAME=someuserid
+//	CAL_PASSWORD=somepassword
+//	CAL_SOURCES=family,mika
+//	CAL_SOURCE_FAMILY_CALENDAR=Family
+//	CAL_SOURCE_FAMILY_COLOR=#4e79a7
+//	CAL_SOURCE_MIKA_NAME=Mika
+//	CAL_SOURCE_MIKA_OWNER=Mika
+//	CAL_SOURCE_MIKA_URL=https://cloud.example.com/remote.php/dav
+//	CAL_SOURCE_MIKA_USERNAME=mika
+//	CAL_SOURCE_MIKA_PASSWORD=secret
+//	CAL_SOURCE_SCHOOL_NAME=School
+//	CAL_SOURCE_SCHOOL_ICS=webcal://school.example.com/schedule.ics
+//
+// The display name defaults to the id and the calendar name to the display
+// name. URL, USERNAME and PASSWORD default to CAL_URL, CAL_USERNAME and
+// CAL_PASSWORD. A source with an ICS URL is a feed, the CalDAV settings do
+// not apply to it and webcal URLs are fetched over https. Without CAL_SOURCES the single calendar CAL_NAME on CAL_URL
+// is used, and without either no calendars are configured.
+func LoadConfig() (*Config, error) {
+	c := &Config{SyncInterval: DefaultSyncInterval}
+	zone := os.Getenv("CAL_BASE_TIMEZONE")
 	if zone == "" {
-		panic("CAL_BASE_TIMEZONE env not set")
+		zone = "Europe/Helsinki"
 	}
-	config.baseTimezone, err = time.LoadLocation(zone)
+	var err error
+	c.BaseTimezone, err = time.LoadLocation(zone)
 	if err != nil {
-		panic(fmt.Sprintf("error loading timezone. It should be a valid IANA Time Zone: %v", err))
+		return nil, fmt.Errorf("invalid CAL_BASE_TIMEZONE, it should be a valid IANA Time Zone: %w", err)
+	}
+	if interval := os.Getenv("CAL_SYNC_INTERVAL"); interval != "" {
+		c.SyncInterval, err = time.ParseDuration(interval)
+		if err != nil || c.SyncInterval < 10*time.Second {
+			return nil, fmt.Errorf("invalid CAL_SYNC_INTERVAL %q, use e.g. 5m", interval)
+		}
+	}
+
+	account := Source{
+		URL:      os.Getenv("CAL_URL"),
+		Username: os.Getenv("CAL_USERNAME"),
+		Password: os.Getenv("CAL_PASSWORD"),
+	}
+	ids := os.Getenv("CAL_SOURCES")
+	if strings.TrimSpace(ids) == "" {
+		if account.URL == "" {
+			return c, nil
+		}
+		account.Name = os.Getenv("CAL_NAME")
+		if account.Name == "" {
+			return nil, fmt.Errorf("CAL_NAME not set, it is needed without CAL_SOURCES")
+		}
+		account.Calendar = account.Name
+		if err := c.add(account); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	for _, id := range strings.Split(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if err := c.add(loadSource(id, account)); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func loadSource(id string, account Source) Source {
+	prefix := "CAL_SOURCE_" + strings.ToUpper(id) + "_"
+	s := Source{
+		Name:     os.Getenv(prefix + "NAME"),
+		Color:    os.Getenv(prefix + "COLOR"),
+		Owner:    os.Getenv(prefix + "OWNER"),
+		URL:      os.Getenv(prefix + "URL"),
+		Username: os.Getenv(prefix + "USERNAME"),
+		Password: os.Getenv(prefix + "PASSWORD"),
+		Calendar: os.Getenv(prefix + "CALENDAR"),
+		ICS:      os.Getenv(prefix + "ICS"),
+	}
+	if s.Name == "" {
+		s.Name = id
+	}
+	if s.Subscription() {
+		if rest, ok := strings.CutPrefix(s.ICS, "webcal://"); ok {
+			s.ICS = "https://" + rest
+		}
+		s.URL, s.Username, s.Password, s.Calendar = "", "", "", ""
+		return s
+	}
+	if s.Calendar == "" {
+		s.Calendar = s.Name
+	}
+	if s.URL == "" {
+		s.URL = account.URL
+	}
+	if s.Username == "" {
+		s.Username = account.Username
+	}
+	if s.Password == "" {
+		s.Password = account.Password
+	}
+	return s
+}
+
+func (c *Config) add(s Source) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	for _, existing := range c.Sources {
+		if strings.EqualFold(existing.Name, s.Name) {
+			return fmt.Errorf("duplicate calendar source %q", s.Name)
+		}
+	}
+	if s.Color == "" {
+		s.Color = DefaultColors[len(c.Sources)%len(DefaultColors)]
 	}
+	c.Sources = append(c.Sources, s)
+	return nil
 }
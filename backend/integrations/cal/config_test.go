@@ -0,0 +1,115 @@
+package cal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clearCalEnv(t *testing.T) {
+	for _, key := range []string{"CAL_URL", "CAL_USERNAME", "CAL_PASSWORD", "CAL_NAME", "CAL_BASE_TIMEZONE", "CAL_SOURCES", "CAL_SYNC_INTERVAL"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoadConfigSources(t *testing.T) {
+	clearCalEnv(t)
+	t.Setenv("CAL_URL", "https://caldav.icloud.com")
+	t.Setenv("CAL_USERNAME", "family")
+	t.Setenv("CAL_PASSWORD", "secret")
+	t.Setenv("CAL_SOURCES", "family, elise")
+	t.Setenv("CAL_SOURCE_FAMILY_CALENDAR", "Perhe")
+	t.Setenv("CAL_SOURCE_FAMILY_NAME", "Family")
+	t.Setenv("CAL_SOURCE_ELISE_NAME", "Elise")
+	t.Setenv("CAL_SOURCE_ELISE_COLOR", "#e15759")
+	t.Setenv("CAL_SOURCE_ELISE_OWNER", "Elise")
+	t.Setenv("CAL_SOURCE_ELISE_URL", "https://cloud.example.com/remote.php/dav")
+	t.Setenv("CAL_SOURCE_ELISE_USERNAME", "elise")
+	t.Setenv("CAL_SOURCE_ELISE_PASSWORD", "hunter2")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Helsinki", cfg.BaseTimezone.String())
+	assert.Equal(t, []Source{
+		{Name: "Family", Color: DefaultColors[0], URL: "https://caldav.icloud.com", Username: "family", Password: "secret", Calendar: "Perhe"},
+		{Name: "Elise", Color: "#e15759", Owner: "Elise", URL: "https://cloud.example.com/remote.php/dav", Username: "elise", Password: "hunter2", Calendar: "Elise"},
+	}, cfg.Sources)
+	assert.NotEqual(t, cfg.Sources[0].account(), cfg.Sources[1].account())
+
+	selected, err := cfg.Select("elise")
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Sources[1:], selected)
+	_, err = cfg.Select("Family", "Work")
+	assert.ErrorIs(t, err, ErrUnknownCalendar)
+}
+
+func TestLoadConfigFeed(t *testing.T) {
+	clearCalEnv(t)
+	t.Setenv("CAL_URL", "https://caldav.icloud.com")
+	t.Setenv("CAL_USERNAME", "family")
+	t.Setenv("CAL_PASSWORD", "secret")
+	t.Setenv("CAL_SOURCES", "school,holidays")
+	t.Setenv("CAL_SOURCE_SCHOOL_NAME", "School")
+	t.Setenv("CAL_SOURCE_SCHOOL_ICS", "webcal://school.example.com/schedule.ics")
+	t.Setenv("CAL_SOURCE_HOLIDAYS_ICS", "https://example.com/holidays.ics")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []Source{
+		{Name: "School", Color: DefaultColors[0], ICS: "https://school.example.com/schedule.ics"},
+		{Name: "holidays", Color: DefaultColors[1], ICS: "https://example.com/holidays.ics"},
+	}, cfg.Sources)
+	assert.True(t, cfg.Sources[0].Subscription())
+}
+
+func TestLoadConfigSingleCalendar(t *testing.T) {
+	clearCalEnv(t)
+	t.Setenv("CAL_URL", "https://caldav.icloud.com")
+	t.Setenv("CAL_USERNAME", "family")
+	t.Setenv("CAL_PASSWORD", "secret")
+	t.Setenv("CAL_NAME", "Family")
+	t.Setenv("CAL_BASE_TIMEZONE", "Europe/Stockholm")
+	t.Setenv("CAL_SYNC_INTERVAL", "2m")
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Stockholm", cfg.BaseTimezone.String())
+	assert.Equal(t, 2*time.Minute, cfg.SyncInterval)
+	assert.Equal(t, []Source{{Name: "Family", Color: DefaultColors[0], URL: "https://caldav.icloud.com", Username: "family", Password: "secret", Calendar: "Family"}}, cfg.Sources)
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"missing CAL_NAME", map[string]string{"CAL_URL": "https://caldav.icloud.com"}},
+		{"invalid timezone", map[string]string{"CAL_BASE_TIMEZONE": "Mars/Olympus"}},
+		{"invalid sync interval", map[string]string{"CAL_SYNC_INTERVAL": "often"}},
+		{"too short sync interval", map[string]string{"CAL_SYNC_INTERVAL": "1s"}},
+		{"missing URL", map[string]string{"CAL_SOURCES": "family"}},
+		{"invalid color", map[string]string{"CAL_URL": "https://caldav.icloud.com", "CAL_SOURCES": "family", "CAL_SOURCE_FAMILY_COLOR": "red"}},
+		{"invalid ICS URL", map[string]string{"CAL_SOURCES": "school", "CAL_SOURCE_SCHOOL_ICS": "school.ics"}},
+		{"duplicate", map[string]string{"CAL_URL": "https://caldav.icloud.com", "CAL_SOURCES": "family,perhe", "CAL_SOURCE_PERHE_NAME": "Family"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCalEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := LoadConfig()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadConfigNone(t *testing.T) {
+	clearCalEnv(t)
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Sources)
+	assert.Equal(t, DefaultSyncInterval, cfg.SyncInterval)
+}
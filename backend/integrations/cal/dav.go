@@ -0,0 +1,259 @@
+package cal
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	webdav "github.com/emersion/go-webdav"
+)
+
+// errInvalidSyncToken is returned by syncCollection when the server no longer
+// accepts the sync token, and the calendar needs a full sync.
+var errInvalidSyncToken = errors.New("invalid sync token")
+
+// davClient makes the WebDAV requests go-webdav has no client for: reading
+// the getctag and sync-token of a calendar, listing the etags of its objects
+// and the sync-collection REPORT of RFC 6578.
+type davClient struct {
+	http     webdav.HTTPClient
+	endpoint *url.URL
+}
+
+func newDAVClient(httpClient webdav.HTTPClient, endpoint string) (*davClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CalDAV URL %q: %w", endpoint, err)
+	}
+	return &davClient{http: httpClient, endpoint: u}, nil
+}
+
+type multistatus struct {
+	Responses []davResponse `xml:"DAV: response"`
+	SyncToken string        `xml:"DAV: sync-token"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"DAV: href"`
+	Status    string        `xml:"DAV: status"`
+	Propstats []davPropstat `xml:"DAV: propstat"`
+}
+
+type davPropstat struct {
+	Status string  `xml:"DAV: status"`
+	Prop   davProp `xml:"DAV: prop"`
+}
+
+type davProp struct {
+	CTag      string `xml:"http://calendarserver.org/ns/ getctag"`
+	SyncToken string `xml:"DAV: sync-token"`
+	ETag      string `xml:"DAV: getetag"`
+}
+
+// prop returns the properties the server found for the response.
+func (r davResponse) prop() davProp {
+	for _, ps := range r.Propstats {
+		if statusCode(ps.Status) == http.StatusOK {
+			return ps.Prop
+		}
+	}
+	return davProp{}
+}
+
+// path returns the path of the resource of the response.
+func (r davResponse) path() (string, error) {
+	u, err := url.Parse(strings.TrimSpace(r.Href))
+	if err != nil {
+		return "", fmt.Errorf("invalid href %q: %w", r.Href, err)
+	}
+	return u.Path, nil
+}
+
+// statusCode returns the code of a status line like "HTTP/1.1 200 OK".
+func statusCode(status string) int {
+	fields := strings.Fields(status)
+	if len(fields) < 2 {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[1])
+	return code
+}
+
+// unquoteETag returns an etag without its quotes.
+func unquoteETag(etag string) string {
+	if unquoted, err := strconv.Unquote(etag); err == nil {
+		return unquoted
+	}
+	return etag
+}
+
+type preconditionKey struct{}
+
+// precondition is the If-Match or If-None-Match header of a write.
+type precondition struct {
+	header, value string
+}
+
+// ifMatch returns a context whose write only succeeds if the object still
+// has the etag. Without an etag the write is unconditional.
+func ifMatch(ctx context.Context, etag string) context.Context {
+	if etag == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, preconditionKey{}, precondition{"If-Match", strconv.Quote(etag)})
+}
+
+// ifNoneMatch returns a context whose write only succeeds if there is no
+// object at the path yet.
+func ifNoneMatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, precondition{"If-None-Match", "*"})
+}
+
+// conditionalClient adds the precondition of the request context to PUT and
+// DELETE requests, which the caldav client has no option for. A failed
+// precondition is returned as ErrConflict and a missing object as
+// ErrEventNotFound.
+type conditionalClient struct {
+	http webdav.HTTPClient
+}
+
+func (c conditionalClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodDelete {
+		return c.http.Do(req)
+	}
+	if p, ok := req.Context().Value(preconditionKey{}).(precondition); ok {
+		req.Header.Set(p.header, p.value)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusPreconditionFailed:
+		resp.Body.Close()
+		return nil, ErrConflict
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrEventNotFound
+	}
+	return resp, nil
+}
+
+func (c *davClient) do(ctx context.Context, method, path, depth, body string) (*multistatus, error) {
+	u := c.endpoint.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", depth)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		if bytes.Contains(data, []byte("valid-sync-token")) {
+			return nil, errInvalidSyncToken
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	var ms multistatus
+	if err := xml.Unmarshal(data, &ms); err != nil {
+		return nil, fmt.Errorf("error parsing %s response: %w", method, err)
+	}
+	return &ms, nil
+}
+
+// collectionState returns the getctag and sync-token of a calendar, either
+// empty if the server does not support it.
+func (c *davClient) collectionState(ctx context.Context, calPath string) (ctag, syncToken string, err error) {
+	const body = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">
+  <d:prop><cs:getctag/><d:sync-token/></d:prop>
+</d:propfind>`
+	ms, err := c.do(ctx, "PROPFIND", calPath, "0", body)
+	if err != nil {
+		return "", "", err
+	}
+	for _, r := range ms.Responses {
+		prop := r.prop()
+		if prop.CTag != "" || prop.SyncToken != "" {
+			return strings.TrimSpace(prop.CTag), strings.TrimSpace(prop.SyncToken), nil
+		}
+	}
+	return "", "", nil
+}
+
+// listETags returns the etags of the objects in a calendar by their path.
+func (c *davClient) listETags(ctx context.Context, calPath string) (map[string]string, error) {
+	const body = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:">
+  <d:prop><d:getetag/></d:prop>
+</d:propfind>`
+	ms, err := c.do(ctx, "PROPFIND", calPath, "1", body)
+	if err != nil {
+		return nil, err
+	}
+	etags := make(map[string]string)
+	for _, r := range ms.Responses {
+		p, err := r.path()
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSuffix(p, "/") == strings.TrimSuffix(calPath, "/") {
+			continue
+		}
+		etags[p] = unquoteETag(strings.TrimSpace(r.prop().ETag))
+	}
+	return etags, nil
+}
+
+// syncCollection returns the objects of a calendar changed since syncToken
+// with their etags, the paths of the objects removed since and the new sync
+// token.
+func (c *davClient) syncCollection(ctx context.Context, calPath, syncToken string) (changed map[string]string, removed []string, newToken string, err error) {
+	var token bytes.Buffer
+	if err := xml.EscapeText(&token, []byte(syncToken)); err != nil {
+		return nil, nil, "", err
+	}
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<d:sync-collection xmlns:d="DAV:">
+  <d:sync-token>` + token.String() + `</d:sync-token>
+  <d:sync-level>1</d:sync-level>
+  <d:prop><d:getetag/></d:prop>
+</d:sync-collection>`
+	ms, err := c.do(ctx, "REPORT", calPath, "0", body)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	changed = make(map[string]string)
+	for _, r := range ms.Responses {
+		p, err := r.path()
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if strings.TrimSuffix(p, "/") == strings.TrimSuffix(calPath, "/") {
+			continue
+		}
+		if statusCode(r.Status) == http.StatusNotFound {
+			removed = append(removed, p)
+			continue
+		}
+		if etag := r.prop().ETag; etag != "" {
+			changed[p] = unquoteETag(strings.TrimSpace(etag))
+		}
+	}
+	return changed, removed, strings.TrimSpace(ms.SyncToken), nil
+}
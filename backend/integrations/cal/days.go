@@ -0,0 +1,80 @@
+package cal
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Segment is the part of an event on a single day. Day counts the days of
+// the event from 1 to Days, an event within a day is a single segment.
+type Segment struct {
+	Event
+	Date string `json:"date"`
+	Day  int    `json:"day"`
+	Days int    `json:"days"`
+}
+
+func (s Segment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		eventJSON
+		Date string `json:"date"`
+		Day  int    `json:"day"`
+		Days int    `json:"days"`
+	}{s.Event.json(), s.Date, s.Day, s.Days})
+}
+
+// SplitDays splits the events into a segment for each day in loc they take
+// place on, with the start and end clipped to the day. All-day events are on
+// their dates in any loc. Only the days overlapping from and to are returned. An event ending at midnight does not
+// continue to the next day. The segments are sorted by start and end so the
+// segments of all-day and longer events come first on a day.
+func SplitDays(events []Event, from, to time.Time, loc *time.Location) []Segment {
+	segments := []Segment{}
+	for _, e := range events {
+		if e.AllDay {
+			start, end := e.dates()
+			e.Start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+			e.End = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+		}
+		first := midnight(e.Start, loc)
+		last := midnight(e.End, loc)
+		if last.Equal(e.End) && e.End.After(e.Start) {
+			last = last.AddDate(0, 0, -1)
+		}
+		days := 1
+		for d := first; d.Before(last); d = d.AddDate(0, 0, 1) {
+			days++
+		}
+		day := first
+		for i := 1; i <= days; i, day = i+1, day.AddDate(0, 0, 1) {
+			next := day.AddDate(0, 0, 1)
+			if !next.After(from) || !day.Before(to) {
+				continue
+			}
+			s := Segment{Event: e, Date: day.Format(time.DateOnly), Day: i, Days: days}
+			if s.Start.Before(day) {
+				s.Start = day
+			}
+			if s.End.After(next) {
+				s.End = next
+			}
+			segments = append(segments, s)
+		}
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		if segments[i].Start.Equal(segments[j].Start) {
+			if segments[i].End.Equal(segments[j].End) {
+				return segments[i].AllDay && !segments[j].AllDay
+			}
+			return segments[i].End.After(segments[j].End)
+		}
+		return segments[i].Start.Before(segments[j].Start)
+	})
+	return segments
+}
+
+func midnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
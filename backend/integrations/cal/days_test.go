@@ -0,0 +1,101 @@
+package cal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDays(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 3, day, hour, 0, 0, 0, helsinki)
+	}
+	trip := Event{Uid: "trip", Summary: "Cabin trip", Start: at(28, 18), End: at(30, 16)}
+	holiday := Event{Uid: "holiday", Summary: "Holiday", Start: at(28, 0), End: at(30, 0), AllDay: true}
+	dinner := Event{Uid: "dinner", Summary: "Dinner", Start: at(29, 17), End: at(29, 19)}
+	late := Event{Uid: "late", Summary: "Late shift", Start: at(29, 20), End: at(30, 0)}
+
+	segments := SplitDays([]Event{trip, holiday, dinner, late}, at(1, 0), at(31, 0), helsinki)
+	type seg struct {
+		uid        string
+		date       string
+		day, days  int
+		start, end time.Time
+	}
+	var got []seg
+	for _, s := range segments {
+		got = append(got, seg{s.Uid, s.Date, s.Day, s.Days, s.Start, s.End})
+	}
+	assert.Equal(t, []seg{
+		{"holiday", "2025-03-28", 1, 2, at(28, 0), at(29, 0)},
+		{"trip", "2025-03-28", 1, 3, at(28, 18), at(29, 0)},
+		{"holiday", "2025-03-29", 2, 2, at(29, 0), at(30, 0)},
+		{"trip", "2025-03-29", 2, 3, at(29, 0), at(30, 0)},
+		{"dinner", "2025-03-29", 1, 1, at(29, 17), at(29, 19)},
+		{"late", "2025-03-29", 1, 1, at(29, 20), at(30, 0)},
+		{"trip", "2025-03-30", 3, 3, at(30, 0), at(30, 16)},
+	}, got)
+	// Summer time starts on the last day of the trip, which is 23 hours long
+	assert.Equal(t, 23*time.Hour, at(31, 0).Sub(segments[6].Start))
+}
+
+func TestSplitDaysWindow(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 6, day, hour, 0, 0, 0, helsinki)
+	}
+	trip := Event{Uid: "trip", Start: at(10, 12), End: at(14, 12)}
+	segments := SplitDays([]Event{trip}, at(12, 8), at(13, 0), helsinki)
+	require.Len(t, segments, 1)
+	assert.Equal(t, "2025-06-12", segments[0].Date)
+	assert.Equal(t, 3, segments[0].Day)
+	assert.Equal(t, 5, segments[0].Days)
+
+	instant := Event{Uid: "reminder", Start: at(12, 9), End: at(12, 9)}
+	segments = SplitDays([]Event{instant}, at(1, 0), at(30, 0), helsinki)
+	require.Len(t, segments, 1)
+	assert.Equal(t, 1, segments[0].Days)
+	assert.Empty(t, SplitDays(nil, at(1, 0), at(30, 0), helsinki))
+}
+
+func TestAllDayDates(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A birthday read with Helsinki as the base time zone
+	birthday := Event{Uid: "birthday", Summary: "Birthday", Start: time.Date(2025, 3, 3, 0, 0, 0, 0, helsinki), End: time.Date(2025, 3, 4, 0, 0, 0, 0, helsinki), AllDay: true}
+
+	data, err := json.Marshal(birthday)
+	require.NoError(t, err)
+	var served map[string]any
+	require.NoError(t, json.Unmarshal(data, &served))
+	assert.Equal(t, "2025-03-03", served["startDate"])
+	assert.Equal(t, "2025-03-04", served["endDate"])
+	assert.Equal(t, "2025-03-03T00:00:00+02:00", served["start"])
+
+	// Split in another zone it stays on its date
+	segments := SplitDays([]Event{birthday}, time.Date(2025, 3, 1, 0, 0, 0, 0, newYork), time.Date(2025, 3, 8, 0, 0, 0, 0, newYork), newYork)
+	require.Len(t, segments, 1)
+	assert.Equal(t, "2025-03-03", segments[0].Date)
+	assert.Equal(t, time.Date(2025, 3, 3, 0, 0, 0, 0, newYork), segments[0].Start)
+	data, err = json.Marshal(segments[0])
+	require.NoError(t, err)
+	served = nil
+	require.NoError(t, json.Unmarshal(data, &served))
+	assert.Equal(t, "2025-03-03", served["date"])
+	assert.Equal(t, "2025-03-03", served["startDate"])
+	assert.Equal(t, float64(1), served["days"])
+	assert.Equal(t, "birthday", served["uid"])
+
+	// Timed events have no dates
+	data, err = json.Marshal(Event{Uid: "dinner", Start: time.Date(2025, 3, 3, 17, 0, 0, 0, helsinki)})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "startDate")
+}
@@ -0,0 +1,166 @@
+package cal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// Event statuses, the STATUS of the VEVENT in lower case
+const (
+	StatusTentative = "tentative"
+	StatusConfirmed = "confirmed"
+	StatusCancelled = "cancelled"
+)
+
+// Attendee is the organizer or an attendee of an event. Status is the
+// participation status in lower case, such as accepted or needs-action.
+type Attendee struct {
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email"`
+	Status string `json:"status,omitempty"`
+}
+
+// Alarm is a reminder of an event. Trigger is the time the reminder goes off
+// and Action how, such as display, audio or email.
+type Alarm struct {
+	Action      string    `json:"action"`
+	Trigger     time.Time `json:"trigger"`
+	Description string    `json:"description,omitempty"`
+}
+
+// Cancelled reports whether the event, or its instance, has been cancelled.
+func (e Event) Cancelled() bool {
+	return e.Status == StatusCancelled
+}
+
+// setDetails sets the fields of e that the VEVENT has a property for, so an
+// override keeps the details of its series it does not change.
+func setDetails(e *Event, comp *ical.Component) {
+	if prop := comp.Props.Get(ical.PropLocation); prop != nil {
+		e.Location = text(prop)
+	}
+	if prop := comp.Props.Get(ical.PropDescription); prop != nil {
+		e.Description = text(prop)
+	}
+	if props := comp.Props.Values(ical.PropCategories); len(props) > 0 {
+		e.Categories = nil
+		for _, prop := range props {
+			categories, err := prop.TextList()
+			if err != nil {
+				categories = strings.Split(prop.Value, ",")
+			}
+			for _, category := range categories {
+				if category = strings.TrimSpace(category); category != "" {
+					e.Categories = append(e.Categories, category)
+				}
+			}
+		}
+	}
+	if prop := comp.Props.Get(ical.PropStatus); prop != nil {
+		e.Status = strings.ToLower(prop.Value)
+	}
+	if prop := comp.Props.Get(ical.PropOrganizer); prop != nil {
+		organizer := attendee(prop)
+		e.Organizer = &organizer
+	}
+	if props := comp.Props.Values(ical.PropAttendee); len(props) > 0 {
+		e.Attendees = nil
+		for i := range props {
+			e.Attendees = append(e.Attendees, attendee(&props[i]))
+		}
+	}
+}
+
+// text returns the unescaped value of a text property, or the value as it is
+// if it is not escaped properly.
+func text(prop *ical.Prop) string {
+	value, err := prop.Text()
+	if err != nil {
+		return prop.Value
+	}
+	return value
+}
+
+func attendee(prop *ical.Prop) Attendee {
+	email := prop.Value
+	if len(email) > len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+		email = email[len("mailto:"):]
+	}
+	return Attendee{
+		Name:   prop.Params.Get(ical.ParamCommonName),
+		Email:  email,
+		Status: strings.ToLower(prop.Params.Get(ical.ParamParticipationStatus)),
+	}
+}
+
+// alarm is a VALARM of an event. The trigger is either at a fixed time or
+// offset from the start, or the end, of each instance.
+type alarm struct {
+	action      string
+	description string
+	at          time.Time
+	offset      time.Duration
+	fromEnd     bool
+}
+
+// componentAlarms returns the VALARMs of a VEVENT.
+func componentAlarms(comp *ical.Component) ([]alarm, error) {
+	var alarms []alarm
+	for _, child := range comp.Children {
+		if child.Name != ical.CompAlarm {
+			continue
+		}
+		trigger := child.Props.Get(ical.PropTrigger)
+		if trigger == nil {
+			return nil, fmt.Errorf("missing TRIGGER in VALARM")
+		}
+		a := alarm{}
+		if prop := child.Props.Get(ical.PropAction); prop != nil {
+			a.action = strings.ToLower(prop.Value)
+		}
+		if prop := child.Props.Get(ical.PropDescription); prop != nil {
+			a.description = text(prop)
+		}
+		if trigger.Params.Get(ical.ParamValue) == string(ical.ValueDateTime) {
+			// Absolute triggers are always in UTC
+			at, err := parseDate(trigger.Value, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing TRIGGER: %w", err)
+			}
+			a.at = at
+		} else {
+			offset, err := trigger.Duration()
+			if err != nil {
+				return nil, fmt.Errorf("error parsing TRIGGER: %w", err)
+			}
+			a.offset = offset
+			a.fromEnd = strings.EqualFold(trigger.Params.Get(ical.ParamRelated), "END")
+		}
+		alarms = append(alarms, a)
+	}
+	return alarms, nil
+}
+
+// alarmTriggers returns the alarms of an instance starting and ending at
+// start and end, sorted by their trigger.
+func alarmTriggers(alarms []alarm, start, end time.Time) []Alarm {
+	var triggers []Alarm
+	for _, a := range alarms {
+		t := a.at
+		if t.IsZero() {
+			t = start.Add(a.offset)
+			if a.fromEnd {
+				t = end.Add(a.offset)
+			}
+		}
+		triggers = append(triggers, Alarm{Action: a.action, Trigger: t.In(start.Location()), Description: a.description})
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Trigger.Before(triggers[j].Trigger)
+	})
+	return triggers
+}
@@ -0,0 +1,118 @@
+package cal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// find returns the event with the summary starting at start.
+func find(t *testing.T, events []Event, summary string, start time.Time) Event {
+	t.Helper()
+	for _, e := range events {
+		if e.Summary == summary && e.Start.Equal(start) {
+			return e
+		}
+	}
+	require.Failf(t, "event not found", "%s at %s", summary, start)
+	return Event{}
+}
+
+func TestExpandCalendarDetails(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	from := time.Date(2025, 2, 10, 0, 0, 0, 0, helsinki)
+	to := time.Date(2025, 4, 8, 0, 0, 0, 0, helsinki)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2025, month, day, hour, min, 0, 0, helsinki)
+	}
+
+	t.Run("icloud", func(t *testing.T) {
+		events, err := expandCalendar(readFixture(t, "icloud.ics"), from, to, helsinki)
+		require.NoError(t, err)
+
+		soccer := find(t, events, "Soccer practice", at(3, 11, 17, 30))
+		assert.Equal(t, "Kaleva sports park", soccer.Location)
+		assert.Equal(t, []Alarm{{Action: "display", Trigger: at(3, 11, 17, 0), Description: "Reminder"}}, soccer.Alarms)
+
+		// The moved instance keeps the location and the alarm of the series,
+		// relative to its new start
+		moved := find(t, events, "Soccer practice (moved to Wednesday)", at(3, 5, 18, 0))
+		assert.Equal(t, "Kaleva sports park", moved.Location)
+		require.Len(t, moved.Alarms, 1)
+		assert.Equal(t, at(3, 5, 17, 30), moved.Alarms[0].Trigger)
+
+		ski := find(t, events, "Ski holiday", at(3, 3, 0, 0))
+		assert.Empty(t, ski.Location)
+		assert.Empty(t, ski.Alarms)
+	})
+
+	t.Run("google", func(t *testing.T) {
+		events, err := expandCalendar(readFixture(t, "google.ics"), from, to, helsinki)
+		require.NoError(t, err)
+
+		call := find(t, events, "Parent-teacher call, Elias", at(3, 10, 10, 0))
+		assert.Equal(t, "Spring term review.\nBring the reading log.", call.Description)
+		assert.Equal(t, "https://meet.example.com/abc-defg-hij", call.Location)
+		assert.Equal(t, StatusConfirmed, call.Status)
+		assert.Equal(t, &Attendee{Name: "Riikka Teacher", Email: "riikka.teacher@example.com"}, call.Organizer)
+		assert.Equal(t, []Attendee{
+			{Name: "Riikka Teacher", Email: "riikka.teacher@example.com", Status: "accepted"},
+			{Name: "Mika", Email: "mika@example.com", Status: "tentative"},
+		}, call.Attendees)
+		assert.Equal(t, []Alarm{
+			{Action: "email", Trigger: at(3, 9, 10, 0), Description: "This is an event reminder"},
+			{Action: "display", Trigger: at(3, 10, 9, 50), Description: "This is an event reminder"},
+		}, call.Alarms)
+
+		piano := find(t, events, "Piano lesson", at(2, 27, 16, 0))
+		assert.True(t, piano.Cancelled())
+	})
+
+	t.Run("nextcloud", func(t *testing.T) {
+		events, err := expandCalendar(readFixture(t, "nextcloud.ics"), from, to, helsinki)
+		require.NoError(t, err)
+
+		swimming := find(t, events, "Swimming school", at(4, 5, 10, 0))
+		assert.Equal(t, "Leppävaara swimming hall", swimming.Location)
+		assert.Equal(t, []string{"Kids", "Sports"}, swimming.Categories)
+		assert.Equal(t, StatusTentative, swimming.Status)
+		// The alarm is relative to the end of the RDATE period
+		assert.Equal(t, []Alarm{{Action: "audio", Trigger: at(4, 5, 12, 5)}}, swimming.Alarms)
+
+		cancelled := find(t, events, "Swimming school", at(3, 8, 10, 0))
+		assert.Equal(t, StatusCancelled, cancelled.Status)
+		assert.Equal(t, "Leppävaara swimming hall", cancelled.Location)
+		assert.Equal(t, []string{"Kids", "Sports"}, cancelled.Categories)
+	})
+}
+
+func TestExpandCalendarAbsoluteAlarm(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	cal := parseICS(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:dentist
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=Europe/Helsinki:20250911T150000
+DTEND;TZID=Europe/Helsinki:20250911T160000
+SUMMARY:Dentist
+CATEGORIES:Health
+CATEGORIES:Kids\,teens
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER;VALUE=DATE-TIME:20250910T150000Z
+END:VALARM
+END:VEVENT
+END:VCALENDAR
+`)
+	events, err := expandCalendar(cal, time.Date(2025, 9, 1, 0, 0, 0, 0, helsinki), time.Date(2025, 9, 30, 0, 0, 0, 0, helsinki), helsinki)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, []string{"Health", "Kids,teens"}, events[0].Categories)
+	assert.Equal(t, []Alarm{{Action: "display", Trigger: time.Date(2025, 9, 10, 18, 0, 0, 0, helsinki)}}, events[0].Alarms)
+}
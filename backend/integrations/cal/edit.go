@@ -0,0 +1,428 @@
+package cal
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+var (
+	ErrEventNotFound = errors.New("event not found")
+	ErrConflict      = errors.New("event has been changed on the server")
+	ErrInvalidEvent  = errors.New("invalid event")
+	ErrReadOnly      = errors.New("calendar is read-only")
+)
+
+// Editor writes events to the CalDAV calendars of the configured sources and
+// records the changes in the Store, so they are served before the next sync.
+// Objects are written only if they still have the etag they were read with,
+// otherwise ErrConflict is returned and the event has to be read again.
+type Editor struct {
+	cfg      *Config
+	store    *Store
+	accounts connections
+}
+
+func NewEditor(cfg *Config, store *Store) *Editor {
+	return &Editor{cfg: cfg, store: store}
+}
+
+// Create adds an event with a new UID to the named calendar, or to the first
+// configured calendar, and returns it as stored. Of the event the summary,
+// times, location, description, categories and status are written.
+func (ed *Editor) Create(ctx context.Context, calendar string, event Event) (Event, error) {
+	event, err := validate(event, ed.cfg.BaseTimezone)
+	if err != nil {
+		return Event{}, err
+	}
+	if calendar == "" {
+		if len(ed.cfg.Sources) == 0 {
+			return Event{}, fmt.Errorf("%w: no calendars configured", ErrUnknownCalendar)
+		}
+		calendar = ed.cfg.Sources[0].Name
+	}
+	sources, err := ed.cfg.Select(calendar)
+	if err != nil {
+		return Event{}, err
+	}
+	source := sources[0]
+	if source.Subscription() {
+		return Event{}, fmt.Errorf("%w: %q is a feed", ErrReadOnly, source.Name)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Event{}, err
+	}
+	name := hex.EncodeToString(id)
+	comp := ical.NewComponent(ical.CompEvent)
+	comp.Props.SetText(ical.PropUID, name+"@gohome")
+	comp.Props.SetDateTime(ical.PropDateTimeStamp, time.Now().UTC())
+	setEvent(comp, event, ed.cfg.BaseTimezone)
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//gohome//calendar//EN")
+	cal.Children = append(cal.Children, comp)
+
+	acc, err := ed.accounts.get(ctx, source)
+	if err != nil {
+		return Event{}, err
+	}
+	calPath, err := acc.calendarPath(source.Calendar)
+	if err != nil {
+		return Event{}, err
+	}
+	etag, err := ed.write(ifNoneMatch(ctx), acc, source, calPath+name+".ics", cal)
+	if err != nil {
+		return Event{}, err
+	}
+	return ed.written(source, comp, etag, nil)
+}
+
+// Update replaces the summary, times, location, description, categories and
+// status of an event, keeping its other properties such as attendees and
+// alarms. With a zero recurrenceID the whole series is changed, otherwise
+// only the instance originally starting then, through an override of the
+// instance. The etag is the one the event was read with, by default the one
+// in the Store.
+func (ed *Editor) Update(ctx context.Context, uid string, recurrenceID time.Time, event Event, etag string) (Event, error) {
+	event, err := validate(event, ed.cfg.BaseTimezone)
+	if err != nil {
+		return Event{}, err
+	}
+	source, p, cal, etag, err := ed.find(uid, etag)
+	if err != nil {
+		return Event{}, err
+	}
+	base := ed.cfg.BaseTimezone
+	master, overrides := seriesComponents(cal, uid)
+	comp := master
+	if !recurrenceID.IsZero() {
+		comp = findOverride(overrides, recurrenceID, base)
+		if comp == nil {
+			if !isInstance(master, recurrenceID, base) {
+				return Event{}, fmt.Errorf("%w: %q has no instance at %s", ErrEventNotFound, uid, recurrenceID.Format(time.RFC3339))
+			}
+			comp = newOverride(master, recurrenceID, base)
+			cal.Children = append(cal.Children, comp)
+		}
+	}
+	if comp == nil {
+		return Event{}, fmt.Errorf("%w: %q", ErrEventNotFound, uid)
+	}
+	setEvent(comp, event, base)
+	touch(comp)
+
+	acc, err := ed.accounts.get(ctx, source)
+	if err != nil {
+		return Event{}, err
+	}
+	newETag, err := ed.write(ifMatch(ctx, etag), acc, source, p, cal)
+	if err != nil {
+		return Event{}, err
+	}
+	var rid *time.Time
+	if !recurrenceID.IsZero() {
+		original := recurrenceID.In(base)
+		rid = &original
+	}
+	return ed.written(source, comp, newETag, rid)
+}
+
+// Delete removes an event, the whole series with a zero recurrenceID and
+// otherwise only the instance originally starting then, by excluding it
+// from the series. The etag is as for Update.
+func (ed *Editor) Delete(ctx context.Context, uid string, recurrenceID time.Time, etag string) error {
+	source, p, cal, etag, err := ed.find(uid, etag)
+	if err != nil {
+		return err
+	}
+	acc, err := ed.accounts.get(ctx, source)
+	if err != nil {
+		return err
+	}
+	base := ed.cfg.BaseTimezone
+	master, overrides := seriesComponents(cal, uid)
+	if !recurrenceID.IsZero() {
+		override := findOverride(overrides, recurrenceID, base)
+		if override == nil && !isInstance(master, recurrenceID, base) {
+			return fmt.Errorf("%w: %q has no instance at %s", ErrEventNotFound, uid, recurrenceID.Format(time.RFC3339))
+		}
+		var children []*ical.Component
+		for _, child := range cal.Children {
+			if child != override {
+				children = append(children, child)
+			}
+		}
+		cal.Children = children
+		if master != nil {
+			exdate := ical.NewProp(ical.PropExceptionDates)
+			setInstanceTime(exdate, recurrenceID, master, base)
+			master.Props.Add(exdate)
+			touch(master)
+		}
+		if master != nil || hasEvents(cal) {
+			_, err := ed.write(ifMatch(ctx, etag), acc, source, p, cal)
+			return err
+		}
+	}
+	if err := acc.client.RemoveAll(ifMatch(ctx, etag), p); err != nil {
+		return fmt.Errorf("error deleting %s: %w", p, err)
+	}
+	ed.store.remove(source.Name, p)
+	return nil
+}
+
+// find returns a copy of the stored object of the event with the UID to
+// change, and the etag to write it with.
+func (ed *Editor) find(uid, etag string) (Source, string, *ical.Calendar, string, error) {
+	source, p, obj, err := ed.store.find(uid, ed.cfg.Sources)
+	if err != nil {
+		return source, "", nil, "", err
+	}
+	if source.Subscription() {
+		return source, "", nil, "", fmt.Errorf("%w: %q is a feed", ErrReadOnly, source.Name)
+	}
+	if etag == "" {
+		etag = obj.etag
+	}
+	// The stored object is shared with the readers of the Store
+	var buf bytes.Buffer
+	if err := ical.NewEncoder(&buf).Encode(obj.data); err != nil {
+		return source, "", nil, "", fmt.Errorf("error encoding %s: %w", p, err)
+	}
+	cal, err := ical.NewDecoder(&buf).Decode()
+	if err != nil {
+		return source, "", nil, "", fmt.Errorf("error decoding %s: %w", p, err)
+	}
+	return source, p, cal, etag, nil
+}
+
+// write puts the object to the path and stores it. The etag of the written
+// object is returned if the server tells it.
+func (ed *Editor) write(ctx context.Context, acc *account, source Source, p string, cal *ical.Calendar) (string, error) {
+	obj, err := acc.client.PutCalendarObject(ctx, p, cal)
+	if err != nil {
+		return "", fmt.Errorf("error writing %s: %w", p, err)
+	}
+	ed.store.put(source.Name, p, obj.ETag, cal)
+	return obj.ETag, nil
+}
+
+// written returns the event of a written VEVENT.
+func (ed *Editor) written(source Source, comp *ical.Component, etag string, recurrenceID *time.Time) (Event, error) {
+	e, err := componentEvent(comp, Event{}, ed.cfg.BaseTimezone)
+	if err != nil {
+		return Event{}, err
+	}
+	e.Calendar = source.Name
+	e.Color = source.Color
+	e.Owner = source.Owner
+	e.RecurrenceID = recurrenceID
+	e.ETag = etag
+	return e, nil
+}
+
+// validate checks the fields of an event to write. A missing end is the
+// start, or the next day for all-day events.
+func validate(e Event, base *time.Location) (Event, error) {
+	e.Summary = strings.TrimSpace(e.Summary)
+	if e.Summary == "" {
+		return e, fmt.Errorf("%w: summary is required", ErrInvalidEvent)
+	}
+	if e.Start.IsZero() {
+		return e, fmt.Errorf("%w: start is required", ErrInvalidEvent)
+	}
+	if e.AllDay {
+		start := e.Start.In(base)
+		e.Start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, base)
+		if !e.End.IsZero() {
+			end := e.End.In(base)
+			e.End = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, base)
+		}
+		if !e.End.After(e.Start) {
+			e.End = e.Start.AddDate(0, 0, 1)
+		}
+	} else if e.End.IsZero() {
+		e.End = e.Start
+	}
+	if e.End.Before(e.Start) {
+		return e, fmt.Errorf("%w: end %s is before start %s", ErrInvalidEvent, e.End.Format(time.RFC3339), e.Start.Format(time.RFC3339))
+	}
+	e.Status = strings.ToLower(e.Status)
+	switch e.Status {
+	case "", StatusTentative, StatusConfirmed, StatusCancelled:
+	default:
+		return e, fmt.Errorf("%w: unknown status %q", ErrInvalidEvent, e.Status)
+	}
+	return e, nil
+}
+
+// setEvent sets the properties of a VEVENT to the fields of an event. Times
+// keep the TZID of the current DTSTART.
+func setEvent(comp *ical.Component, e Event, base *time.Location) {
+	tzid := timeZoneID(comp.Props.Get(ical.PropDateTimeStart))
+	comp.Props.SetText(ical.PropSummary, e.Summary)
+	start := ical.NewProp(ical.PropDateTimeStart)
+	setTime(start, e.Start, e.AllDay, tzid, base)
+	comp.Props.Set(start)
+	end := ical.NewProp(ical.PropDateTimeEnd)
+	setTime(end, e.End, e.AllDay, tzid, base)
+	comp.Props.Set(end)
+	comp.Props.Del(ical.PropDuration)
+	setText(comp, ical.PropLocation, e.Location)
+	setText(comp, ical.PropDescription, e.Description)
+	comp.Props.Del(ical.PropCategories)
+	if len(e.Categories) > 0 {
+		categories := ical.NewProp(ical.PropCategories)
+		categories.SetTextList(e.Categories)
+		comp.Props.Set(categories)
+	}
+	comp.Props.Del(ical.PropStatus)
+	if e.Status != "" {
+		status := ical.NewProp(ical.PropStatus)
+		status.Value = strings.ToUpper(e.Status)
+		comp.Props.Set(status)
+	}
+}
+
+// setText sets a text property, or removes it when the text is empty.
+func setText(comp *ical.Component, name, text string) {
+	if text == "" {
+		comp.Props.Del(name)
+		return
+	}
+	comp.Props.SetText(name, text)
+}
+
+// setTime sets a date-time property to t, as a date in base for all-day
+// events, a local time for a TZID, or otherwise in UTC.
+func setTime(prop *ical.Prop, t time.Time, allDay bool, tzid string, base *time.Location) {
+	prop.Params.Del(ical.ParamTimezoneID)
+	switch {
+	case allDay:
+		prop.SetDate(t.In(base))
+	case tzid != "":
+		prop.SetValueType(ical.ValueDateTime)
+		prop.Params.Set(ical.ParamTimezoneID, tzid)
+		prop.Value = t.In(loadLocation(tzid, base)).Format("20060102T150405")
+	default:
+		prop.SetDateTime(t.UTC())
+	}
+}
+
+// timeZoneID returns the TZID of a date-time property, or empty for dates,
+// UTC and floating times.
+func timeZoneID(prop *ical.Prop) string {
+	if prop == nil || isDate(prop) {
+		return ""
+	}
+	return prop.Params.Get(ical.ParamTimezoneID)
+}
+
+// setInstanceTime sets a RECURRENCE-ID or EXDATE property of an instance
+// of master in the form of its DTSTART.
+func setInstanceTime(prop *ical.Prop, t time.Time, master *ical.Component, base *time.Location) {
+	dtstart := master.Props.Get(ical.PropDateTimeStart)
+	setTime(prop, t, dtstart != nil && isDate(dtstart), timeZoneID(dtstart), base)
+}
+
+// touch bumps the SEQUENCE of a changed VEVENT and sets its DTSTAMP.
+func touch(comp *ical.Component) {
+	sequence := 0
+	if prop := comp.Props.Get(ical.PropSequence); prop != nil {
+		sequence, _ = prop.Int()
+	}
+	prop := ical.NewProp(ical.PropSequence)
+	prop.Value = strconv.Itoa(sequence + 1)
+	comp.Props.Set(prop)
+	comp.Props.SetDateTime(ical.PropDateTimeStamp, time.Now().UTC())
+}
+
+// seriesComponents returns the master VEVENT of the UID in cal and its
+// overrides.
+func seriesComponents(cal *ical.Calendar, uid string) (master *ical.Component, overrides []*ical.Component) {
+	for _, child := range cal.Children {
+		if child.Name != ical.CompEvent {
+			continue
+		}
+		if prop := child.Props.Get(ical.PropUID); prop == nil || prop.Value != uid {
+			continue
+		}
+		if child.Props.Get(ical.PropRecurrenceID) != nil {
+			overrides = append(overrides, child)
+		} else {
+			master = child
+		}
+	}
+	return master, overrides
+}
+
+// hasEvents reports whether cal has VEVENTs left.
+func hasEvents(cal *ical.Calendar) bool {
+	for _, child := range cal.Children {
+		if child.Name == ical.CompEvent {
+			return true
+		}
+	}
+	return false
+}
+
+// findOverride returns the override of the instance originally starting at
+// recurrenceID, or nil.
+func findOverride(overrides []*ical.Component, recurrenceID time.Time, base *time.Location) *ical.Component {
+	for _, o := range overrides {
+		times, err := propTimes(o.Props.Get(ical.PropRecurrenceID), base)
+		if err == nil && len(times) == 1 && times[0].Equal(recurrenceID) {
+			return o
+		}
+	}
+	return nil
+}
+
+// isInstance reports whether a recurring event has an instance starting at
+// recurrenceID.
+func isInstance(master *ical.Component, recurrenceID time.Time, base *time.Location) bool {
+	if master == nil {
+		return false
+	}
+	if master.Props.Get(ical.PropRecurrenceRule) == nil && master.Props.Get(ical.PropRecurrenceDates) == nil {
+		return false
+	}
+	instances, err := expandSeries(master, nil, recurrenceID, recurrenceID.Add(time.Second), base)
+	if err != nil {
+		return false
+	}
+	for _, instance := range instances {
+		if instance.RecurrenceID != nil && instance.RecurrenceID.Equal(recurrenceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// newOverride returns an override of the instance of master originally
+// starting at recurrenceID, with the properties and alarms of master.
+func newOverride(master *ical.Component, recurrenceID time.Time, base *time.Location) *ical.Component {
+	override := ical.NewComponent(ical.CompEvent)
+	for name, props := range master.Props {
+		switch name {
+		case ical.PropRecurrenceRule, ical.PropRecurrenceDates, ical.PropExceptionDates:
+			continue
+		}
+		override.Props[name] = append([]ical.Prop(nil), props...)
+	}
+	override.Children = append(override.Children, master.Children...)
+	rid := ical.NewProp(ical.PropRecurrenceID)
+	setInstanceTime(rid, recurrenceID, master, base)
+	override.Props.Set(rid)
+	return override
+}
@@ -0,0 +1,185 @@
+package cal
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const weeklySwimming = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:swimming
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=Europe/Helsinki:20250818T170000
+DTEND;TZID=Europe/Helsinki:20250818T180000
+RRULE:FREQ=WEEKLY
+SUMMARY:Swimming
+LOCATION:Itis
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT30M
+DESCRIPTION:Swimming
+END:VALARM
+END:VEVENT
+END:VCALENDAR
+`
+
+// object returns the object of the test backend at the path.
+func (b *testBackend) object(t *testing.T, p string) *ical.Calendar {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, objects := range b.objects {
+		for _, o := range objects {
+			if o.Path == p {
+				return o.Data
+			}
+		}
+	}
+	t.Fatalf("no object at %s", p)
+	return nil
+}
+
+func newEditTest(t *testing.T) (*testBackend, string, *Store, *Syncer, *Editor) {
+	t.Helper()
+	backend, family, store, syncer := newSyncTest(t, true)
+	backend.addObject(t, family, "swimming.ics", weeklySwimming)
+	require.NoError(t, syncer.Sync(context.Background()))
+	return backend, family, store, syncer, NewEditor(syncer.cfg, store)
+}
+
+func TestEditorCreate(t *testing.T) {
+	backend, family, store, syncer, editor := newEditTest(t)
+	thursday := weekStart.AddDate(0, 0, 3).Add(15 * time.Hour)
+
+	created, err := editor.Create(context.Background(), "", Event{Summary: " Dentist ", Start: thursday, End: thursday.Add(time.Hour), Location: "Kamppi"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(created.Uid, "@gohome"))
+	assert.Equal(t, "Dentist", created.Summary)
+	assert.Equal(t, "Family", created.Calendar)
+	assert.True(t, thursday.Equal(created.Start))
+
+	// The event is served before the next sync, and stays after it
+	assert.Equal(t, []string{"Swimming", "Family dinner", "Sauna", "Dentist"}, summaries(t, store))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, []string{"Swimming", "Family dinner", "Sauna", "Dentist"}, summaries(t, store))
+	name := strings.TrimSuffix(created.Uid, "@gohome") + ".ics"
+	assert.Equal(t, "Kamppi", backend.object(t, family+name).Events()[0].Props.Get(ical.PropLocation).Value)
+
+	birthday, err := editor.Create(context.Background(), "family", Event{Summary: "Ella's birthday", Start: weekStart.AddDate(0, 0, 4).Add(12 * time.Hour), AllDay: true})
+	require.NoError(t, err)
+	assert.True(t, birthday.AllDay)
+	assert.Equal(t, weekStart.AddDate(0, 0, 4), birthday.Start)
+	assert.Equal(t, weekStart.AddDate(0, 0, 5), birthday.End)
+
+	_, err = editor.Create(context.Background(), "", Event{Start: thursday})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+	_, err = editor.Create(context.Background(), "", Event{Summary: "Dentist", Start: thursday, End: thursday.Add(-time.Hour)})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+	_, err = editor.Create(context.Background(), "", Event{Summary: "Dentist", Start: thursday, Status: "maybe"})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+	_, err = editor.Create(context.Background(), "Work", Event{Summary: "Dentist", Start: thursday})
+	assert.ErrorIs(t, err, ErrUnknownCalendar)
+}
+
+func TestEditorUpdateConflict(t *testing.T) {
+	backend, family, store, syncer, editor := newEditTest(t)
+	events, err := store.Events(weekStart, weekEnd, false)
+	require.NoError(t, err)
+	dinner := events[1]
+	require.Equal(t, "dinner", dinner.Uid)
+	require.NotEmpty(t, dinner.ETag)
+
+	dinner.Summary = "Pizza night"
+	dinner.Categories = []string{"Food", "Family"}
+	_, err = editor.Update(context.Background(), "dinner", time.Time{}, dinner, "stale")
+	assert.ErrorIs(t, err, ErrConflict)
+
+	updated, err := editor.Update(context.Background(), "dinner", time.Time{}, dinner, dinner.ETag)
+	require.NoError(t, err)
+	assert.Equal(t, "Pizza night", updated.Summary)
+	assert.Equal(t, []string{"Food", "Family"}, updated.Categories)
+	assert.Equal(t, "1", backend.object(t, family+"dinner.ics").Events()[0].Props.Get(ical.PropSequence).Value)
+	assert.Equal(t, []string{"Swimming", "Pizza night", "Sauna"}, summaries(t, store))
+
+	// Changed on the server since the sync
+	require.NoError(t, syncer.Sync(context.Background()))
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Taco night", weekStart.AddDate(0, 0, 1).Add(12*time.Hour), time.Hour))
+	_, err = editor.Update(context.Background(), "dinner", time.Time{}, dinner, "")
+	assert.ErrorIs(t, err, ErrConflict)
+	err = editor.Delete(context.Background(), "dinner", time.Time{}, "")
+	assert.ErrorIs(t, err, ErrConflict)
+
+	require.NoError(t, syncer.Sync(context.Background()))
+	require.NoError(t, editor.Delete(context.Background(), "dinner", time.Time{}, ""))
+	assert.Equal(t, []string{"Swimming", "Sauna"}, summaries(t, store))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, []string{"Swimming", "Sauna"}, summaries(t, store))
+
+	_, err = editor.Update(context.Background(), "dinner", time.Time{}, dinner, "")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
+
+func TestEditorRecurringInstance(t *testing.T) {
+	backend, family, store, syncer, editor := newEditTest(t)
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	thisWeek := time.Date(2025, 9, 1, 17, 0, 0, 0, helsinki)
+	nextWeek := thisWeek.AddDate(0, 0, 7)
+
+	// Only this week's swimming moves to Tuesday
+	moved := Event{Summary: "Swimming", Start: thisWeek.AddDate(0, 0, 1), End: thisWeek.AddDate(0, 0, 1).Add(time.Hour), Location: "Itis"}
+	updated, err := editor.Update(context.Background(), "swimming", thisWeek, moved, "")
+	require.NoError(t, err)
+	require.NotNil(t, updated.RecurrenceID)
+	assert.True(t, thisWeek.Equal(*updated.RecurrenceID))
+	require.NoError(t, syncer.Sync(context.Background()))
+
+	events, err := store.Events(thisWeek.AddDate(0, 0, -1), nextWeek.AddDate(0, 0, 1), false, "Family")
+	require.NoError(t, err)
+	var swimming []Event
+	for _, e := range events {
+		if e.Uid == "swimming" {
+			swimming = append(swimming, e)
+		}
+	}
+	require.Len(t, swimming, 2)
+	assert.True(t, thisWeek.AddDate(0, 0, 1).Equal(swimming[0].Start))
+	assert.True(t, thisWeek.Equal(*swimming[0].RecurrenceID))
+	require.Len(t, swimming[0].Alarms, 1)
+	assert.True(t, nextWeek.Equal(swimming[1].Start))
+
+	cal := backend.object(t, family+"swimming.ics")
+	require.Len(t, cal.Events(), 2)
+	rid := cal.Events()[1].Props.Get(ical.PropRecurrenceID)
+	assert.Equal(t, "20250901T170000", rid.Value)
+	assert.Equal(t, "Europe/Helsinki", rid.Params.Get(ical.ParamTimezoneID))
+	assert.Nil(t, cal.Events()[1].Props.Get(ical.PropRecurrenceRule))
+
+	// Deleting the instances leaves the rest of the series
+	require.NoError(t, editor.Delete(context.Background(), "swimming", thisWeek, ""))
+	require.NoError(t, editor.Delete(context.Background(), "swimming", nextWeek, ""))
+	events, err = store.Events(thisWeek.AddDate(0, 0, -1), nextWeek.AddDate(0, 0, 8), false, "Family")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.True(t, nextWeek.AddDate(0, 0, 7).Equal(events[2].Start))
+	cal = backend.object(t, family+"swimming.ics")
+	require.Len(t, cal.Events(), 1)
+	assert.Len(t, cal.Events()[0].Props.Values(ical.PropExceptionDates), 2)
+
+	_, err = editor.Update(context.Background(), "swimming", thisWeek.Add(time.Hour), moved, "")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	err = editor.Delete(context.Background(), "swimming", nextWeek, "")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+
+	require.NoError(t, editor.Delete(context.Background(), "swimming", time.Time{}, ""))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, []string{"Family dinner", "Sauna"}, summaries(t, store))
+}
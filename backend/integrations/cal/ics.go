@@ -0,0 +1,75 @@
+package cal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// maxFeedSize is the largest iCalendar feed read.
+const maxFeedSize = 16 << 20
+
+// feed is an iCalendar feed as fetched, with the validators to fetch it again
+// only when it has changed.
+type feed struct {
+	cal          *ical.Calendar
+	etag         string
+	lastModified string
+}
+
+// fetchFeed downloads the iCalendar feed at feedURL. With the ETag and
+// Last-Modified of the previous fetch the request is conditional, and a nil
+// feed is returned if the feed has not changed.
+func fetchFeed(ctx context.Context, client *http.Client, feedURL, etag, lastModified string) (*feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ical.MIMEType)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("GET %s: %s", feedURL, resp.Status)
+	}
+	cal, err := ical.NewDecoder(io.LimitReader(resp.Body, maxFeedSize)).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", feedURL, err)
+	}
+	return &feed{cal: cal, etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified")}, nil
+}
+
+// getFeedEvents returns the events of a feed between start and end.
+func getFeedEvents(ctx context.Context, source Source, start, end time.Time, base *time.Location) ([]Event, error) {
+	f, err := fetchFeed(ctx, http.DefaultClient, source.ICS, "", "")
+	if err != nil {
+		return nil, err
+	}
+	found, err := expandCalendar(f.cal, start, end, base)
+	if err != nil {
+		return nil, fmt.Errorf("error in %s: %w", source.ICS, err)
+	}
+	for i := range found {
+		found[i].Calendar = source.Name
+		found[i].Color = source.Color
+		found[i].Owner = source.Owner
+		found[i].ReadOnly = true
+	}
+	return found, nil
+}
@@ -0,0 +1,174 @@
+package cal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feedServer serves a fixture as an iCalendar feed, with the fixture name as
+// the ETag unless etags is off, and answers conditional requests.
+type feedServer struct {
+	mu          sync.Mutex
+	fixture     string
+	modified    time.Time
+	etags       bool
+	status      int
+	fetches     int
+	notModified int
+}
+
+func newFeedServer(t *testing.T, fixture string, etags bool) (*httptest.Server, *feedServer) {
+	t.Helper()
+	f := &feedServer{fixture: fixture, modified: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), etags: etags}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.fetches++
+		if f.status != 0 {
+			http.Error(w, http.StatusText(f.status), f.status)
+			return
+		}
+		etag := `"` + f.fixture + `"`
+		if f.etags {
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Last-Modified", f.modified.Format(http.TimeFormat))
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if (f.etags && r.Header.Get("If-None-Match") == etag) || (!f.etags && err == nil && !f.modified.After(since)) {
+			f.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", f.fixture))
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, f
+}
+
+// change serves another fixture from now on.
+func (f *feedServer) change(fixture string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fixture = fixture
+	f.modified = f.modified.Add(time.Hour)
+}
+
+// fail answers with the status from now on.
+func (f *feedServer) fail(status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
+
+func (f *feedServer) counts() (fetches, notModified int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.fetches, f.notModified
+}
+
+func TestSyncerFeed(t *testing.T) {
+	for _, etags := range []bool{true, false} {
+		name := "etag"
+		if !etags {
+			name = "last-modified"
+		}
+		t.Run(name, func(t *testing.T) {
+			helsinki, err := time.LoadLocation("Europe/Helsinki")
+			require.NoError(t, err)
+			from := time.Date(2025, 3, 5, 0, 0, 0, 0, helsinki)
+			to := from.AddDate(0, 0, 2)
+
+			srv, feed := newFeedServer(t, "google.ics", etags)
+			cfg := &Config{BaseTimezone: helsinki}
+			require.NoError(t, cfg.add(Source{Name: "Hobbies", ICS: srv.URL + "/hobbies.ics"}))
+			store := NewStore(cfg)
+			syncer := NewSyncer(cfg, store, time.Minute)
+
+			require.NoError(t, syncer.Sync(context.Background()))
+			events, err := store.Events(from, to, false)
+			require.NoError(t, err)
+			assert.Equal(t, []string{
+				"2025-03-05T18:00+02:00 2025-03-05T20:00+02:00 Book club",
+				"2025-03-06T16:00+02:00 2025-03-06T16:45+02:00 Piano lesson",
+			}, describe(events))
+			assert.True(t, events[0].ReadOnly)
+			assert.Equal(t, "Hobbies", events[0].Calendar)
+
+			// The feed is downloaded again only when it has changed
+			require.NoError(t, syncer.Sync(context.Background()))
+			fetches, notModified := feed.counts()
+			assert.Equal(t, 2, fetches)
+			assert.Equal(t, 1, notModified)
+			events, err = store.Events(from, to, false)
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+
+			feed.change("icloud.ics")
+			require.NoError(t, syncer.Sync(context.Background()))
+			_, notModified = feed.counts()
+			assert.Equal(t, 1, notModified)
+			events, err = store.Events(from, to, false)
+			require.NoError(t, err)
+			assert.Equal(t, []string{
+				"2025-03-03T00:00+02:00 2025-03-06T00:00+02:00 Ski holiday *",
+				"2025-03-05T18:00+02:00 2025-03-05T19:30+02:00 Soccer practice (moved to Wednesday)",
+			}, describe(events))
+
+			// A failing feed keeps its events
+			feed.fail(http.StatusBadGateway)
+			assert.ErrorContains(t, syncer.Sync(context.Background()), "Hobbies")
+			events, err = store.Events(from, to, false)
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+		})
+	}
+}
+
+func TestFeedWithCalDAV(t *testing.T) {
+	feedSrv, _ := newFeedServer(t, "google.ics", true)
+	srv, backend := newTestServer(t, "shared")
+	family := backend.addCalendar("Family")
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Family dinner", time.Date(2025, 3, 6, 12, 0, 0, 0, time.UTC), time.Hour))
+
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "shared", Password: "shared"}))
+	require.NoError(t, cfg.add(Source{Name: "Hobbies", ICS: feedSrv.URL + "/hobbies.ics"}))
+	from := time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	events, err := GetFamilyCalendarEvents(context.Background(), cfg, from, to, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2025-03-06T12:00Z 2025-03-06T13:00Z Family dinner",
+		"2025-03-06T14:00Z 2025-03-06T14:45Z Piano lesson",
+	}, describe(events))
+	assert.False(t, events[0].ReadOnly)
+	assert.True(t, events[1].ReadOnly)
+
+	store := NewStore(cfg)
+	require.NoError(t, NewSyncer(cfg, store, time.Minute).Sync(context.Background()))
+	stored, err := store.Events(from, to, false)
+	require.NoError(t, err)
+	assert.Equal(t, describe(events), describe(stored))
+
+	// Feeds can't be changed
+	editor := NewEditor(cfg, store)
+	_, err = editor.Create(context.Background(), "Hobbies", Event{Summary: "Piano recital", Start: from.Add(18 * time.Hour)})
+	assert.ErrorIs(t, err, ErrReadOnly)
+	piano := stored[1]
+	_, err = editor.Update(context.Background(), piano.Uid, time.Time{}, piano, "")
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorIs(t, editor.Delete(context.Background(), piano.Uid, *piano.RecurrenceID, ""), ErrReadOnly)
+}
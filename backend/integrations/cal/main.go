@@ -2,67 +2,161 @@ package cal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
-	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/emersion/go-ical"
 	webdav "github.com/emersion/go-webdav"
 	"github.com/emersion/go-webdav/caldav"
-	"github.com/teambition/rrule-go"
+	"github.com/rs/zerolog/log"
 )
 
 type Event struct {
-	Uid     string    `json:"uid"`
-	Start   time.Time `json:"start"`
-	End     time.Time `json:"end"`
-	Summary string    `json:"summary"`
+	Uid         string     `json:"uid"`
+	Start       time.Time  `json:"start"`
+	End         time.Time  `json:"end"`
+	Summary     string     `json:"summary"`
+	AllDay      bool       `json:"allDay"`
+	Location    string     `json:"location,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Categories  []string   `json:"categories,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Organizer   *Attendee  `json:"organizer,omitempty"`
+	Attendees   []Attendee `json:"attendees,omitempty"`
+	Alarms      []Alarm    `json:"alarms,omitempty"`
+	Calendar    string     `json:"calendar"`
+	Color       string     `json:"color,omitempty"`
+	Owner       string     `json:"owner,omitempty"`
+	// The original start of an instance of a recurring event, which
+	// identifies the instance when it is edited
+	RecurrenceID *time.Time `json:"recurrenceId,omitempty"`
+	// The etag of the calendar object the event was read from
+	ETag string `json:"etag,omitempty"`
+	// Events of feeds can't be changed
+	ReadOnly bool `json:"readOnly,omitempty"`
 }
 
-type DateOffset struct {
-	Years  int
-	Months int
-	Days   int
+// plainEvent is an Event without its MarshalJSON.
+type plainEvent Event
+
+// eventJSON is an event as served, with the dates of an all-day event.
+type eventJSON struct {
+	plainEvent
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
 }
 
-type EventOverride struct {
-	OriginalStart time.Time
-	NewStart      time.Time
-	NewEnd        time.Time
-	NewSummary    string
+func (e Event) json() eventJSON {
+	j := eventJSON{plainEvent: plainEvent(e)}
+	if e.AllDay {
+		start, end := e.dates()
+		j.StartDate, j.EndDate = start.Format(time.DateOnly), end.Format(time.DateOnly)
+	}
+	return j
 }
 
-// Pretty print DateOffset for logging
-func (d DateOffset) String() string {
-	return fmt.Sprintf("%d years, %d months, %d days", d.Years, d.Months, d.Days)
+// MarshalJSON adds the dates of an all-day event as startDate and endDate,
+// YYYY-MM-DD with the end exclusive. Unlike start and end at midnight in the
+// base time zone, the dates are the same in every time zone.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.json())
 }
 
-// GetFamilyCalendarEvents retrieves events from a family calendar within a specified date range.
-// The range is determined by two DateOffset structs, 'from' and 'to'.
-// Each DateOffset represents an offset in years, months, and days from the current date.
-// For example, GetFamilyCalendarEvents(DateOffset{Days: -7}, DateOffset{Days: 7}) retrieves events from one week before to one week after today.
+// dates returns the dates an all-day event starts and ends on as midnight
+// UTC. They are the days of Start and End in their own location, which is
+// the base time zone the dates were read in.
+func (e Event) dates() (time.Time, time.Time) {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return day(e.Start), day(e.End)
+}
+
+// GetFamilyCalendarEvents retrieves the events between start and end from the configured calendars.
+// Only the calendars named in 'calendars' are queried, or all of them when none are named.
+// Each event is tagged with the display name, color and owner of its calendar.
+// Cancelled events are left out unless 'includeCancelled' is set.
 // The function returns a slice of Event structs and an error. If the function succeeds, the error is nil.
 // If the function fails, the slice is nil and the error contains details about the failure.
-func GetFamilyCalendarEvents(from DateOffset, to DateOffset) ([]Event, error) {
-	reqStart := time.Now().AddDate(from.Years, from.Months, from.Days)
-	reqEnd := time.Now().AddDate(to.Years, to.Months, to.Days)
+func GetFamilyCalendarEvents(ctx context.Context, cfg *Config, start, end time.Time, includeCancelled bool, calendars ...string) ([]Event, error) {
+	sources, err := cfg.Select(calendars...)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("event", "cal_get_events").Time("start", start).Time("end", end).Msg("getting family calendar events")
+
+	// Sources on the same account are discovered with a single connection
+	var accounts []string
+	var feeds []Source
+	byAccount := make(map[string][]Source)
+	for _, source := range sources {
+		if source.Subscription() {
+			feeds = append(feeds, source)
+			continue
+		}
+		key := source.account()
+		if _, ok := byAccount[key]; !ok {
+			accounts = append(accounts, key)
+		}
+		byAccount[key] = append(byAccount[key], source)
+	}
+
+	var found []Event
+	for _, key := range accounts {
+		accountEvents, err := getAccountEvents(ctx, byAccount[key], start, end, cfg.BaseTimezone)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, accountEvents...)
+	}
+	for _, source := range feeds {
+		feedEvents, err := getFeedEvents(ctx, source, start, end, cfg.BaseTimezone)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, feedEvents...)
+	}
+
+	events := []Event{}
+	for _, event := range found {
+		if event.Cancelled() && !includeCancelled {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sortByTime(events)
+	log.Debug().Str("event", "cal_got_events").Int("events", len(events)).Msg("")
+	return events, nil
+}
 
-	println("Getting family calendar events from: ", from.String(), " to: ", to.String())
+// account is a connection to a CalDAV account with its discovered calendars.
+type account struct {
+	client    *caldav.Client
+	dav       *davClient
+	calendars []caldav.Calendar
+}
 
+// connect connects to the CalDAV account of a source and discovers its
+// calendars.
+func connect(ctx context.Context, source Source) (*account, error) {
 	httpClient := &http.Client{}
 
-	fmt.Printf("Connecting to %s with %s\n", config.calUrl, config.username)
-	authorizedClient := webdav.HTTPClientWithBasicAuth(httpClient, config.username, config.password)
-	calDavClient, err := caldav.NewClient(authorizedClient, config.calUrl)
+	fmt.Printf("Connecting to %s with %s\n", source.URL, source.Username)
+	authorizedClient := conditionalClient{webdav.HTTPClientWithBasicAuth(httpClient, source.Username, source.Password)}
+	calDavClient, err := caldav.NewClient(authorizedClient, source.URL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
-	ctx := context.Background()
-	ctx, _ = signal.NotifyContext(ctx, os.Interrupt)
+	dav, err := newDAVClient(authorizedClient, source.URL)
+	if err != nil {
+		return nil, err
+	}
 
 	fmt.Print("Finding current user principal. ")
 	curUser, err := calDavClient.FindCurrentUserPrincipal(ctx)
@@ -86,204 +180,208 @@ func GetFamilyCalendarEvents(from DateOffset, to DateOffset) ([]Event, error) {
 	for i, cal := range calendars {
 		fmt.Printf("Calendar %d: %s: %s\n", i, cal.Path, cal.Name)
 	}
+	return &account{client: calDavClient, dav: dav, calendars: calendars}, nil
+}
+
+// calendarPath returns the path of the calendar with the given name.
+func (a *account) calendarPath(name string) (string, error) {
+	for _, cal := range a.calendars {
+		if cal.Name == name {
+			return cal.Path, nil
+		}
+	}
+	return "", fmt.Errorf("calendar %q not found", name)
+}
+
+// connections keeps the connections to the CalDAV accounts of the sources so
+// each account is discovered only once. The zero value is ready to use.
+type connections struct {
+	mu       sync.Mutex
+	accounts map[string]*account
+}
+
+// get returns the connection to the account of a source, connecting to it
+// the first time.
+func (c *connections) get(ctx context.Context, source Source) (*account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if acc, ok := c.accounts[source.account()]; ok {
+		return acc, nil
+	}
+	acc, err := connect(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if c.accounts == nil {
+		c.accounts = make(map[string]*account)
+	}
+	c.accounts[source.account()] = acc
+	return acc, nil
+}
+
+// drop forgets the connection to the account of a source, which is then
+// discovered again.
+func (c *connections) drop(source Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accounts, source.account())
+}
+
+// getAccountEvents queries the calendars of sources that share a CalDAV account.
+func getAccountEvents(ctx context.Context, sources []Source, start, end time.Time, base *time.Location) ([]Event, error) {
+	acc, err := connect(ctx, sources[0])
+	if err != nil {
+		return nil, err
+	}
 
 	events := []Event{}
-	fmt.Printf("Querying calendars with the name '%s'\n", config.calName)
-	calQuery := buildQuery(from, to)
-	for _, cal := range calendars {
-		if cal.Name == config.calName {
+	calQuery := buildQuery(start, end)
+	for _, source := range sources {
+		fmt.Printf("Querying calendars with the name '%s'\n", source.Calendar)
+		for _, cal := range acc.calendars {
+			if cal.Name != source.Calendar {
+				continue
+			}
 			fmt.Printf("Found. Querying calendar: %s\n", cal.Path)
-			objects, err := calDavClient.QueryCalendar(ctx, cal.Path, &calQuery)
+			objects, err := acc.client.QueryCalendar(ctx, cal.Path, &calQuery)
 			if err != nil {
 				return nil, fmt.Errorf("error in QueryCalendar: %w", err)
 			}
 			fmt.Printf("Found %d objects\n", len(objects))
 			for _, obj := range objects {
-				if len(obj.Data.Children) < 1 {
-					continue
-				}
-
-				uid := obj.Data.Children[0].Props.Get("UID")
-				dtstart := obj.Data.Children[0].Props.Get("DTSTART")
-				dtend := obj.Data.Children[0].Props.Get("DTEND")
-				summary := obj.Data.Children[0].Props.Get("SUMMARY")
-				var exdateVal string
-				exdate := obj.Data.Children[0].Props.Get("EXDATE")
-				if exdate != nil {
-					exdateVal = exdate.Value
-				}
-				var rRuleVal string
-				rrule := obj.Data.Children[0].Props.Get("RRULE")
-				if rrule != nil {
-					rRuleVal = rrule.Value
-				}
-				tzid := dtstart.Params.Get("TZID")
-				fmt.Printf("\nParsing object: %s %s %s %s %s, exdate: %s\n",
-					uid, dtstart, dtend, summary, tzid, exdateVal)
-				if rRuleVal != "" {
-					fmt.Printf("RRULE: %s\n", rRuleVal)
-				}
-
-				location, err := time.LoadLocation(tzid)
-				if err != nil {
-					return nil, fmt.Errorf("error loading location with tzid: %s: %w", tzid, err)
-				}
-
-				startTime, err := parseDate(dtstart.Value, location)
+				found, err := expandCalendar(obj.Data, start, end, base)
 				if err != nil {
-					return nil, fmt.Errorf("error parsing start date: %w", err)
+					return nil, err
 				}
-				endTime, err := parseDate(dtend.Value, location)
-				if err != nil {
-					return nil, fmt.Errorf("error parsing end date: %w", err)
-				}
-
-				if rRuleVal != "" {
-					var overrides []EventOverride
-					if len(obj.Data.Children) > 1 {
-						overrides, err = getOverrideEvents(obj.Data.Children[1:], location)
-						if err != nil {
-							return nil, fmt.Errorf("error getting override events: %w", err)
-						}
-					}
-					recEvent := Event{uid.Value, startTime, endTime, summary.Value}
-					eventInstances, err := getRecurrenceEvents(recEvent, reqStart, reqEnd, rRuleVal, exdateVal, location, overrides)
-					if err != nil {
-						return nil, fmt.Errorf("error parsing events based on rrule: %s, err: %w", rRuleVal, err)
-					}
-					events = append(events, eventInstances...)
-				} else {
-					event := Event{uid.Value, startTime, endTime, summary.Value}
+				for _, event := range found {
+					event.Calendar = source.Name
+					event.Color = source.Color
+					event.Owner = source.Owner
+					event.ETag = obj.ETag
 					events = append(events, event)
 				}
 			}
 		}
 	}
+	return events, nil
+}
 
-	// Sort events
-	sort.Slice(events, func(i, j int) bool {
-		if events[i].Start.Equal(events[j].Start) {
-			return events[i].End.Before(events[j].End)
-		} else {
-			return events[i].Start.Before(events[j].Start)
+// loadLocation returns the location of a TZID. Besides IANA names it
+// accepts names with a vendor prefix like /mozilla.org/20050126_1/Europe/Helsinki.
+// Unknown time zones, such as Windows names, fall back to base.
+func loadLocation(tzid string, base *time.Location) *time.Location {
+	if location, err := time.LoadLocation(tzid); err == nil {
+		return location
+	}
+	parts := strings.Split(strings.Trim(tzid, "/"), "/")
+	for i := len(parts) - 2; i >= 0 && i >= len(parts)-3; i-- {
+		if location, err := time.LoadLocation(strings.Join(parts[i:], "/")); err == nil {
+			return location
 		}
-	})
-	for _, event := range events {
-		fmt.Printf("Event: %s %s %s %s\n",
-			event.Uid, event.Start.Format(time.DateTime), event.End.Format(time.DateTime), event.Summary)
 	}
-	return events, nil
+	return base
 }
 
-func getOverrideEvents(childEvents []*ical.Component, tz *time.Location) ([]EventOverride, error) {
-	var overrides []EventOverride
-	for _, child := range childEvents {
-		uidProp := child.Props.Get("UID")
-		if uidProp == nil {
-			return nil, fmt.Errorf("missing UID property in override event")
-		}
+// propLocation returns the location of the TZID of a date-time property.
+// All-day dates and floating times without a TZID are in base.
+func propLocation(prop *ical.Prop, base *time.Location) *time.Location {
+	tzid := prop.Params.Get(ical.ParamTimezoneID)
+	if tzid == "" || isDate(prop) {
+		return base
+	}
+	return loadLocation(tzid, base)
+}
 
-		dtstartProp := child.Props.Get("DTSTART")
-		if dtstartProp == nil {
-			return nil, fmt.Errorf("missing DTSTART property in override event")
-		}
-		dtendProp := child.Props.Get("DTEND")
-		if dtendProp == nil {
-			return nil, fmt.Errorf("missing DTEND property in override event")
-		}
-		summaryProp := child.Props.Get("SUMMARY")
-		if summaryProp == nil {
-			return nil, fmt.Errorf("missing SUMMARY property in override event")
-		}
-		replaceDateProp := child.Props.Get("RECURRENCE-ID")
-		if replaceDateProp == nil {
-			return nil, fmt.Errorf("missing RECURRENCE-ID property in override event")
-		}
-		replaceDate, err := parseDate(replaceDateProp.Value, tz)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing RECURRENCE-ID date: %w", err)
-		}
-		startTime, err := parseDate(dtstartProp.Value, tz)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing start date: %w", err)
-		}
-		endTime, err := parseDate(dtendProp.Value, tz)
+// isDate reports whether a date-time property is a date of an all-day event.
+func isDate(prop *ical.Prop) bool {
+	return isDateValue(prop, prop.Value)
+}
+
+// isDateValue reports whether a value of a date-time property is a date.
+func isDateValue(prop *ical.Prop, value string) bool {
+	return prop.Params.Get(ical.ParamValue) == string(ical.ValueDate) || len(value) == len("20060102")
+}
+
+// propTimes parses the comma separated values of a date-time property like
+// EXDATE, each in the location of the property. Dates are midnight in base.
+func propTimes(prop *ical.Prop, base *time.Location) ([]time.Time, error) {
+	var times []time.Time
+	for _, value := range propValues(prop) {
+		t, err := parseValue(prop, value, base)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing end date: %w", err)
+			return nil, err
 		}
-
-		e := EventOverride{replaceDate, startTime, endTime, summaryProp.Value}
-		overrides = append(overrides, e)
+		times = append(times, t)
 	}
-	return overrides, nil
+	return times, nil
 }
 
-func getRecurrenceEvents(event Event, from, to time.Time, rRuleVal string, exdateStr string, tz *time.Location, overrides []EventOverride) ([]Event, error) {
-	// Calculate event start and end time difference
-	eventDuration := event.End.Sub(event.Start)
-	// Parse the recurrence rule
-	rule, err := rrule.StrToRRule(rRuleVal)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse rrule: %w", err)
+// propValues returns the comma separated values of a property.
+func propValues(prop *ical.Prop) []string {
+	var values []string
+	for _, value := range strings.Split(prop.Value, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
 	}
-	rule.DTStart(event.Start)
-
-	// Generate the dates between from and to
-	dates := rule.Between(event.Start, to, true)
+	return values
+}
 
-	// Parse the exdate string
-	var exDates []time.Time
-	if exdateStr != "" {
-		exdateVals := strings.Split(exdateStr, ",")
-		for _, exdate := range exdateVals {
-			t, err := parseDate(exdate, tz)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse exdate: %w", err)
-			}
-			exDates = append(exDates, t)
-		}
+// parseValue parses a value of a date-time property in the location of the
+// property, or a date as midnight in base.
+func parseValue(prop *ical.Prop, value string, base *time.Location) (time.Time, error) {
+	if isDateValue(prop, value) {
+		return parseDate(value, base)
 	}
+	return parseDate(value, propLocation(prop, base))
+}
 
-	// Create a map for faster lookup of exDate
-	exDate := make(map[time.Time]bool)
-	for _, exdate := range exDates {
-		exDate[exdate] = true
+// eventTimes returns the start and end of a VEVENT, in the locations of
+// their TZIDs, and whether it is an all-day event. All-day events start and
+// end at midnight in base whatever their TZID, so a birthday stays on its
+// date, which Event.MarshalJSON serves as such. Without DTEND the end is taken from DURATION, or defaults to a day
+// for all-day events.
+func eventTimes(comp *ical.Component, base *time.Location) (start, end time.Time, allDay bool, err error) {
+	dtstart := comp.Props.Get(ical.PropDateTimeStart)
+	if dtstart == nil {
+		return start, end, false, fmt.Errorf("missing DTSTART property")
+	}
+	allDay = isDate(dtstart)
+	start, err = parseDate(dtstart.Value, propLocation(dtstart, base))
+	if err != nil {
+		return start, end, allDay, fmt.Errorf("error parsing start date: %w", err)
 	}
 
-	// Create events, filtering out the exdates and events outside the from-to range
-	var events []Event
-	for _, eventFrom := range dates {
-		eventTo := eventFrom.Add(eventDuration)
-		isOutsideRange := eventFrom.After(to) || eventTo.Before(from)
-		if !exDate[eventFrom] && !isOutsideRange {
-			// Check for overrides
-			for _, override := range overrides {
-				if override.OriginalStart.Equal(eventFrom) {
-					fmt.Printf("Applying override for event %s on %s\n", event.Summary, eventFrom)
-					fmt.Printf("New event details: start: %s, end: %s, summary: %s\n", override.NewStart, override.NewEnd, override.NewSummary)
-					eventFrom = override.NewStart
-					eventTo = override.NewEnd
-					if override.NewSummary != "" {
-						event.Summary = override.NewSummary
-					}
-					break
-				}
-			}
-			events = append(events, Event{event.Uid, eventFrom, eventTo, event.Summary})
+	if dtend := comp.Props.Get(ical.PropDateTimeEnd); dtend != nil {
+		end, err = parseDate(dtend.Value, propLocation(dtend, base))
+		if err != nil {
+			return start, end, allDay, fmt.Errorf("error parsing end date: %w", err)
 		}
+	} else if duration := comp.Props.Get(ical.PropDuration); duration != nil {
+		d, err := duration.Duration()
+		if err != nil {
+			return start, end, allDay, fmt.Errorf("error parsing duration: %w", err)
+		}
+		end = start.Add(d)
+		if allDay {
+			// Whole days keep the time of day over DST changes
+			days := int(d / (24 * time.Hour))
+			end = start.AddDate(0, 0, days).Add(d - time.Duration(days)*24*time.Hour)
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	} else {
+		end = start
 	}
-
-	fmt.Printf("Recurrence events: %v\n", events)
-	return events, nil
+	return start, end, allDay, nil
 }
 
 // parseDate takes a date string and a timezone location,
-// and returns the parsed date as a time.Time value in the base timezone provided in env variable.
+// and returns the parsed date as a time.Time value in that location.
 // If the date string cannot be parsed, it returns an error.
 //
-// The date string should be in the format "20060102T150405" or "20060102".
-// The timezone location should be a valid IANA Time Zone database name
-// (e.g., "America/New_York"). If the location is nil, the function uses UTC.
+// The date string should be in the format "20060102T150405", "20060102T150405Z" or "20060102".
+// Times with the Z suffix are in UTC whatever the location.
 //
 // Example:
 //
@@ -291,16 +389,12 @@ func getRecurrenceEvents(event Event, from, to time.Time, rRuleVal string, exdat
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	fmt.Println(t)
 //
-// This will print the time corresponding to April 12, 2022, 12:30:00 in the New York timezone.
+// t is then April 12, 2022, 12:30:00 in the New York timezone.
 func parseDate(d string, tz *time.Location) (time.Time, error) {
 	// Try parsing with Z suffix (UTC time)
 	if len(d) > 0 && d[len(d)-1] == 'Z' {
-		parsed, err := time.Parse("20060102T150405Z", d)
-		if err == nil {
-			return parsed.In(config.baseTimezone), nil
-		}
+		return time.Parse("20060102T150405Z", d)
 	}
 
 	// Try formats without Z
@@ -308,15 +402,13 @@ func parseDate(d string, tz *time.Location) (time.Time, error) {
 	if err != nil {
 		parsed, err = time.ParseInLocation("20060102", d, tz)
 		if err != nil {
-			fmt.Printf("Error parsing date: %s\n", err)
-			return time.Time{}, err
+			return time.Time{}, fmt.Errorf("invalid date %q", d)
 		}
 	}
-	parsed = parsed.In(config.baseTimezone)
 	return parsed, nil
 }
 
-func buildQuery(from DateOffset, to DateOffset) caldav.CalendarQuery {
+func buildQuery(start, end time.Time) caldav.CalendarQuery {
 	return caldav.CalendarQuery{
 		CompRequest: caldav.CalendarCompRequest{
 			Name: "VCALENDAR",
@@ -327,18 +419,30 @@ func buildQuery(from DateOffset, to DateOffset) caldav.CalendarQuery {
 					"UID",
 					"DTSTART",
 					"DTEND",
+					"DURATION",
 					"RRULE",
+					"RDATE",
 					"EXDATE",
 					"RECURRENCE-ID",
+					"LOCATION",
+					"DESCRIPTION",
+					"CATEGORIES",
+					"STATUS",
+					"ORGANIZER",
+					"ATTENDEE",
 				},
+				Comps: []caldav.CalendarCompRequest{{
+					Name:     "VALARM",
+					AllProps: true,
+				}},
 			}},
 		},
 		CompFilter: caldav.CompFilter{
 			Name: "VCALENDAR",
 			Comps: []caldav.CompFilter{{
 				Name:  "VEVENT",
-				Start: time.Now().AddDate(from.Years, from.Months, from.Days),
-				End:   time.Now().AddDate(to.Years, to.Months, to.Days),
+				Start: start,
+				End:   end,
 			}},
 		},
 	}
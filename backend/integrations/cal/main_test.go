@@ -0,0 +1,231 @@
+package cal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The week the calendars are queried for in the tests
+var (
+	weekStart = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	weekEnd   = weekStart.AddDate(0, 0, 7)
+)
+
+func event(uid, summary string, start time.Time, d time.Duration) string {
+	const layout = "20060102T150405Z"
+	return fmt.Sprintf(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:%s
+DTSTAMP:20250101T000000Z
+DTSTART:%s
+DTEND:%s
+SUMMARY:%s
+END:VEVENT
+END:VCALENDAR
+`, uid, start.UTC().Format(layout), start.Add(d).UTC().Format(layout), summary)
+}
+
+func TestGetFamilyCalendarEventsFromSources(t *testing.T) {
+	tomorrow := weekStart.AddDate(0, 0, 1).Add(10 * time.Hour)
+
+	shared, sharedBackend := newTestServer(t, "shared")
+	family := sharedBackend.addCalendar("Family")
+	sharedBackend.addObject(t, family, "dinner.ics", event("dinner", "Family dinner", tomorrow.Add(2*time.Hour), time.Hour))
+	work := sharedBackend.addCalendar("Work")
+	sharedBackend.addObject(t, work, "standup.ics", event("standup", "Standup", tomorrow, 15*time.Minute))
+
+	personal, personalBackend := newTestServer(t, "elise")
+	soccer := personalBackend.addCalendar("Hobbies")
+	personalBackend.addObject(t, soccer, "soccer.ics", event("soccer", "Soccer", tomorrow.Add(time.Hour), time.Hour))
+	personalBackend.addObject(t, soccer, "old.ics", event("old", "Last month", tomorrow.AddDate(0, -1, 0), time.Hour))
+	personalBackend.addObject(t, soccer, "next.ics", event("next", "Next week", weekEnd, time.Hour))
+
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: shared.URL, Username: "shared", Password: "shared"}))
+	require.NoError(t, cfg.add(Source{Name: "Elise", Calendar: "Hobbies", Color: "#e15759", Owner: "Elise", URL: personal.URL, Username: "elise", Password: "elise"}))
+
+	events, err := GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, Event{Uid: "soccer", Start: tomorrow.Add(time.Hour).UTC(), End: tomorrow.Add(2 * time.Hour).UTC(), Summary: "Soccer", Calendar: "Elise", Color: "#e15759", Owner: "Elise", ETag: "soccer.ics-1"}, events[0])
+	assert.Equal(t, "dinner", events[1].Uid)
+	assert.Equal(t, "Family", events[1].Calendar)
+	assert.Equal(t, DefaultColors[0], events[1].Color)
+	assert.Empty(t, events[1].Owner)
+
+	events, err = GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false, "family")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "dinner", events[0].Uid)
+
+	_, err = GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false, "Work")
+	assert.ErrorIs(t, err, ErrUnknownCalendar)
+}
+
+func TestGetFamilyCalendarEventsSharesConnection(t *testing.T) {
+	tomorrow := weekStart.AddDate(0, 0, 1).Add(10 * time.Hour)
+	srv, backend := newTestServer(t, "shared")
+	mika := backend.addCalendar("Mika")
+	backend.addObject(t, mika, "trip.ics", event("trip", "Work trip", tomorrow, time.Hour))
+	ella := backend.addCalendar("Ella")
+	backend.addObject(t, ella, "dance.ics", event("dance", "Dance", tomorrow.Add(time.Hour), time.Hour))
+
+	cfg := &Config{BaseTimezone: time.UTC}
+	for _, name := range []string{"Mika", "Ella"} {
+		require.NoError(t, cfg.add(Source{Name: name, Calendar: name, Owner: name, URL: srv.URL, Username: "shared", Password: "shared"}))
+	}
+
+	events, err := GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Mika", events[0].Owner)
+	assert.Equal(t, DefaultColors[0], events[0].Color)
+	assert.Equal(t, "Ella", events[1].Owner)
+	assert.Equal(t, DefaultColors[1], events[1].Color)
+}
+
+func TestGetFamilyCalendarEventsCancelled(t *testing.T) {
+	tomorrow := weekStart.AddDate(0, 0, 1).Add(10 * time.Hour)
+	srv, backend := newTestServer(t, "shared")
+	family := backend.addCalendar("Family")
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Family dinner", tomorrow, time.Hour))
+	backend.addObject(t, family, "sauna.ics", strings.Replace(event("sauna", "Sauna", tomorrow.Add(2*time.Hour), time.Hour),
+		"SUMMARY:Sauna\n", "SUMMARY:Sauna\nSTATUS:CANCELLED\n", 1))
+
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "shared", Password: "shared"}))
+
+	events, err := GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "dinner", events[0].Uid)
+
+	events, err = GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, true)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "sauna", events[1].Uid)
+	assert.Equal(t, StatusCancelled, events[1].Status)
+}
+
+func TestGetFamilyCalendarEventsWrongPassword(t *testing.T) {
+	srv, _ := newTestServer(t, "shared")
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "shared", Password: "wrong"}))
+
+	_, err := GetFamilyCalendarEvents(context.Background(), cfg, weekStart, weekEnd, false)
+	assert.Error(t, err)
+}
+
+func parseICS(t *testing.T, data string) *ical.Calendar {
+	t.Helper()
+	cal, err := ical.NewDecoder(strings.NewReader(strings.ReplaceAll(data, "\n", "\r\n"))).Decode()
+	require.NoError(t, err)
+	return cal
+}
+
+func TestExpandCalendarAllDay(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, helsinki)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, helsinki)
+
+	tests := []struct {
+		name   string
+		props  string
+		start  time.Time
+		end    time.Time
+		allDay bool
+	}{
+		{
+			name:   "birthday",
+			props:  "DTSTART;VALUE=DATE:20250612\nDTEND;VALUE=DATE:20250613",
+			start:  time.Date(2025, 6, 12, 0, 0, 0, 0, helsinki),
+			end:    time.Date(2025, 6, 13, 0, 0, 0, 0, helsinki),
+			allDay: true,
+		},
+		{
+			name:   "date without end",
+			props:  "DTSTART;VALUE=DATE:20250612",
+			start:  time.Date(2025, 6, 12, 0, 0, 0, 0, helsinki),
+			end:    time.Date(2025, 6, 13, 0, 0, 0, 0, helsinki),
+			allDay: true,
+		},
+		{
+			name:   "date with a TZID",
+			props:  "DTSTART;TZID=America/New_York;VALUE=DATE:20250620\nDTEND;TZID=America/New_York;VALUE=DATE:20250623",
+			start:  time.Date(2025, 6, 20, 0, 0, 0, 0, helsinki),
+			end:    time.Date(2025, 6, 23, 0, 0, 0, 0, helsinki),
+			allDay: true,
+		},
+		{
+			name:   "days as duration",
+			props:  "DTSTART;VALUE=DATE:20250620\nDURATION:P3D",
+			start:  time.Date(2025, 6, 20, 0, 0, 0, 0, helsinki),
+			end:    time.Date(2025, 6, 23, 0, 0, 0, 0, helsinki),
+			allDay: true,
+		},
+		{
+			name:  "floating time",
+			props: "DTSTART:20250612T170000\nDTEND:20250612T183000",
+			start: time.Date(2025, 6, 12, 17, 0, 0, 0, helsinki),
+			end:   time.Date(2025, 6, 12, 18, 30, 0, 0, helsinki),
+		},
+		{
+			name:  "time with a TZID",
+			props: "DTSTART;TZID=Europe/Stockholm:20250612T170000\nDURATION:PT1H30M",
+			start: time.Date(2025, 6, 12, 18, 0, 0, 0, helsinki),
+			end:   time.Date(2025, 6, 12, 19, 30, 0, 0, helsinki),
+		},
+		{
+			name:  "UTC time without end",
+			props: "DTSTART:20250612T140000Z",
+			start: time.Date(2025, 6, 12, 17, 0, 0, 0, helsinki),
+			end:   time.Date(2025, 6, 12, 17, 0, 0, 0, helsinki),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := parseICS(t, "BEGIN:VCALENDAR\nVERSION:2.0\nPRODID:-//gohome//test//EN\nBEGIN:VEVENT\nUID:e\nDTSTAMP:20250101T000000Z\n"+
+				tt.props+"\nSUMMARY:Event\nEND:VEVENT\nEND:VCALENDAR\n")
+			events, err := expandCalendar(obj, from, to, helsinki)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.True(t, tt.start.Equal(events[0].Start), "start %s", events[0].Start)
+			assert.True(t, tt.end.Equal(events[0].End), "end %s", events[0].End)
+			assert.Equal(t, tt.allDay, events[0].AllDay)
+		})
+	}
+}
+
+func TestExpandCalendarRecurringAllDay(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	obj := parseICS(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:birthday
+DTSTAMP:20200101T000000Z
+DTSTART;VALUE=DATE:20200612
+DTEND;VALUE=DATE:20200613
+RRULE:FREQ=YEARLY
+SUMMARY:Elise's birthday
+END:VEVENT
+END:VCALENDAR
+`)
+	events, err := expandCalendar(obj, time.Date(2025, 1, 1, 0, 0, 0, 0, helsinki), time.Date(2026, 1, 1, 0, 0, 0, 0, helsinki), helsinki)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].AllDay)
+	assert.Equal(t, "2025-06-12T00:00:00+03:00", events[0].Start.Format(time.RFC3339))
+	assert.Equal(t, "2025-06-13T00:00:00+03:00", events[0].End.Format(time.RFC3339))
+}
@@ -0,0 +1,311 @@
+package cal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/teambition/rrule-go"
+)
+
+// expandCalendar returns the events of the VEVENTs in cal that overlap from
+// and to, with the times in base. VEVENTs are grouped by UID into a series:
+// the master event and the overrides of its instances, which have a
+// RECURRENCE-ID. See expandSeries for how a series is expanded.
+func expandCalendar(cal *ical.Calendar, from, to time.Time, base *time.Location) ([]Event, error) {
+	var uids []string
+	masters := make(map[string]*ical.Component)
+	overrides := make(map[string][]*ical.Component)
+	for _, child := range cal.Children {
+		if child.Name != ical.CompEvent {
+			continue
+		}
+		var uid string
+		if prop := child.Props.Get(ical.PropUID); prop != nil {
+			uid = prop.Value
+		}
+		if _, ok := masters[uid]; !ok && len(overrides[uid]) == 0 {
+			uids = append(uids, uid)
+		}
+		if child.Props.Get(ical.PropRecurrenceID) != nil {
+			overrides[uid] = append(overrides[uid], child)
+		} else {
+			masters[uid] = child
+		}
+	}
+
+	events := []Event{}
+	for _, uid := range uids {
+		found, err := expandSeries(masters[uid], overrides[uid], from, to, base)
+		if err != nil {
+			return nil, fmt.Errorf("error in event %s: %w", uid, err)
+		}
+		events = append(events, found...)
+	}
+	return events, nil
+}
+
+// expandSeries returns the instances of an event that overlap from and to.
+// The instances are DTSTART, the RRULE occurrences and the RDATEs, less the
+// EXDATEs. An override replaces the instance its RECURRENCE-ID refers to,
+// also when it moves the instance into or out of the range. A cancelled
+// instance is returned with the cancelled status. Recurrence rules are
+// expanded in the time zone of DTSTART so the instances keep their wall
+// clock time over DST changes.
+// Overrides with RANGE=THISANDFUTURE apply to their own instance only.
+func expandSeries(master *ical.Component, overrides []*ical.Component, from, to time.Time, base *time.Location) ([]Event, error) {
+	events := []Event{}
+	if master == nil {
+		// Instances shared without the rest of the series, for example an
+		// invitation to a single occurrence
+		for _, o := range overrides {
+			e, err := componentEvent(o, Event{}, base)
+			if err != nil {
+				return nil, err
+			}
+			if times, err := propTimes(o.Props.Get(ical.PropRecurrenceID), base); err == nil && len(times) == 1 {
+				original := times[0].In(base)
+				e.RecurrenceID = &original
+			}
+			if overlaps(e.Start, e.End, from, to) {
+				events = append(events, e)
+			}
+		}
+		return events, nil
+	}
+
+	e, err := componentEvent(master, Event{}, base)
+	if err != nil {
+		return nil, err
+	}
+	rules := master.Props.Values(ical.PropRecurrenceRule)
+	rdates := master.Props.Values(ical.PropRecurrenceDates)
+	if len(rules) == 0 && len(rdates) == 0 {
+		if overlaps(e.Start, e.End, from, to) {
+			events = append(events, e)
+		}
+		return events, nil
+	}
+
+	start, end, allDay, err := eventTimes(master, base)
+	if err != nil {
+		return nil, err
+	}
+	alarms, err := componentAlarms(master)
+	if err != nil {
+		return nil, err
+	}
+	days, duration := span(start, end, allDay)
+	instanceEnd := func(t time.Time) time.Time {
+		return t.AddDate(0, 0, days).Add(duration)
+	}
+
+	// Instance starts by their Unix time, with the end of RDATE periods
+	instances := map[int64]time.Time{start.Unix(): start}
+	ends := make(map[int64]time.Time)
+	// Instances starting this early may still overlap the range
+	after := from.Add(-end.Sub(start)).AddDate(0, 0, -1)
+	for _, prop := range rules {
+		option, err := rrule.StrToROptionInLocation(prop.Value, start.Location())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing RRULE %s: %w", prop.Value, err)
+		}
+		option.Dtstart = start
+		rule, err := rrule.NewRRule(*option)
+		if err != nil {
+			return nil, fmt.Errorf("error in RRULE %s: %w", prop.Value, err)
+		}
+		for _, t := range rule.Between(after, to, true) {
+			instances[t.Unix()] = t
+		}
+	}
+	for i := range rdates {
+		prop := &rdates[i]
+		if prop.Params.Get(ical.ParamValue) == string(ical.ValuePeriod) {
+			for _, period := range propValues(prop) {
+				periodStart, periodEnd, err := parsePeriod(period, prop, base)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing RDATE: %w", err)
+				}
+				instances[periodStart.Unix()] = periodStart
+				ends[periodStart.Unix()] = periodEnd
+			}
+			continue
+		}
+		times, err := propTimes(prop, base)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing RDATE: %w", err)
+		}
+		for _, t := range times {
+			instances[t.Unix()] = t
+		}
+	}
+
+	excluded, err := exclusions(master, start, allDay, base)
+	if err != nil {
+		return nil, err
+	}
+
+	overridden := make(map[int64]bool)
+	for _, o := range overrides {
+		rid := o.Props.Get(ical.PropRecurrenceID)
+		times, err := propTimes(rid, base)
+		if err != nil || len(times) != 1 {
+			return nil, fmt.Errorf("invalid RECURRENCE-ID %q", rid.Value)
+		}
+		original := times[0]
+		if excluded(original) {
+			continue
+		}
+		overridden[original.Unix()] = true
+		// An override without times keeps the ones of its instance, and
+		// without alarms the alarms of the series
+		defaults := e
+		defaults.Start = original.In(base)
+		defaults.End = instanceEnd(original).In(base)
+		instance, err := componentEvent(o, defaults, base)
+		if err != nil {
+			return nil, err
+		}
+		instance.RecurrenceID = &defaults.Start
+		if !hasAlarms(o) {
+			instance.Alarms = alarmTriggers(alarms, instance.Start, instance.End)
+		}
+		if overlaps(instance.Start, instance.End, from, to) {
+			events = append(events, instance)
+		}
+	}
+
+	for key, t := range instances {
+		if overridden[key] || excluded(t) {
+			continue
+		}
+		original := t.In(base)
+		instance := e
+		instance.Start = original
+		instance.End = instanceEnd(t).In(base)
+		instance.RecurrenceID = &original
+		if end, ok := ends[key]; ok {
+			instance.End = end.In(base)
+		}
+		instance.Alarms = alarmTriggers(alarms, instance.Start, instance.End)
+		if overlaps(instance.Start, instance.End, from, to) {
+			events = append(events, instance)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events, nil
+}
+
+// componentEvent returns the event of a VEVENT. Times and properties the
+// VEVENT does not have are taken from defaults, which an override is given
+// its instance as.
+func componentEvent(comp *ical.Component, defaults Event, base *time.Location) (Event, error) {
+	e := defaults
+	if prop := comp.Props.Get(ical.PropUID); prop != nil {
+		e.Uid = prop.Value
+	}
+	if summary, err := comp.Props.Text(ical.PropSummary); err == nil && summary != "" {
+		e.Summary = summary
+	}
+	setDetails(&e, comp)
+	if comp.Props.Get(ical.PropDateTimeStart) != nil || defaults.Start.IsZero() {
+		start, end, allDay, err := eventTimes(comp, base)
+		if err != nil {
+			return e, err
+		}
+		e.Start, e.End, e.AllDay = start.In(base), end.In(base), allDay
+	}
+	alarms, err := componentAlarms(comp)
+	if err != nil {
+		return e, err
+	}
+	if len(alarms) > 0 {
+		e.Alarms = alarmTriggers(alarms, e.Start, e.End)
+	}
+	return e, nil
+}
+
+func hasAlarms(comp *ical.Component) bool {
+	for _, child := range comp.Children {
+		if child.Name == ical.CompAlarm {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusions returns a function reporting whether an instance is excluded
+// by an EXDATE of the master event. A date excludes the instances on that
+// date even when the event has a time.
+func exclusions(master *ical.Component, start time.Time, allDay bool, base *time.Location) (func(time.Time) bool, error) {
+	times := make(map[int64]bool)
+	dates := make(map[string]bool)
+	for _, prop := range master.Props.Values(ical.PropExceptionDates) {
+		for _, value := range propValues(&prop) {
+			t, err := parseValue(&prop, value, base)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing EXDATE: %w", err)
+			}
+			if !allDay && isDateValue(&prop, value) {
+				dates[t.Format(time.DateOnly)] = true
+			} else {
+				times[t.Unix()] = true
+			}
+		}
+	}
+	return func(t time.Time) bool {
+		return times[t.Unix()] || dates[t.In(start.Location()).Format(time.DateOnly)]
+	}, nil
+}
+
+// span returns the length of an event in days and the time beyond them,
+// counting the days by the date so all-day events keep their length over DST
+// changes.
+func span(start, end time.Time, allDay bool) (int, time.Duration) {
+	if !allDay {
+		return 0, end.Sub(start)
+	}
+	days := 0
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days, end.Sub(start.AddDate(0, 0, days))
+}
+
+// overlaps reports whether an event overlaps from and to. An event without a
+// length overlaps if it takes place in the range.
+func overlaps(start, end, from, to time.Time) bool {
+	if !end.After(start) {
+		return !start.Before(from) && start.Before(to)
+	}
+	return start.Before(to) && end.After(from)
+}
+
+// parsePeriod parses an RDATE period given as start/end or start/duration.
+func parsePeriod(period string, prop *ical.Prop, base *time.Location) (time.Time, time.Time, error) {
+	startStr, endStr, found := strings.Cut(period, "/")
+	if !found {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period %q", period)
+	}
+	location := propLocation(prop, base)
+	start, err := parseDate(startStr, location)
+	if err != nil {
+		return start, start, err
+	}
+	if strings.HasPrefix(endStr, "P") || strings.HasPrefix(endStr, "+P") || strings.HasPrefix(endStr, "-P") {
+		d := ical.NewProp(ical.PropDuration)
+		d.Value = endStr
+		duration, err := d.Duration()
+		if err != nil {
+			return start, start, err
+		}
+		return start, start.Add(duration), nil
+	}
+	end, err := parseDate(endStr, location)
+	return start, end, err
+}
@@ -0,0 +1,238 @@
+package cal
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) *ical.Calendar {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+	cal, err := ical.NewDecoder(f).Decode()
+	require.NoError(t, err)
+	return cal
+}
+
+// describe formats the events for comparison, all-day events with a * and
+// cancelled ones with an x.
+func describe(events []Event) []string {
+	const layout = "2006-01-02T15:04Z07:00"
+	var lines []string
+	for _, e := range events {
+		line := e.Start.Format(layout) + " " + e.End.Format(layout) + " " + e.Summary
+		if e.AllDay {
+			line += " *"
+		}
+		if e.Cancelled() {
+			line += " x"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// sortEvents sorts the events of all series by start.
+func sortEvents(events []Event) []Event {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events
+}
+
+func TestExpandCalendarFixtures(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, helsinki)
+	}
+
+	tests := []struct {
+		name     string
+		fixture  string
+		from, to time.Time
+		want     []string
+	}{
+		{
+			// Separate EXDATE properties, an instance moved to another day and
+			// a change to summer time
+			name:    "icloud",
+			fixture: "icloud.ics",
+			from:    day(2, 10),
+			to:      day(4, 8),
+			want: []string{
+				"2025-02-11T17:30+02:00 2025-02-11T19:00+02:00 Soccer practice",
+				"2025-02-25T17:30+02:00 2025-02-25T19:00+02:00 Soccer practice",
+				"2025-03-03T00:00+02:00 2025-03-06T00:00+02:00 Ski holiday *",
+				"2025-03-05T18:00+02:00 2025-03-05T19:30+02:00 Soccer practice (moved to Wednesday)",
+				"2025-03-11T17:30+02:00 2025-03-11T19:00+02:00 Soccer practice",
+				"2025-03-18T17:30+02:00 2025-03-18T19:00+02:00 Soccer practice",
+				"2025-03-25T17:30+02:00 2025-03-25T19:00+02:00 Soccer practice",
+				"2025-04-01T17:30+03:00 2025-04-01T19:00+03:00 Soccer practice",
+			},
+		},
+		{
+			// The instance of the day is moved out of it
+			name:    "icloud moved out",
+			fixture: "icloud.ics",
+			from:    day(3, 4),
+			to:      day(3, 5),
+			want: []string{
+				"2025-03-03T00:00+02:00 2025-03-06T00:00+02:00 Ski holiday *",
+			},
+		},
+		{
+			// Comma separated EXDATE, a cancelled instance with a RECURRENCE-ID
+			// in UTC and an instance moved into the range from outside it
+			name:    "google",
+			fixture: "google.ics",
+			from:    day(2, 10),
+			to:      day(3, 31),
+			want: []string{
+				"2025-02-27T16:00+02:00 2025-02-27T16:45+02:00 Piano lesson x",
+				"2025-03-05T18:00+02:00 2025-03-05T20:00+02:00 Book club",
+				"2025-03-06T16:00+02:00 2025-03-06T16:45+02:00 Piano lesson",
+				"2025-03-10T10:00+02:00 2025-03-10T10:30+02:00 Parent-teacher call, Elias",
+				"2025-03-13T16:00+02:00 2025-03-13T16:45+02:00 Piano lesson",
+				"2025-03-20T16:00+02:00 2025-03-20T16:45+02:00 Piano lesson",
+				"2025-03-26T18:00+02:00 2025-03-26T20:00+02:00 Book club (at Anna's)",
+				"2025-03-27T16:00+02:00 2025-03-27T16:45+02:00 Piano lesson",
+			},
+		},
+		{
+			// Yearly and biweekly all-day events with a date EXDATE and UNTIL,
+			// RDATEs as a date-time and as a period, and a cancelled instance
+			name:    "nextcloud",
+			fixture: "nextcloud.ics",
+			from:    day(2, 10),
+			to:      day(4, 8),
+			want: []string{
+				"2025-02-10T00:00+02:00 2025-02-11T00:00+02:00 Recycling *",
+				"2025-03-01T10:00+02:00 2025-03-01T11:00+02:00 Swimming school",
+				"2025-03-08T10:00+02:00 2025-03-08T11:00+02:00 Swimming school x",
+				"2025-03-10T00:00+02:00 2025-03-11T00:00+02:00 Recycling *",
+				"2025-03-12T00:00+02:00 2025-03-13T00:00+02:00 Ella's birthday *",
+				"2025-03-15T10:00+02:00 2025-03-15T11:00+02:00 Swimming school",
+				"2025-03-24T00:00+02:00 2025-03-25T00:00+02:00 Recycling *",
+				"2025-03-29T10:00+02:00 2025-03-29T11:00+02:00 Swimming school",
+				"2025-04-05T10:00+03:00 2025-04-05T12:00+03:00 Swimming school",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := expandCalendar(readFixture(t, tt.fixture), tt.from, tt.to, helsinki)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, describe(sortEvents(events)))
+		})
+	}
+}
+
+func TestExpandCalendarTimeZones(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	cal := parseICS(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:call
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=/mozilla.org/20050126_1/Europe/London:20250321T090000
+DTEND;TZID=/mozilla.org/20050126_1/Europe/London:20250321T093000
+RRULE:FREQ=WEEKLY;COUNT=3
+EXDATE;TZID=Europe/Helsinki:20250328T110000
+SUMMARY:Call with grandma
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=W. Europe Standard Time:20250324T090000
+DTEND;TZID=W. Europe Standard Time:20250324T091500
+RRULE:FREQ=DAILY;COUNT=3
+EXDATE;VALUE=DATE:20250325
+SUMMARY:Standup
+END:VEVENT
+END:VCALENDAR
+`)
+	events, err := expandCalendar(cal, time.Date(2025, 3, 1, 0, 0, 0, 0, helsinki), time.Date(2025, 5, 1, 0, 0, 0, 0, helsinki), helsinki)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		// London and Helsinki change to summer time on the same day
+		"2025-03-21T11:00+02:00 2025-03-21T11:30+02:00 Call with grandma",
+		// An unknown time zone is taken as the base one
+		"2025-03-24T09:00+02:00 2025-03-24T09:15+02:00 Standup",
+		"2025-03-26T09:00+02:00 2025-03-26T09:15+02:00 Standup",
+		"2025-04-04T11:00+03:00 2025-04-04T11:30+03:00 Call with grandma",
+	}, describe(sortEvents(events)))
+}
+
+func TestExpandCalendarOverrides(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	cal := parseICS(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:hockey
+DTSTAMP:20250101T000000Z
+DTSTART;TZID=Europe/Helsinki:20250901T180000
+DTEND;TZID=Europe/Helsinki:20250901T193000
+RRULE:FREQ=DAILY;COUNT=4
+SUMMARY:Hockey
+END:VEVENT
+BEGIN:VEVENT
+UID:hockey
+DTSTAMP:20250101T000000Z
+RECURRENCE-ID;TZID=Europe/Helsinki:20250902T180000
+SUMMARY:Hockey game
+END:VEVENT
+BEGIN:VEVENT
+UID:invited
+DTSTAMP:20250101T000000Z
+RECURRENCE-ID;TZID=Europe/Helsinki:20250903T120000
+DTSTART;TZID=Europe/Helsinki:20250903T120000
+DTEND;TZID=Europe/Helsinki:20250903T130000
+SUMMARY:Lunch with Mika's team
+END:VEVENT
+END:VCALENDAR
+`)
+	events, err := expandCalendar(cal, time.Date(2025, 9, 1, 0, 0, 0, 0, helsinki), time.Date(2025, 9, 30, 0, 0, 0, 0, helsinki), helsinki)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2025-09-01T18:00+03:00 2025-09-01T19:30+03:00 Hockey",
+		// An override without times keeps the ones of its instance, and its
+		// summary is not carried over to the next instances
+		"2025-09-02T18:00+03:00 2025-09-02T19:30+03:00 Hockey game",
+		"2025-09-03T12:00+03:00 2025-09-03T13:00+03:00 Lunch with Mika's team",
+		"2025-09-03T18:00+03:00 2025-09-03T19:30+03:00 Hockey",
+		"2025-09-04T18:00+03:00 2025-09-04T19:30+03:00 Hockey",
+	}, describe(sortEvents(events)))
+	for _, e := range events {
+		assert.NotEmpty(t, e.Uid)
+	}
+}
+
+func TestExpandCalendarInvalid(t *testing.T) {
+	cal := parseICS(t, `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//gohome//test//EN
+BEGIN:VEVENT
+UID:broken
+DTSTAMP:20250101T000000Z
+DTSTART:20250901T180000Z
+RRULE:FREQ=SOMETIMES
+SUMMARY:Broken
+END:VEVENT
+END:VCALENDAR
+`)
+	_, err := expandCalendar(cal, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC), time.UTC)
+	assert.ErrorContains(t, err, "broken")
+}
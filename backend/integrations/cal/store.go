@@ -0,0 +1,200 @@
+package cal
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotSynced is returned for calendars that have not been synced yet.
+var ErrNotSynced = errors.New("calendar not synced yet")
+
+// Store keeps the calendar objects of the configured sources in memory, as
+// synced by a Syncer, and serves their events.
+type Store struct {
+	cfg       *Config
+	mu        sync.RWMutex
+	calendars map[string]*storedCalendar // by source name in lower case
+}
+
+// storedCalendar is the synced state of a calendar: the ctag and sync token
+// it was synced at and its objects by path.
+type storedCalendar struct {
+	ctag      string
+	syncToken string
+	synced    time.Time
+	objects   map[string]storedObject
+}
+
+type storedObject struct {
+	etag string
+	data *ical.Calendar
+}
+
+func NewStore(cfg *Config) *Store {
+	return &Store{cfg: cfg, calendars: make(map[string]*storedCalendar)}
+}
+
+// Events returns the events between start and end of the named calendars,
+// or of all calendars, like GetFamilyCalendarEvents but from the synced
+// objects. Calendars not synced yet are left out, ErrNotSynced is returned
+// only if none of them has been synced.
+func (s *Store) Events(start, end time.Time, includeCancelled bool, calendars ...string) ([]Event, error) {
+	sources, err := s.cfg.Select(calendars...)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events := []Event{}
+	var unsynced []string
+	for _, source := range sources {
+		stored, ok := s.calendars[strings.ToLower(source.Name)]
+		if !ok {
+			unsynced = append(unsynced, source.Name)
+			continue
+		}
+		paths := make([]string, 0, len(stored.objects))
+		for p := range stored.objects {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			found, err := expandCalendar(stored.objects[p].data, start, end, s.cfg.BaseTimezone)
+			if err != nil {
+				return nil, fmt.Errorf("error in %s: %w", p, err)
+			}
+			for _, event := range found {
+				if event.Cancelled() && !includeCancelled {
+					continue
+				}
+				event.Calendar = source.Name
+				event.Color = source.Color
+				event.Owner = source.Owner
+				event.ETag = stored.objects[p].etag
+				event.ReadOnly = source.Subscription()
+				events = append(events, event)
+			}
+		}
+	}
+	if len(unsynced) == len(sources) {
+		return nil, fmt.Errorf("%w: %q", ErrNotSynced, unsynced)
+	}
+	if len(unsynced) > 0 {
+		log.Debug().Str("event", "cal_events_unsynced").Strs("calendars", unsynced).Msg("serving the synced calendars")
+	}
+	sortByTime(events)
+	return events, nil
+}
+
+// Synced returns when the named calendar was last synced, or the zero time.
+func (s *Store) Synced(name string) time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if stored, ok := s.calendars[strings.ToLower(name)]; ok {
+		return stored.synced
+	}
+	return time.Time{}
+}
+
+// state returns the ctag, sync token and object etags a calendar was synced
+// at, and whether it has been synced.
+func (s *Store) state(name string) (ctag, syncToken string, etags map[string]string, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored, ok := s.calendars[strings.ToLower(name)]
+	if !ok {
+		return "", "", nil, false
+	}
+	etags = make(map[string]string, len(stored.objects))
+	for p, obj := range stored.objects {
+		etags[p] = obj.etag
+	}
+	return stored.ctag, stored.syncToken, etags, true
+}
+
+// update records a sync of a calendar: the changed objects are stored, the
+// removed ones dropped and the ctag and sync token saved.
+func (s *Store) update(name, ctag, syncToken string, changed []caldav.CalendarObject, removed []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(name)
+	stored, ok := s.calendars[key]
+	if !ok {
+		stored = &storedCalendar{objects: make(map[string]storedObject)}
+		s.calendars[key] = stored
+	}
+	for _, obj := range changed {
+		stored.objects[obj.Path] = storedObject{etag: obj.ETag, data: obj.Data}
+	}
+	for _, p := range removed {
+		delete(stored.objects, p)
+	}
+	stored.ctag, stored.syncToken, stored.synced = ctag, syncToken, time.Now()
+}
+
+// find returns the source, path and object of the event with the UID in the
+// given sources. If it is in none of the synced ones while some are not
+// synced yet, ErrNotSynced is returned.
+func (s *Store) find(uid string, sources []Source) (Source, string, storedObject, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var unsynced []string
+	for _, source := range sources {
+		stored, ok := s.calendars[strings.ToLower(source.Name)]
+		if !ok {
+			unsynced = append(unsynced, source.Name)
+			continue
+		}
+		for p, obj := range stored.objects {
+			for _, child := range obj.data.Children {
+				if child.Name != ical.CompEvent {
+					continue
+				}
+				if prop := child.Props.Get(ical.PropUID); prop != nil && prop.Value == uid {
+					return source, p, obj, nil
+				}
+			}
+		}
+	}
+	if len(unsynced) > 0 {
+		return Source{}, "", storedObject{}, fmt.Errorf("%w: event %q may be in %q", ErrNotSynced, uid, unsynced)
+	}
+	return Source{}, "", storedObject{}, fmt.Errorf("%w: %q", ErrEventNotFound, uid)
+}
+
+// put stores an object written to a synced calendar, so it is served before
+// the next sync downloads it.
+func (s *Store) put(name, path, etag string, data *ical.Calendar) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.calendars[strings.ToLower(name)]; ok {
+		stored.objects[path] = storedObject{etag: etag, data: data}
+	}
+}
+
+// remove drops an object removed from a synced calendar.
+func (s *Store) remove(name, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.calendars[strings.ToLower(name)]; ok {
+		delete(stored.objects, path)
+	}
+}
+
+// sortByTime sorts events by start, and by end for events starting together.
+func sortByTime(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Start.Equal(events[j].Start) {
+			return events[i].End.Before(events[j].End)
+		}
+		return events[i].Start.Before(events[j].Start)
+	})
+}
@@ -0,0 +1,188 @@
+package cal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultSyncInterval is how often the calendars are synced by default.
+const DefaultSyncInterval = 5 * time.Minute
+
+// Syncer keeps a Store in sync with the CalDAV calendars of the configured
+// sources. Each account is discovered once and its connection reused, and
+// only the objects changed since the last sync are downloaded: a calendar
+// whose getctag has not changed is skipped, the changes of one are listed
+// with a sync-collection REPORT when the server supports sync tokens, and
+// otherwise by comparing the etags of its objects. Feeds are downloaded
+// again only when their ETag or Last-Modified has changed.
+type Syncer struct {
+	cfg      *Config
+	store    *Store
+	interval time.Duration
+	http     *http.Client
+	accounts connections
+	feeds    map[string]feed // validators of the feeds by source name in lower case
+	failing  bool
+}
+
+func NewSyncer(cfg *Config, store *Store, interval time.Duration) *Syncer {
+	if interval == 0 {
+		interval = DefaultSyncInterval
+	}
+	return &Syncer{cfg: cfg, store: store, interval: interval, http: &http.Client{}, feeds: make(map[string]feed)}
+}
+
+// Run syncs until the context is done. Failures are logged as warnings only
+// when they start and end.
+func (s *Syncer) Run(ctx context.Context) {
+	log.Info().Str("event", "cal_sync_start").Dur("interval", s.interval).Int("calendars", len(s.cfg.Sources)).Msg("calendar sync started")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		err := s.Sync(ctx)
+		switch {
+		case err != nil && !s.failing:
+			s.failing = true
+			log.Warn().Err(err).Str("event", "cal_sync_failed").Msg("retrying every interval")
+		case err != nil:
+			log.Debug().Err(err).Str("event", "cal_sync_failed").Msg("")
+		case s.failing:
+			s.failing = false
+			log.Info().Str("event", "cal_sync_recovered").Msg("")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync syncs each configured calendar once. A calendar failing to sync does
+// not stop the others from syncing, the errors are returned together.
+func (s *Syncer) Sync(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+	var errs []error
+	for _, source := range s.cfg.Sources {
+		if source.Subscription() {
+			if err := s.syncFeed(ctx, source); err != nil {
+				errs = append(errs, fmt.Errorf("calendar %s: %w", source.Name, err))
+			}
+			continue
+		}
+		if err := s.syncSource(ctx, source); err != nil {
+			// The account is discovered again on the next sync
+			s.accounts.drop(source)
+			errs = append(errs, fmt.Errorf("calendar %s: %w", source.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Syncer) syncSource(ctx context.Context, source Source) error {
+	acc, err := s.accounts.get(ctx, source)
+	if err != nil {
+		return err
+	}
+	calPath, err := acc.calendarPath(source.Calendar)
+	if err != nil {
+		return err
+	}
+
+	ctag, syncToken, err := acc.dav.collectionState(ctx, calPath)
+	if err != nil {
+		return fmt.Errorf("error reading calendar state: %w", err)
+	}
+	oldCTag, oldSyncToken, etags, synced := s.store.state(source.Name)
+	if synced && ctag != "" && ctag == oldCTag {
+		s.store.update(source.Name, ctag, oldSyncToken, nil, nil)
+		log.Debug().Str("event", "cal_synced").Str("calendar", source.Name).Str("mode", "ctag").Msg("unchanged")
+		return nil
+	}
+
+	if synced && oldSyncToken != "" && syncToken != "" {
+		changed, removed, newSyncToken, err := acc.dav.syncCollection(ctx, calPath, oldSyncToken)
+		if err == nil {
+			if newSyncToken == "" {
+				newSyncToken = syncToken
+			}
+			return s.apply(ctx, acc, source, calPath, "sync-token", ctag, newSyncToken, changed, etags, removed)
+		}
+		// Servers expire old sync tokens, the etags tell what changed then
+		log.Debug().Err(err).Str("event", "cal_sync_token_failed").Str("calendar", source.Name).Msg("listing etags")
+	}
+
+	listed, err := acc.dav.listETags(ctx, calPath)
+	if err != nil {
+		return fmt.Errorf("error listing calendar objects: %w", err)
+	}
+	var removed []string
+	for p := range etags {
+		if _, ok := listed[p]; !ok {
+			removed = append(removed, p)
+		}
+	}
+	return s.apply(ctx, acc, source, calPath, "etag", ctag, syncToken, listed, etags, removed)
+}
+
+// syncFeed downloads a feed if it has changed since the last sync, and
+// stores it as the only object of its calendar.
+func (s *Syncer) syncFeed(ctx context.Context, source Source) error {
+	var previous feed
+	if _, _, _, synced := s.store.state(source.Name); synced {
+		previous = s.feeds[strings.ToLower(source.Name)]
+	}
+	f, err := fetchFeed(ctx, s.http, source.ICS, previous.etag, previous.lastModified)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		s.store.update(source.Name, "", "", nil, nil)
+		log.Debug().Str("event", "cal_synced").Str("calendar", source.Name).Str("mode", "feed").Msg("unchanged")
+		return nil
+	}
+	// Only the validators are kept, the feed is in the store
+	s.feeds[strings.ToLower(source.Name)] = feed{etag: f.etag, lastModified: f.lastModified}
+	s.store.update(source.Name, "", "", []caldav.CalendarObject{{Path: source.ICS, ETag: f.etag, Data: f.cal}}, nil)
+	log.Debug().Str("event", "cal_synced").Str("calendar", source.Name).Str("mode", "feed").Int("changed", 1).Msg("")
+	return nil
+}
+
+// apply downloads the objects whose etag differs from the stored one and
+// records the sync in the store.
+func (s *Syncer) apply(ctx context.Context, acc *account, source Source, calPath, mode, ctag, syncToken string, changed, etags map[string]string, removed []string) error {
+	var paths []string
+	for p, etag := range changed {
+		if stored, ok := etags[p]; !ok || etag == "" || stored != etag {
+			paths = append(paths, p)
+		}
+	}
+	var objects []caldav.CalendarObject
+	if len(paths) > 0 {
+		var err error
+		objects, err = acc.client.MultiGetCalendar(ctx, calPath, &caldav.CalendarMultiGet{
+			Paths:       paths,
+			CompRequest: caldav.CalendarCompRequest{Name: "VCALENDAR", AllProps: true, AllComps: true},
+		})
+		if err != nil {
+			return fmt.Errorf("error downloading calendar objects: %w", err)
+		}
+	}
+	for i := range objects {
+		if objects[i].ETag == "" {
+			objects[i].ETag = changed[objects[i].Path]
+		}
+	}
+	s.store.update(source.Name, ctag, syncToken, objects, removed)
+	log.Debug().Str("event", "cal_synced").Str("calendar", source.Name).Str("mode", mode).
+		Int("changed", len(objects)).Int("removed", len(removed)).Msg("")
+	return nil
+}
@@ -0,0 +1,147 @@
+package cal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// summaries returns the summaries of the store's events in the test week.
+func summaries(t *testing.T, store *Store, calendars ...string) []string {
+	t.Helper()
+	events, err := store.Events(weekStart, weekEnd, false, calendars...)
+	require.NoError(t, err)
+	var found []string
+	for _, e := range events {
+		found = append(found, e.Summary)
+	}
+	return found
+}
+
+func newSyncTest(t *testing.T, syncTokens bool) (*testBackend, string, *Store, *Syncer) {
+	t.Helper()
+	srv, backend := newTestServer(t, "shared")
+	backend.syncTokens = syncTokens
+	family := backend.addCalendar("Family")
+	tomorrow := weekStart.AddDate(0, 0, 1).Add(10 * time.Hour)
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Family dinner", tomorrow, time.Hour))
+	backend.addObject(t, family, "sauna.ics", event("sauna", "Sauna", tomorrow.Add(2*time.Hour), time.Hour))
+
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "shared", Password: "shared"}))
+	store := NewStore(cfg)
+	return backend, family, store, NewSyncer(cfg, store, time.Minute)
+}
+
+func TestSyncerSyncTokens(t *testing.T) {
+	backend, family, store, syncer := newSyncTest(t, true)
+	_, err := store.Events(weekStart, weekEnd, false)
+	require.ErrorIs(t, err, ErrNotSynced)
+
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, []string{"Family dinner", "Sauna"}, summaries(t, store))
+	assert.Equal(t, 2, backend.gets)
+	assert.False(t, store.Synced("family").IsZero())
+
+	// Nothing is downloaded while the ctag stays the same
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 2, backend.gets)
+	assert.Equal(t, 0, backend.reports)
+
+	// Only the changed object is downloaded
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Pizza night", weekStart.AddDate(0, 0, 2).Add(17*time.Hour), time.Hour))
+	backend.removeObject(family, "sauna.ics")
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, backend.reports)
+	assert.Equal(t, 3, backend.gets)
+	assert.Equal(t, []string{"Pizza night"}, summaries(t, store))
+}
+
+func TestSyncerExpiredSyncToken(t *testing.T) {
+	backend, family, store, syncer := newSyncTest(t, true)
+	require.NoError(t, syncer.Sync(context.Background()))
+
+	backend.minToken = backend.version + 1
+	backend.removeObject(family, "dinner.ics")
+	backend.addObject(t, family, "birthday.ics", event("birthday", "Ella's birthday", weekStart.AddDate(0, 0, 3), time.Hour))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 1, backend.reports)
+	assert.Equal(t, 3, backend.gets)
+	assert.Equal(t, []string{"Sauna", "Ella's birthday"}, summaries(t, store))
+}
+
+func TestSyncerEtags(t *testing.T) {
+	backend, family, store, syncer := newSyncTest(t, false)
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 2, backend.gets)
+
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 2, backend.gets)
+
+	backend.addObject(t, family, "sauna.ics", event("sauna", "Sauna with grandpa", weekStart.AddDate(0, 0, 1).Add(20*time.Hour), time.Hour))
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 3, backend.gets)
+	assert.Equal(t, []string{"Family dinner", "Sauna with grandpa"}, summaries(t, store))
+
+	backend.removeObject(family, "dinner.ics")
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Equal(t, 3, backend.gets)
+	assert.Equal(t, []string{"Sauna with grandpa"}, summaries(t, store))
+}
+
+func TestSyncerFailure(t *testing.T) {
+	srv, backend := newTestServer(t, "shared")
+	backend.addCalendar("Family")
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "shared", Password: "shared"}))
+	require.NoError(t, cfg.add(Source{Name: "Work", Calendar: "Work", URL: srv.URL, Username: "shared", Password: "shared"}))
+	store := NewStore(cfg)
+	syncer := NewSyncer(cfg, store, time.Minute)
+
+	// The missing calendar does not keep the other one from syncing
+	err := syncer.Sync(context.Background())
+	assert.ErrorContains(t, err, "Work")
+	assert.Empty(t, summaries(t, store, "Family"))
+	_, err = store.Events(weekStart, weekEnd, false, "Work")
+	assert.ErrorIs(t, err, ErrNotSynced)
+	_, err = store.Events(weekStart, weekEnd, false, "Hobbies")
+	assert.ErrorIs(t, err, ErrUnknownCalendar)
+
+	// The calendars are discovered again
+	backend.addCalendar("Work")
+	require.NoError(t, syncer.Sync(context.Background()))
+	assert.Empty(t, summaries(t, store))
+}
+
+func TestStoreServesSyncedCalendars(t *testing.T) {
+	srv, backend := newTestServer(t, "shared")
+	family := backend.addCalendar("Family")
+	backend.addObject(t, family, "dinner.ics", event("dinner", "Family dinner", weekStart.AddDate(0, 0, 1).Add(17*time.Hour), time.Hour))
+	cfg := &Config{BaseTimezone: time.UTC}
+	require.NoError(t, cfg.add(Source{Name: "Work", Calendar: "Work", URL: srv.URL, Username: "shared", Password: "shared"}))
+	require.NoError(t, cfg.add(Source{Name: "Family", Calendar: "Family", URL: srv.URL, Username: "wrong", Password: "wrong"}))
+	store := NewStore(cfg)
+	syncer := NewSyncer(cfg, store, time.Minute)
+
+	// Neither calendar synced
+	assert.Error(t, syncer.Sync(context.Background()))
+	_, err := store.Events(weekStart, weekEnd, false)
+	assert.ErrorIs(t, err, ErrNotSynced)
+
+	// Work still fails but the synced Family calendar is served
+	cfg.Sources[1].Username, cfg.Sources[1].Password = "shared", "shared"
+	assert.ErrorContains(t, syncer.Sync(context.Background()), "Work")
+	assert.Equal(t, []string{"Family dinner"}, summaries(t, store))
+	_, err = store.Events(weekStart, weekEnd, false, "Work")
+	assert.ErrorIs(t, err, ErrNotSynced)
+
+	// An event is found past the calendar not synced
+	source, _, _, err := store.find("dinner", cfg.Sources)
+	require.NoError(t, err)
+	assert.Equal(t, "Family", source.Name)
+	_, _, _, err = store.find("missing", cfg.Sources)
+	assert.ErrorIs(t, err, ErrNotSynced)
+}
@@ -0,0 +1,248 @@
+package fmi
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/sun"
+	"github.com/rs/zerolog/log"
+)
+
+// Condition is a weather condition shared by the forecast SmartSymbol and the
+// observation WaWa code systems.
+type Condition string
+
+const (
+	ConditionClear        Condition = "clear"
+	ConditionMostlyClear  Condition = "mostly_clear"
+	ConditionPartlyCloudy Condition = "partly_cloudy"
+	ConditionCloudy       Condition = "cloudy"
+	ConditionOvercast     Condition = "overcast"
+	ConditionFog          Condition = "fog"
+	ConditionDrizzle      Condition = "drizzle"
+	ConditionFreezingRain Condition = "freezing_rain"
+	ConditionShowers      Condition = "showers"
+	ConditionLightRain    Condition = "light_rain"
+	ConditionRain         Condition = "rain"
+	ConditionHeavyRain    Condition = "heavy_rain"
+	ConditionSleet        Condition = "sleet"
+	ConditionLightSnow    Condition = "light_snow"
+	ConditionSnow         Condition = "snow"
+	ConditionHeavySnow    Condition = "heavy_snow"
+	ConditionHail         Condition = "hail"
+	ConditionThunder      Condition = "thunder"
+)
+
+// Description is a condition described in Finnish and English.
+type Description struct {
+	Fi string `json:"fi"`
+	En string `json:"en"`
+}
+
+// Text returns the description in the language, English if not supported.
+func (d Description) Text(lang string) string {
+	if lang == "fi" {
+		return d.Fi
+	}
+	return d.En
+}
+
+type conditionInfo struct {
+	symbol      int // Day SmartSymbol used as the icon of the condition
+	description Description
+}
+
+var conditions = map[Condition]conditionInfo{
+	ConditionClear:        {1, Description{"Selkeää", "Clear"}},
+	ConditionMostlyClear:  {2, Description{"Melko selkeää", "Mostly clear"}},
+	ConditionPartlyCloudy: {4, Description{"Puolipilvistä", "Partly cloudy"}},
+	ConditionCloudy:       {6, Description{"Melko pilvistä", "Mostly cloudy"}},
+	ConditionOvercast:     {7, Description{"Pilvistä", "Overcast"}},
+	ConditionFog:          {9, Description{"Sumua", "Fog"}},
+	ConditionDrizzle:      {11, Description{"Tihkusadetta", "Drizzle"}},
+	ConditionFreezingRain: {17, Description{"Jäätävää sadetta", "Freezing rain"}},
+	ConditionShowers:      {24, Description{"Sadekuuroja", "Showers"}},
+	ConditionLightRain:    {33, Description{"Heikkoa vesisadetta", "Light rain"}},
+	ConditionRain:         {36, Description{"Vesisadetta", "Rain"}},
+	ConditionHeavyRain:    {39, Description{"Voimakasta vesisadetta", "Heavy rain"}},
+	ConditionSleet:        {46, Description{"Räntäsadetta", "Sleet"}},
+	ConditionLightSnow:    {53, Description{"Heikkoa lumisadetta", "Light snow"}},
+	ConditionSnow:         {56, Description{"Lumisadetta", "Snow"}},
+	ConditionHeavySnow:    {59, Description{"Voimakasta lumisadetta", "Heavy snow"}},
+	ConditionHail:         {64, Description{"Raekuuroja", "Hail"}},
+	ConditionThunder:      {74, Description{"Ukkoskuuroja", "Thunderstorms"}},
+}
+
+// WeatherCondition is a normalized weather code. Symbol is the SmartSymbol
+// to show as the icon, night symbols offset by 100.
+type WeatherCondition struct {
+	Condition   Condition   `json:"code"`
+	Night       bool        `json:"night"`
+	Symbol      int         `json:"symbol"`
+	Description Description `json:"description"`
+}
+
+func newCondition(c Condition, symbol int, night bool) *WeatherCondition {
+	if night {
+		symbol += 100
+	}
+	return &WeatherCondition{
+		Condition:   c,
+		Night:       night,
+		Symbol:      symbol,
+		Description: conditions[c].description,
+	}
+}
+
+// FromSmartSymbol normalizes a forecast SmartSymbol. FMI marks night with an
+// offset of 100. Returns nil for unknown symbols.
+func FromSmartSymbol(code int) *WeatherCondition {
+	night := code > 100
+	base := code
+	if night {
+		base -= 100
+	}
+	c, ok := smartSymbolCondition(base)
+	if !ok {
+		return nil
+	}
+	return newCondition(c, base, night)
+}
+
+func smartSymbolCondition(code int) (Condition, bool) {
+	switch code {
+	case 1:
+		return ConditionClear, true
+	case 2:
+		return ConditionMostlyClear, true
+	case 4:
+		return ConditionPartlyCloudy, true
+	case 6:
+		return ConditionCloudy, true
+	case 7:
+		return ConditionOvercast, true
+	case 9:
+		return ConditionFog, true
+	case 11:
+		return ConditionDrizzle, true
+	case 14, 17:
+		return ConditionFreezingRain, true
+	case 21, 24, 27:
+		return ConditionShowers, true
+	case 61, 64, 67:
+		return ConditionHail, true
+	case 71, 74, 77:
+		return ConditionThunder, true
+	}
+	// 31-59 are rain, sleet and snow in threes of increasing intensity, each
+	// under increasing cloudiness
+	if code < 31 || code > 59 || code%10 == 0 {
+		return "", false
+	}
+	intensity := (code%10 - 1) / 3
+	switch code / 10 {
+	case 3:
+		return []Condition{ConditionLightRain, ConditionRain, ConditionHeavyRain}[intensity], true
+	case 4:
+		return ConditionSleet, true
+	default:
+		return []Condition{ConditionLightSnow, ConditionSnow, ConditionHeavySnow}[intensity], true
+	}
+}
+
+// FromWaWa normalizes an observation WaWa code (WMO code table 4680). The
+// code tells nothing of clouds when there is no weather to report, then the
+// cloud cover in oktas decides. Day and night follow the sun at t. Returns nil
+// for unknown codes and, without cloud cover, for the no-weather codes.
+func FromWaWa(code int, cloudOktas *float64, t time.Time) *WeatherCondition {
+	c, ok := wawaCondition(code)
+	if !ok {
+		return nil
+	}
+	if c == "" {
+		if c, ok = cloudCondition(cloudOktas); !ok {
+			return nil
+		}
+	}
+	return newCondition(c, conditions[c].symbol, IsNight(t))
+}
+
+// wawaCondition returns an empty condition for codes without present weather.
+func wawaCondition(code int) (Condition, bool) {
+	switch {
+	case code >= 0 && code <= 3, code >= 20 && code <= 25, code == 27:
+		// No weather now, or only during the past hour
+		return "", true
+	case code == 4, code == 5, code == 10, code >= 30 && code <= 35:
+		return ConditionFog, true
+	case code == 12, code == 26, code >= 90 && code <= 96:
+		return ConditionThunder, true
+	case code == 40, code == 41, code == 60, code == 62:
+		return ConditionRain, true
+	case code == 42, code == 63, code == 84:
+		return ConditionHeavyRain, true
+	case code >= 50 && code <= 53:
+		return ConditionDrizzle, true
+	case code >= 54 && code <= 56, code >= 64 && code <= 66:
+		return ConditionFreezingRain, true
+	case code == 57, code == 58, code == 61:
+		return ConditionLightRain, true
+	case code == 67, code == 68, code >= 74 && code <= 76:
+		return ConditionSleet, true
+	case code == 71, code == 77, code == 78:
+		return ConditionLightSnow, true
+	case code == 70, code == 72, code >= 85 && code <= 87:
+		return ConditionSnow, true
+	case code == 73:
+		return ConditionHeavySnow, true
+	case code >= 80 && code <= 83:
+		return ConditionShowers, true
+	case code == 89:
+		return ConditionHail, true
+	}
+	return "", false
+}
+
+func cloudCondition(oktas *float64) (Condition, bool) {
+	if oktas == nil || math.IsNaN(*oktas) {
+		return "", false
+	}
+	switch o := math.Round(*oktas); {
+	case o < 0 || o > 8:
+		// 9 is sky obscured, e.g. by fog
+		return "", false
+	case o <= 0:
+		return ConditionClear, true
+	case o <= 2:
+		return ConditionMostlyClear, true
+	case o <= 5:
+		return ConditionPartlyCloudy, true
+	case o <= 7:
+		return ConditionCloudy, true
+	default:
+		return ConditionOvercast, true
+	}
+}
+
+var (
+	sunOnce     sync.Once
+	sunData     *sun.SunData
+	helsinki, _ = time.LoadLocation("Europe/Helsinki")
+)
+
+// IsNight reports whether the sun is down at t in Helsinki.
+func IsNight(t time.Time) bool {
+	sunOnce.Do(func() {
+		var err error
+		if sunData, err = sun.NewSunData(); err != nil {
+			log.Err(err).Msg("Sun data not available, conditions are all day")
+		}
+	})
+	if sunData == nil {
+		return false
+	}
+	local := t.In(helsinki)
+	day := sunData.GetSunDataForSingleDate(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, helsinki))
+	return t.Before(day.Sunrise) || !t.Before(day.Sunset)
+}
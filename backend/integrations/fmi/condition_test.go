@@ -0,0 +1,110 @@
+package fmi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromSmartSymbol(t *testing.T) {
+	tests := []struct {
+		code      int
+		condition Condition
+		night     bool
+	}{
+		{1, ConditionClear, false},
+		{106, ConditionCloudy, true},
+		{9, ConditionFog, false},
+		{14, ConditionFreezingRain, false},
+		{124, ConditionShowers, true},
+		{31, ConditionLightRain, false},
+		{35, ConditionRain, false},
+		{139, ConditionHeavyRain, true},
+		{44, ConditionSleet, false},
+		{53, ConditionLightSnow, false},
+		{157, ConditionHeavySnow, true},
+		{64, ConditionHail, false},
+		{77, ConditionThunder, false},
+	}
+	for _, tt := range tests {
+		c := FromSmartSymbol(tt.code)
+		if c == nil {
+			t.Errorf("SmartSymbol %d, got nil, want %s", tt.code, tt.condition)
+			continue
+		}
+		if c.Condition != tt.condition || c.Night != tt.night || c.Symbol != tt.code {
+			t.Errorf("SmartSymbol %d, got %+v, want %s (night %v)", tt.code, c, tt.condition, tt.night)
+		}
+	}
+	for _, code := range []int{0, 3, 30, 40, 99, 200} {
+		if c := FromSmartSymbol(code); c != nil {
+			t.Errorf("Unknown SmartSymbol %d, got %+v", code, c)
+		}
+	}
+}
+
+func TestFromWaWa(t *testing.T) {
+	noon := time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 12, 15, 22, 0, 0, 0, time.UTC)
+	oktas := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name      string
+		code      int
+		clouds    *float64
+		at        time.Time
+		condition Condition
+		symbol    int
+	}{
+		{"clear by clouds", 0, oktas(0), noon, ConditionClear, 1},
+		{"overcast by clouds", 2, oktas(8), noon, ConditionOvercast, 7},
+		{"past hour rain", 23, oktas(4), midnight, ConditionPartlyCloudy, 104},
+		{"mist", 10, nil, noon, ConditionFog, 9},
+		{"drizzle", 51, nil, noon, ConditionDrizzle, 11},
+		{"moderate rain", 62, nil, noon, ConditionRain, 36},
+		{"freezing rain", 65, nil, midnight, ConditionFreezingRain, 117},
+		{"rain and snow", 67, nil, noon, ConditionSleet, 46},
+		{"heavy snow", 73, nil, midnight, ConditionHeavySnow, 159},
+		{"snow showers", 86, nil, noon, ConditionSnow, 56},
+		{"thunderstorm", 92, nil, noon, ConditionThunder, 74},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := FromWaWa(tt.code, tt.clouds, tt.at)
+			if c == nil {
+				t.Fatalf("WaWa %d, got nil, want %s", tt.code, tt.condition)
+			}
+			if c.Condition != tt.condition || c.Symbol != tt.symbol {
+				t.Errorf("WaWa %d, got %+v, want %s (%d)", tt.code, c, tt.condition, tt.symbol)
+			}
+		})
+	}
+	if c := FromWaWa(0, nil, noon); c != nil {
+		t.Errorf("No weather without clouds, got %+v", c)
+	}
+	if c := FromWaWa(99, oktas(0), noon); c != nil {
+		t.Errorf("Unknown WaWa, got %+v", c)
+	}
+}
+
+func TestIsNight(t *testing.T) {
+	tests := []struct {
+		at    time.Time
+		night bool
+	}{
+		{time.Date(2024, 6, 21, 21, 30, 0, 0, time.UTC), true},  // 00:30 in Helsinki
+		{time.Date(2024, 6, 21, 2, 30, 0, 0, time.UTC), false},  // 05:30, after sunrise
+		{time.Date(2024, 12, 21, 6, 0, 0, 0, time.UTC), true},   // 08:00, before sunrise
+		{time.Date(2024, 12, 21, 10, 0, 0, 0, time.UTC), false}, // 12:00
+	}
+	for _, tt := range tests {
+		if got := IsNight(tt.at); got != tt.night {
+			t.Errorf("IsNight(%s), got %v, want %v", tt.at, got, tt.night)
+		}
+	}
+}
+
+func TestDescriptionText(t *testing.T) {
+	d := FromSmartSymbol(36).Description
+	if d.Text("fi") != "Vesisadetta" || d.Text("en") != "Rain" || d.Text("sv") != "Rain" {
+		t.Errorf("Description, got %+v", d)
+	}
+}
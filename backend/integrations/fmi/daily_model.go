@@ -0,0 +1,196 @@
+package fmi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultHeatingBase is the indoor base temperature FMI uses for heating
+// degree days (lämmitystarveluku).
+const DefaultHeatingBase = 17.0
+
+// MaxDailyObservationRange is the longest time range FMI serves in a single
+// daily observation request.
+const MaxDailyObservationRange = 366 * 24 * time.Hour
+
+// DailyWeather holds the values of a day. Like in WeatherData, missing values
+// are nil and serialize as null. Heating degree days are left out without a
+// mean temperature.
+type DailyWeather struct {
+	Date              string   `json:"date"`
+	TempMean          *float64 `json:"temp_mean"`
+	TempMin           *float64 `json:"temp_min"`
+	TempMax           *float64 `json:"temp_max"`
+	Precipitation     *float64 `json:"precipitation"`
+	SnowDepth         *float64 `json:"snow"`
+	HeatingDegreeDays *float64 `json:"heating_degree_days"`
+}
+
+// MonthlyWeather aggregates the days of a month. Each value is aggregated
+// over the days it is present on, and is nil if it is missing on all of them.
+type MonthlyWeather struct {
+	Month             string   `json:"month"`
+	Days              int      `json:"days"`
+	TempMean          *float64 `json:"temp_mean"`
+	TempMin           *float64 `json:"temp_min"`
+	TempMax           *float64 `json:"temp_max"`
+	Precipitation     *float64 `json:"precipitation"`
+	SnowDepthMax      *float64 `json:"snow_max"`
+	HeatingDegreeDays *float64 `json:"heating_degree_days"`
+}
+
+var dailyParameters = []string{"tday", "tmin", "tmax", "rrday", "snow"}
+
+// loadDailyObservations loads daily values for a station. The range must fit
+// in a single FMI request, see ObservationQuery.Chunks.
+func (obs *FMI_ObservationsModel) loadDailyObservations(client HTTPClient, endpoint string, location StationId, query ObservationQuery) error {
+	obs.Observations.Resolution = Days
+	query.Timestep = 0
+	query.Parameters = dailyParameters
+	q := fmt.Sprintf("%s?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::weather::daily::multipointcoverage&fmisid=%s&%s",
+		endpoint, location, query.values().Encode())
+	return obs.load(client, q)
+}
+
+// ConvertToDailyWeather converts daily observations to DailyWeather and
+// calculates heating degree days against the base temperature. Days without
+// any values are left out.
+func (fm FMI_ObservationsModel) ConvertToDailyWeather(base float64) ([]DailyWeather, error) {
+	obs := fm.Observations
+	times, err := obs.positionTimes()
+	if err != nil {
+		return nil, err
+	}
+	lines := splitLines(obs.Measures)
+	if len(times) != len(lines) {
+		return nil, errors.Errorf("The amount of positions doesn't match the measures: Positions len: %d, measures len: %d", len(times), len(lines))
+	}
+	var days []DailyWeather
+	for i, line := range lines {
+		values := strings.Fields(line)
+		if len(values) != len(obs.Fields) {
+			return nil, errors.Errorf("The amount of measures doesn't match the fields: Measures len: %d, fields len: %d", len(values), len(obs.Fields))
+		}
+		date := times[i].UTC()
+		d := DailyWeather{Date: date.Format(time.DateOnly)}
+		hasValues := false
+		for j, field := range obs.Fields {
+			value, err := strconv.ParseFloat(values[j], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to parse string measure %s from position %d from line %d", values[j], j, i)
+			}
+			if math.IsNaN(value) {
+				continue
+			}
+			hasValues = true
+			switch field.Name {
+			case "tday":
+				d.TempMean = valueOrNil(value)
+			case "tmin":
+				d.TempMin = valueOrNil(value)
+			case "tmax":
+				d.TempMax = valueOrNil(value)
+			case "rrday":
+				// -1 marks a day without precipitation
+				d.Precipitation = valueOrNil(math.Max(value, 0))
+			case "snow":
+				// -1 marks a day without snow cover
+				d.SnowDepth = valueOrNil(math.Max(value, 0))
+			}
+		}
+		if !hasValues {
+			continue
+		}
+		if d.TempMean != nil {
+			d.HeatingDegreeDays = valueOrNil(HeatingDegreeDays(date, *d.TempMean, base))
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// HeatingDegreeDays returns the day's heating degree days using FMI's rules:
+// the difference between the base and the mean temperature, counted only when
+// the mean is below the heating threshold of 10 °C in spring (January-June)
+// and 12 °C in autumn (July-December).
+func HeatingDegreeDays(date time.Time, mean, base float64) float64 {
+	threshold := 12.0
+	if date.Month() <= time.June {
+		threshold = 10.0
+	}
+	if mean >= threshold || mean >= base {
+		return 0
+	}
+	return round1(base - mean)
+}
+
+// MonthlyAggregates groups daily values by calendar month. Missing values
+// are left out of the month's minimum, maximum, mean and sums.
+func MonthlyAggregates(days []DailyWeather) []MonthlyWeather {
+	var months []MonthlyWeather
+	var tempDays []int
+	for _, d := range days {
+		month := d.Date[:7]
+		if len(months) == 0 || months[len(months)-1].Month != month {
+			months = append(months, MonthlyWeather{Month: month})
+			tempDays = append(tempDays, 0)
+		}
+		m := &months[len(months)-1]
+		m.Days++
+		if d.TempMean != nil {
+			tempDays[len(tempDays)-1]++
+			m.TempMean = aggregate(m.TempMean, *d.TempMean, sum)
+		}
+		if d.TempMin != nil {
+			m.TempMin = aggregate(m.TempMin, *d.TempMin, math.Min)
+		}
+		if d.TempMax != nil {
+			m.TempMax = aggregate(m.TempMax, *d.TempMax, math.Max)
+		}
+		if d.Precipitation != nil {
+			m.Precipitation = aggregate(m.Precipitation, *d.Precipitation, sum)
+		}
+		if d.SnowDepth != nil {
+			m.SnowDepthMax = aggregate(m.SnowDepthMax, *d.SnowDepth, math.Max)
+		}
+		if d.HeatingDegreeDays != nil {
+			m.HeatingDegreeDays = aggregate(m.HeatingDegreeDays, *d.HeatingDegreeDays, sum)
+		}
+	}
+	for i := range months {
+		m := &months[i]
+		if m.TempMean != nil {
+			*m.TempMean = round1(*m.TempMean / float64(tempDays[i]))
+		}
+		if m.Precipitation != nil {
+			*m.Precipitation = round1(*m.Precipitation)
+		}
+		if m.HeatingDegreeDays != nil {
+			*m.HeatingDegreeDays = round1(*m.HeatingDegreeDays)
+		}
+	}
+	return months
+}
+
+// aggregate combines v into the aggregate so far, which is nil before the
+// first value.
+func aggregate(agg *float64, v float64, combine func(a, b float64) float64) *float64 {
+	if agg == nil {
+		return &v
+	}
+	*agg = combine(*agg, v)
+	return agg
+}
+
+func sum(a, b float64) float64 {
+	return a + b
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
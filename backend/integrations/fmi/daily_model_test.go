@@ -0,0 +1,114 @@
+package fmi
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mikahozz/gohome/integrations/fmi/mock"
+)
+
+func float(v float64) *float64 { return &v }
+
+func TestHeatingDegreeDays(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		mean float64
+		want float64
+	}{
+		{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), -8.4, 25.4},
+		{time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 9.5, 7.5},
+		// Above the spring threshold of 10 °C
+		{time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 10.5, 0},
+		// Autumn threshold is 12 °C
+		{time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC), 11.0, 6.0},
+		{time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC), 12.0, 0},
+	}
+	for _, tt := range tests {
+		if got := HeatingDegreeDays(tt.date, tt.mean, DefaultHeatingBase); got != tt.want {
+			t.Errorf("HeatingDegreeDays(%s, %.1f), got %.1f, want %.1f", tt.date.Format(time.DateOnly), tt.mean, got, tt.want)
+		}
+	}
+	if got := HeatingDegreeDays(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), 5.0, 8.0); got != 3.0 {
+		t.Errorf("HeatingDegreeDays with base 8, got %.1f, want 3.0", got)
+	}
+}
+
+func TestGetDailyWeather(t *testing.T) {
+	client := mock.NewMockHTTPClient("testdata/exampleDaily.xml")
+	service := NewWeatherService(client, "http://mock.api")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	days, err := service.GetDailyWeather(StationId("101004"), start, start.AddDate(0, 0, 7), DefaultHeatingBase)
+	if err != nil {
+		t.Fatalf("GetDailyWeather failed: %v", err)
+	}
+	u, err := url.Parse(client.LastQuery)
+	if err != nil {
+		t.Fatalf("Invalid query %s: %v", client.LastQuery, err)
+	}
+	query := u.Query()
+	if got := query.Get("storedquery_id"); got != "fmi::observations::weather::daily::multipointcoverage" {
+		t.Errorf("storedquery_id, got %s", got)
+	}
+	if got := query.Get("timestep"); got != "" {
+		t.Errorf("Daily query should not set timestep, got %s", got)
+	}
+
+	// 2024-01-06 has no values
+	if len(days) != 7 {
+		t.Fatalf("len(days), got %d, want %d", len(days), 7)
+	}
+	want := DailyWeather{
+		Date:              "2024-01-01",
+		TempMean:          float(-8.4),
+		TempMin:           float(-12.1),
+		TempMax:           float(-5.2),
+		Precipitation:     float(0),
+		SnowDepth:         float(12),
+		HeatingDegreeDays: float(25.4),
+	}
+	if diff := cmp.Diff(want, days[0]); diff != "" {
+		t.Errorf("First day mismatch (-want +got):\n%s", diff)
+	}
+	if days[5].Date != "2024-01-07" {
+		t.Errorf("Sixth day, got %s, want 2024-01-07", days[5].Date)
+	}
+	if diff := cmp.Diff(float(2.3), days[1].Precipitation); diff != "" {
+		t.Errorf("Second day precipitation mismatch (-want +got):\n%s", diff)
+	}
+
+	// 2024-01-03 has no mean or minimum temperature, and so no heating
+	// degree days
+	want = DailyWeather{
+		Date:          "2024-01-03",
+		TempMax:       float(0.3),
+		Precipitation: float(0.4),
+		SnowDepth:     float(15),
+	}
+	if diff := cmp.Diff(want, days[2]); diff != "" {
+		t.Errorf("Partially missing day mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMonthlyAggregates(t *testing.T) {
+	days := []DailyWeather{
+		{Date: "2024-01-30", TempMean: float(-4), TempMin: float(-8), TempMax: float(-1), Precipitation: float(1.2), SnowDepth: float(20), HeatingDegreeDays: float(21)},
+		{Date: "2024-01-31", TempMean: float(-6), TempMin: float(-10), TempMax: float(-2), Precipitation: float(0.3), SnowDepth: float(22), HeatingDegreeDays: float(23)},
+		// Missing values don't count as zeros
+		{Date: "2024-02-01", TempMin: float(-1), Precipitation: float(4)},
+		{Date: "2024-02-02", TempMean: float(1), TempMax: float(3), SnowDepth: float(18), HeatingDegreeDays: float(16)},
+		{Date: "2024-02-03", TempMean: float(3), TempMin: float(2), TempMax: float(4), HeatingDegreeDays: float(14)},
+		{Date: "2024-03-01", Precipitation: float(0)},
+	}
+	months := MonthlyAggregates(days)
+	want := []MonthlyWeather{
+		{Month: "2024-01", Days: 2, TempMean: float(-5), TempMin: float(-10), TempMax: float(-1), Precipitation: float(1.5), SnowDepthMax: float(22), HeatingDegreeDays: float(44)},
+		{Month: "2024-02", Days: 3, TempMean: float(2), TempMin: float(-1), TempMax: float(4), Precipitation: float(4), SnowDepthMax: float(18), HeatingDegreeDays: float(30)},
+		{Month: "2024-03", Days: 1, Precipitation: float(0)},
+	}
+	if diff := cmp.Diff(want, months); diff != "" {
+		t.Errorf("MonthlyAggregates mismatch (-want +got):\n%s", diff)
+	}
+}
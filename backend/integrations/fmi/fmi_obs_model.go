@@ -3,9 +3,6 @@ package fmi
 import (
 	"encoding/xml"
 	"fmt"
-	"io/ioutil"
-	"math"
-	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -24,8 +21,9 @@ type ObservationCollection struct {
 	Resolution    Resolution `validate:"required"`
 	BeginPosition string     `xml:"member>GridSeriesObservation>phenomenonTime>TimePeriod>beginPosition" validate:"required,ISO8601date"`
 	EndPosition   string     `xml:"member>GridSeriesObservation>phenomenonTime>TimePeriod>endPosition" validate:"required,ISO8601date"`
+	Positions     string     `xml:"member>GridSeriesObservation>result>MultiPointCoverage>domainSet>SimpleMultiPoint>positions"`
 	Measures      string     `xml:"member>GridSeriesObservation>result>MultiPointCoverage>rangeSet>DataBlock>doubleOrNilReasonTupleList" validate:"required"`
-	Fields        []Field    `xml:"member>GridSeriesObservation>result>MultiPointCoverage>rangeType>DataRecord>field" validate:"gt=3,dive"`
+	Fields        []Field    `xml:"member>GridSeriesObservation>result>MultiPointCoverage>rangeType>DataRecord>field" validate:"gt=0,dive"`
 }
 type Field struct {
 	Name string `xml:"name,attr" validate:"required"`
@@ -35,6 +33,7 @@ type Resolution int64
 const (
 	Hours Resolution = iota + 1
 	Minutes
+	Days
 )
 
 type RequestType int64
@@ -45,39 +44,78 @@ const (
 )
 
 func (obs *FMI_ObservationsModel) LoadObservations(location StationId, requestType RequestType) error {
+	return obs.loadObservations(NewDefaultHTTPClient(), APIEndpoint, location, requestType)
+}
+
+func (obs *FMI_ObservationsModel) loadObservations(client HTTPClient, endpoint string, location StationId, requestType RequestType) error {
 	q := ""
 	switch requestType {
 	case Observations:
 		obs.Observations.Resolution = Minutes
-		q = fmt.Sprintf("http://opendata.fmi.fi/wfs?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::weather::multipointcoverage&fmisid=%s",
-			location)
+		q = fmt.Sprintf("%s?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::weather::multipointcoverage&fmisid=%s",
+			endpoint, location)
 	case Forecast:
-		obs.Observations.Resolution = Hours
-		q = fmt.Sprintf("http://opendata.fmi.fi/wfs?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::forecast::harmonie::surface::point::multipointcoverage&parameters=Temperature,Humidity,WindSpeedMS,WindGust,WindDirection,precipitation1h,Pressure,DewPoint,Visibility,TotalCloudCover,SmartSymbol&place=%s",
-			location)
+		return obs.loadForecast(client, endpoint, location, ForecastQuery{}, time.Now())
 	default:
 		return errors.Errorf("Invalid requestType: %v", requestType)
 	}
 
-	resp, err := http.Get(q)
-	if err != nil {
-		return errors.Wrap(err, "Error fetching data from FMI")
+	return obs.load(client, q)
+}
+
+// loadForecast loads the point forecast for a place name or coordinates.
+func (obs *FMI_ObservationsModel) loadForecast(client HTTPClient, endpoint string, location StationId, query ForecastQuery, now time.Time) error {
+	obs.Observations.Resolution = Hours
+	locationParam := "place"
+	if isLatLon(location) {
+		locationParam = "latlon"
 	}
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return errors.Wrapf(err, "Error reading body from FMI request: StatusCode: %d", resp.StatusCode)
+	q := fmt.Sprintf("%s?service=WFS&version=2.0.0&request=getFeature&storedquery_id=%s&%s&%s=%s",
+		endpoint, query.storedQuery(), query.values(now).Encode(), locationParam, location)
+	return obs.load(client, q)
+}
+
+// loadObservationRange loads observations for a station over the query's time range.
+// The range must fit in a single FMI request, see ObservationQuery.Chunks.
+func (obs *FMI_ObservationsModel) loadObservationRange(client HTTPClient, endpoint string, location StationId, query ObservationQuery) error {
+	obs.Observations.Resolution = Minutes
+	if query.Timestep >= time.Hour {
+		obs.Observations.Resolution = Hours
 	}
-	if resp.StatusCode != http.StatusOK {
-		return errors.Errorf("Error fetching data from FMI: StatusCode: %d, Body: %s", resp.StatusCode, body)
+	q := fmt.Sprintf("%s?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::weather::multipointcoverage&fmisid=%s&%s",
+		endpoint, location, query.values().Encode())
+	return obs.load(client, q)
+}
+
+func (obs *FMI_ObservationsModel) load(client HTTPClient, q string) error {
+	body, err := client.Get(q)
+	if err != nil {
+		return err
 	}
 
 	err = xml.Unmarshal(body, &obs.Observations)
 	if err != nil {
-		return errors.Wrapf(err, "Error parsing body to FMI_ObservationsModel. Body: %v", body)
+		return errors.Wrapf(err, "Error parsing body to FMI_ObservationsModel. Body: %s", excerpt(body))
 	}
 	return obs.Validate()
 }
 
+// LatLon formats coordinates so they can be used in place of a place name in
+// forecast requests.
+func LatLon(lat, lon float64) StationId {
+	return StationId(strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lon, 'f', -1, 64))
+}
+
+func isLatLon(location StationId) bool {
+	lat, lon, found := strings.Cut(string(location), ",")
+	if !found {
+		return false
+	}
+	_, latErr := strconv.ParseFloat(lat, 64)
+	_, lonErr := strconv.ParseFloat(lon, 64)
+	return latErr == nil && lonErr == nil
+}
+
 func ISO8601Date(fl validator.FieldLevel) bool {
 	ISO8601DateRegexString := "^(?:[1-9]\\d{3}-(?:(?:0[1-9]|1[0-2])-(?:0[1-9]|1\\d|2[0-8])|(?:0[13-9]|1[0-2])-(?:29|30)|(?:0[13578]|1[02])-31)|(?:[1-9]\\d(?:0[48]|[2468][048]|[13579][26])|(?:[2468][048]|[13579][26])00)-02-29)T(?:[01]\\d|2[0-3]):[0-5]\\d:[0-5]\\d(?:\\.\\d{1,9})?(?:Z|[+-][01]\\d:[0-5]\\d)$"
 	ISO8601DateRegex := regexp.MustCompile(ISO8601DateRegexString)
@@ -95,32 +133,29 @@ func (f FMI_ObservationsModel) Validate() error {
 	return nil
 }
 
+// ConvertToWeatherData converts the measures to WeatherData. Row times are
+// read from the positions in the response. Only when they are missing the
+// rows are assumed to follow BeginPosition at the set Resolution.
 func (fm FMI_ObservationsModel) ConvertToWeatherData() (WeatherDataModel, error) {
 	wData := WeatherDataModel{}
 	obs := fm.Observations
-	if obs.Resolution == 0 {
-		return wData, errors.New("Resolution is not set, cannot convert to WeatherData")
-	}
-	lines := strings.Split(
-		strings.TrimSpace(
-			strings.ReplaceAll(obs.Measures, "\r\n", "\n"),
-		),
-		"\n")
-	beginDate, err := time.Parse(time.RFC3339, obs.BeginPosition)
+	lines := splitLines(obs.Measures)
+	times, err := obs.positionTimes()
 	if err != nil {
-		return wData, errors.Wrapf(err, "Failed to parse date: %s", obs.BeginPosition)
+		return wData, err
 	}
-	dt := beginDate
-	var timeAdd time.Duration
-	if obs.Resolution == Hours {
-		timeAdd = time.Hour
+	if times == nil {
+		times, err = obs.resolutionTimes(len(lines))
+		if err != nil {
+			return wData, err
+		}
 	}
-	if obs.Resolution == Minutes {
-		timeAdd = time.Minute * 10
+	if len(times) != len(lines) {
+		return wData, errors.Errorf("The amount of positions doesn't match the measures: Positions len: %d, measures len: %d", len(times), len(lines))
 	}
 	for i, line := range lines {
 		w := WeatherData{}
-		w.Time = dt.UTC().Format(time.RFC3339)
+		w.Time = times[i].UTC().Format(time.RFC3339)
 		values := strings.Split(strings.TrimSpace(line), " ")
 		fields := obs.Fields
 		if len(values) != len(fields) {
@@ -131,52 +166,70 @@ func (fm FMI_ObservationsModel) ConvertToWeatherData() (WeatherDataModel, error)
 			if err != nil {
 				return wData, errors.Wrapf(err, "Failed to parse string measure %s from position %d from line %d: %v", values[j], j, i, err)
 			}
-			switch field.Name {
-			case "TA_PT1H_AVG", "t2m", "Temperature":
-				w.Temp = valueOrZero(value)
-			case "TA_PT1H_MAX":
-				w.TempMax = valueOrZero(value)
-			case "TA_PT1H_MIN":
-				w.TempMin = valueOrZero(value)
-			case "RH_PT1H_AVG", "rh", "Humidity":
-				w.Humidity = valueOrZero(value)
-			case "WS_PT1H_AVG", "ws_10min", "WindSpeedMS":
-				w.WindSpeed = valueOrZero(value)
-			case "WS_PT1H_MAX", "wg_10min", "WindGust":
-				w.MaxWindSpeed = valueOrZero(value)
-			case "WS_PT1H_MIN":
-				w.MinWindSpeed = valueOrZero(value)
-			case "WD_PT1H_AVG", "wd_10min", "WindDirection":
-				w.WindDirection = valueOrZero(value)
-			case "PRA_PT1H_ACC", "r_1h", "precipitation1h":
-				w.Rain = valueOrZero(value)
-			case "PRI_PT1H_MAX", "ri_10min":
-				w.MaxRainIntensity = valueOrZero(value)
-			case "PA_PT1H_AVG", "p_sea", "Pressure":
-				w.Pressure = valueOrZero(value)
-			case "WAWA_PT1H_RANK", "wawa":
-				w.Weather = int(valueOrZero(value))
-			case "td", "DewPoint":
-				w.DewPoint = valueOrZero(value)
-			case "snow_aws":
-				w.SnowDepth = valueOrZero(value)
-			case "vis", "Visibility":
-				w.Visibility = valueOrZero(value)
-			case "n_man", "TotalCloudCover":
-				w.CloudCover = valueOrZero(value)
-			case "SmartSymbol":
-				w.Weather = int(valueOrZero(value))
+			if f, ok := weatherFields[field.Name]; ok {
+				f.set(&w, value)
+				continue
+			}
+			if w.Extra == nil {
+				w.Extra = map[string]*float64{}
 			}
+			w.Extra[field.Name] = valueOrNil(value)
 		}
+		w.Condition = w.condition(times[i])
 		wData.WeatherData = append(wData.WeatherData, w)
-		dt = dt.Add(timeAdd)
 	}
 	return wData, nil
 }
 
-func valueOrZero(v float64) float64 {
-	if math.IsNaN(v) {
-		return 0.0
+func splitLines(s string) []string {
+	return strings.Split(
+		strings.TrimSpace(
+			strings.ReplaceAll(s, "\r\n", "\n"),
+		),
+		"\n")
+}
+
+// positionTimes parses the unix timestamps from the "lat lon time" positions.
+// Returns nil if the response has no positions.
+func (obs ObservationCollection) positionTimes() ([]time.Time, error) {
+	if strings.TrimSpace(obs.Positions) == "" {
+		return nil, nil
+	}
+	var times []time.Time
+	for i, line := range splitLines(obs.Positions) {
+		values := strings.Fields(line)
+		if len(values) != 3 {
+			return nil, errors.Errorf("Invalid position on line %d: %s", i, line)
+		}
+		unix, err := strconv.ParseInt(values[2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to parse time %s from position line %d", values[2], i)
+		}
+		times = append(times, time.Unix(unix, 0))
+	}
+	return times, nil
+}
+
+func (obs ObservationCollection) resolutionTimes(n int) ([]time.Time, error) {
+	var timeAdd time.Duration
+	switch obs.Resolution {
+	case Hours:
+		timeAdd = time.Hour
+	case Minutes:
+		timeAdd = time.Minute * 10
+	case Days:
+		timeAdd = time.Hour * 24
+	default:
+		return nil, errors.New("Resolution is not set, cannot convert to WeatherData")
+	}
+	dt, err := time.Parse(time.RFC3339, obs.BeginPosition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse date: %s", obs.BeginPosition)
+	}
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = dt
+		dt = dt.Add(timeAdd)
 	}
-	return v
+	return times, nil
 }
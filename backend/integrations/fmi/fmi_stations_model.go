@@ -3,8 +3,8 @@ package fmi
 import (
 	"encoding/xml"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
@@ -18,15 +18,19 @@ type StationCollection struct {
 	Stations []Station `xml:"member>EnvironmentalMonitoringFacility" validate:"required,dive"` // Weather stations
 }
 type Station struct {
-	Id    StationId `xml:"identifier" validate:"required"`
-	Names []Name    `xml:"name" validate:"gt=1,dive"`
-	Point string    `xml:"representativePoint>Point>pos" validate:"required"`
+	Id       StationId `xml:"identifier" validate:"required"`
+	Names    []Name    `xml:"name" validate:"gt=1,dive"`
+	Point    string    `xml:"representativePoint>Point>pos" validate:"required"`
+	Networks []Network `xml:"belongsTo"`
 }
 type StationId string
 type Name struct {
 	Key   string `xml:"codeSpace,attr"`
 	Value string `xml:",chardata"`
 }
+type Network struct {
+	Title string `xml:"title,attr"`
+}
 
 func (f FMI_StationsModel) Validate() error {
 	validate := validator.New()
@@ -37,23 +41,24 @@ func (f FMI_StationsModel) Validate() error {
 	return nil
 }
 
+// Station network titles are in Finnish since the catalogue is queried from
+// the Finnish endpoint.
+const StationsEndpoint = "https://opendata.fmi.fi/wfs/fin"
+
 func (fmis *FMI_StationsModel) LoadWeatherStations() error {
-	q := fmt.Sprintf("https://opendata.fmi.fi/wfs/fin?request=getFeature&storedquery_id=fmi::ef::stations")
-	resp, err := http.Get(q)
+	return fmis.loadWeatherStations(NewDefaultHTTPClient(), StationsEndpoint)
+}
+
+func (fmis *FMI_StationsModel) loadWeatherStations(client HTTPClient, endpoint string) error {
+	q := fmt.Sprintf("%s?request=getFeature&storedquery_id=fmi::ef::stations", endpoint)
+	body, err := client.Get(q)
 	if err != nil {
 		return errors.Wrap(err, "Error fetching stations from FMI")
 	}
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return errors.Wrapf(err, "Error reading body from FMI stations request: StatusCode: %d", resp.StatusCode)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return errors.Errorf("Error fetching data from FMI stations: StatusCode: %d, Body: %s", resp.StatusCode, body)
-	}
 
 	err = xml.Unmarshal(body, &fmis.StationsCol)
 	if err != nil {
-		return errors.Wrapf(err, "Error parsing body to FMI_StationsModel. Body: %v", body)
+		return errors.Wrapf(err, "Error parsing body to FMI_StationsModel. Body: %s", excerpt(body))
 	}
 	return fmis.Validate()
 }
@@ -61,8 +66,21 @@ func (fmis *FMI_StationsModel) LoadWeatherStations() error {
 func (s *FMI_StationsModel) ConvertToWeatherStations() (WeatherStationModel, error) {
 	wsm := WeatherStationModel{}
 	for _, station := range s.StationsCol.Stations {
+		lat, lon, err := parsePoint(station.Point)
+		if err != nil {
+			return wsm, errors.Wrapf(err, "Invalid position for station %s", station.Id)
+		}
 		weatherStation := WeatherStation{
-			Id: string(station.Id),
+			Id:  string(station.Id),
+			Lat: lat,
+			Lon: lon,
+		}
+		for _, network := range station.Networks {
+			weatherStation.Networks = append(weatherStation.Networks, network.Title)
+			measures := networkMeasures[network.Title]
+			weatherStation.Temperature = weatherStation.Temperature || measures.temperature
+			weatherStation.Wind = weatherStation.Wind || measures.wind
+			weatherStation.Snow = weatherStation.Snow || measures.snow
 		}
 		for _, name := range station.Names {
 			switch name.Key {
@@ -76,3 +94,35 @@ func (s *FMI_StationsModel) ConvertToWeatherStations() (WeatherStationModel, err
 	}
 	return wsm, wsm.Validate()
 }
+
+// parsePoint parses a "lat lon" gml:pos value.
+func parsePoint(pos string) (float64, float64, error) {
+	parts := strings.Fields(pos)
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("Expected two coordinates, got %q", pos)
+	}
+	lat, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "Failed to parse latitude %s", parts[0])
+	}
+	lon, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "Failed to parse longitude %s", parts[1])
+	}
+	return lat, lon, nil
+}
+
+type measures struct {
+	temperature, wind, snow bool
+}
+
+// networkMeasures tells what a station reports based on the FMI networks it
+// belongs to. Precipitation stations measure snow depth manually, automatic
+// weather stations mostly with a sensor.
+var networkMeasures = map[string]measures{
+	"Automaattinen sääasema":         {temperature: true, wind: true, snow: true},
+	"IL:n hallinnoima lentosääasema": {temperature: true, wind: true},
+	"Sääasema":                       {temperature: true, wind: true},
+	"Mastohavaintoasema":             {temperature: true, wind: true},
+	"Sadeasema":                      {snow: true},
+}
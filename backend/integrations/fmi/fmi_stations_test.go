@@ -50,9 +50,12 @@ func TestStations(t *testing.T) {
 		t.Errorf("WeatherStations length, got %d, want %d", wslen, 452)
 	}
 	tmpStation := WeatherStation{
-		Id:     "100539",
-		Region: "Kemi",
-		Name:   "Kemi Ajos",
+		Id:       "100539",
+		Region:   "Kemi",
+		Name:     "Kemi Ajos",
+		Lat:      65.67337,
+		Lon:      24.51526,
+		Networks: []string{"Mareografiasema"},
 	}
 	if !cmp.Equal(tmpStation, ws.WeatherStations[0]) {
 		t.Errorf("Station compare, got %v, want %v", tmpStation, ws.WeatherStations[0])
@@ -0,0 +1,102 @@
+package fmi
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ForecastModel selects the numerical weather model of a point forecast.
+type ForecastModel string
+
+const (
+	Harmonie ForecastModel = "harmonie" // FMI's high resolution model, about 2 days ahead
+	ECMWF    ForecastModel = "ecmwf"    // European medium range model, about 10 days ahead
+)
+
+// storedQueries holds the WFS stored query of each forecast model.
+var storedQueries = map[ForecastModel]string{
+	Harmonie: "fmi::forecast::harmonie::surface::point::multipointcoverage",
+	ECMWF:    "ecmwf::forecast::surface::point::multipointcoverage",
+}
+
+// MaxForecastHorizon is the longest horizon accepted in a forecast query.
+const MaxForecastHorizon = 240 * time.Hour
+
+// DefaultForecastParameters are the parameters the dashboard forecast uses.
+var DefaultForecastParameters = []string{
+	"Temperature", "Humidity", "WindSpeedMS", "WindGust", "WindDirection", "precipitation1h",
+	"Pressure", "DewPoint", "Visibility", "TotalCloudCover", "SmartSymbol",
+}
+
+// ParameterSets are named parameter lists that can be used in place of
+// listing the parameters. Not every model publishes every parameter.
+var ParameterSets = map[string][]string{
+	"default":  append([]string{}, DefaultForecastParameters...),
+	"extended": append(append([]string{}, DefaultForecastParameters...), "FeelsLike", "UVIndex", "PoP"),
+}
+
+// ForecastQuery selects the model, parameters and horizon of a forecast.
+// The zero value is the HARMONIE forecast with DefaultForecastParameters over
+// the model's whole range.
+type ForecastQuery struct {
+	Model      ForecastModel
+	Parameters []string
+	Horizon    time.Duration
+}
+
+func (q ForecastQuery) Validate() error {
+	if _, ok := storedQueries[q.model()]; !ok {
+		return errors.Errorf("Unknown forecast model %q", q.Model)
+	}
+	if q.Horizon < 0 || q.Horizon > MaxForecastHorizon {
+		return errors.Errorf("Horizon must be between 0 and %s, got %s", MaxForecastHorizon, q.Horizon)
+	}
+	for _, p := range q.Parameters {
+		if p == "" || strings.ContainsAny(p, ", &") {
+			return errors.Errorf("Invalid parameter name %q", p)
+		}
+	}
+	return nil
+}
+
+func (q ForecastQuery) model() ForecastModel {
+	if q.Model == "" {
+		return Harmonie
+	}
+	return q.Model
+}
+
+func (q ForecastQuery) storedQuery() string {
+	return storedQueries[q.model()]
+}
+
+func (q ForecastQuery) values(now time.Time) url.Values {
+	v := url.Values{}
+	parameters := q.Parameters
+	if len(parameters) == 0 {
+		parameters = DefaultForecastParameters
+	}
+	v.Set("parameters", strings.Join(parameters, ","))
+	if q.Horizon > 0 {
+		v.Set("endtime", now.Add(q.Horizon).UTC().Truncate(time.Hour).Format(time.RFC3339))
+	}
+	return v
+}
+
+// ParseParameters resolves a parameter set name or a comma separated list of
+// FMI parameter names. A named set is returned as a copy.
+func ParseParameters(s string) []string {
+	if set, ok := ParameterSets[s]; ok {
+		return append([]string{}, set...)
+	}
+	var parameters []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parameters = append(parameters, p)
+		}
+	}
+	return parameters
+}
@@ -0,0 +1,74 @@
+package fmi
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestForecastQueryValues(t *testing.T) {
+	now := time.Date(2024, 11, 2, 18, 25, 0, 0, time.UTC)
+
+	q := ForecastQuery{}
+	if got := q.storedQuery(); got != "fmi::forecast::harmonie::surface::point::multipointcoverage" {
+		t.Errorf("Default stored query, got %s", got)
+	}
+	v := q.values(now)
+	if got := v.Get("parameters"); got != strings.Join(DefaultForecastParameters, ",") {
+		t.Errorf("Default parameters, got %s", got)
+	}
+	if v.Has("endtime") {
+		t.Errorf("Zero horizon should use the model's range, got endtime %s", v.Get("endtime"))
+	}
+
+	q = ForecastQuery{Model: ECMWF, Parameters: ParseParameters("extended"), Horizon: 72 * time.Hour}
+	if got := q.storedQuery(); got != "ecmwf::forecast::surface::point::multipointcoverage" {
+		t.Errorf("ECMWF stored query, got %s", got)
+	}
+	v = q.values(now)
+	if got := v.Get("endtime"); got != "2024-11-05T18:00:00Z" {
+		t.Errorf("endtime, got %s, want %s", got, "2024-11-05T18:00:00Z")
+	}
+	if got := v.Get("parameters"); !strings.HasSuffix(got, ",FeelsLike,UVIndex,PoP") {
+		t.Errorf("Extended parameters, got %s", got)
+	}
+	// The named set is not modified by the extended set
+	if len(ParameterSets["default"]) != 11 {
+		t.Errorf("Default set length, got %d, want 11", len(ParameterSets["default"]))
+	}
+	// Nor by changes to a parsed set
+	ParseParameters("default")[0] = "Changed"
+	if ParameterSets["default"][0] != "Temperature" || DefaultForecastParameters[0] != "Temperature" {
+		t.Errorf("Default set modified through a parsed set, got %v", ParameterSets["default"])
+	}
+}
+
+func TestForecastQueryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ForecastQuery
+		valid bool
+	}{
+		{"zero", ForecastQuery{}, true},
+		{"ecmwf", ForecastQuery{Model: ECMWF, Horizon: MaxForecastHorizon}, true},
+		{"unknown model", ForecastQuery{Model: "gfs"}, false},
+		{"negative horizon", ForecastQuery{Horizon: -time.Hour}, false},
+		{"horizon too long", ForecastQuery{Horizon: MaxForecastHorizon + time.Hour}, false},
+		{"invalid parameter", ForecastQuery{Parameters: []string{"Temperature&x=1"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate, got %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestParseParameters(t *testing.T) {
+	got := ParseParameters(" Temperature, FeelsLike ,,PoP")
+	if strings.Join(got, ",") != "Temperature,FeelsLike,PoP" {
+		t.Errorf("ParseParameters, got %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package fmi
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const APIEndpoint = "http://opendata.fmi.fi/wfs"
+
+type HTTPClient interface {
+	Get(query string) ([]byte, error)
+}
+
+type DefaultHTTPClient struct{}
+
+func NewDefaultHTTPClient() *DefaultHTTPClient {
+	return &DefaultHTTPClient{}
+}
+
+func (c *DefaultHTTPClient) Get(query string) ([]byte, error) {
+	resp, err := http.Get(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error fetching data from FMI")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading body from FMI request: StatusCode: %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Error fetching data from FMI: StatusCode: %d, Body: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// maxBodyExcerpt limits the part of a response body quoted in errors.
+const maxBodyExcerpt = 512
+
+// excerpt returns the beginning of a response body for error messages.
+func excerpt(body []byte) string {
+	if len(body) > maxBodyExcerpt {
+		return string(body[:maxBodyExcerpt]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,243 @@
+package fmi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// MaxLightningRange is the longest time range accepted in a lightning query.
+const MaxLightningRange = 24 * time.Hour
+
+// MaxLightningRadiusKm limits the search area around a location.
+const MaxLightningRadiusKm = 500.0
+
+// LightningQuery selects strikes within RadiusKm of a point between StartTime
+// and EndTime.
+type LightningQuery struct {
+	Lat       float64
+	Lon       float64
+	RadiusKm  float64
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// LastMinutes returns a query for the strikes of the last minutes before now.
+func LastMinutes(lat, lon, radiusKm float64, minutes int, now time.Time) LightningQuery {
+	return LightningQuery{
+		Lat:       lat,
+		Lon:       lon,
+		RadiusKm:  radiusKm,
+		StartTime: now.Add(-time.Duration(minutes) * time.Minute),
+		EndTime:   now,
+	}
+}
+
+func (q LightningQuery) Validate() error {
+	if q.Lat < -90 || q.Lat > 90 || q.Lon < -180 || q.Lon > 180 {
+		return errors.Errorf("Invalid coordinates %f,%f", q.Lat, q.Lon)
+	}
+	if q.RadiusKm <= 0 || q.RadiusKm > MaxLightningRadiusKm {
+		return errors.Errorf("Radius must be between 0 and %.0f km, got %f", MaxLightningRadiusKm, q.RadiusKm)
+	}
+	if q.StartTime.IsZero() || q.EndTime.IsZero() {
+		return errors.New("Start and end time are required")
+	}
+	if !q.EndTime.After(q.StartTime) {
+		return errors.Errorf("End time %s is not after start time %s", q.EndTime, q.StartTime)
+	}
+	if q.EndTime.Sub(q.StartTime) > MaxLightningRange {
+		return errors.Errorf("Time range is longer than %s", MaxLightningRange)
+	}
+	return nil
+}
+
+// bbox returns the bounding box around the search circle in FMI's
+// lon,lat,lon,lat order.
+func (q LightningQuery) bbox() string {
+	dLat := q.RadiusKm / (earthRadiusKm * math.Pi / 180)
+	dLon := dLat / math.Cos(q.Lat*math.Pi/180)
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+	return strings.Join([]string{f(q.Lon - dLon), f(q.Lat - dLat), f(q.Lon + dLon), f(q.Lat + dLat)}, ",")
+}
+
+func (q LightningQuery) values() url.Values {
+	v := url.Values{}
+	v.Set("starttime", q.StartTime.UTC().Format(time.RFC3339))
+	v.Set("endtime", q.EndTime.UTC().Format(time.RFC3339))
+	v.Set("bbox", q.bbox())
+	v.Set("parameters", "peak_current,cloud_indicator,multiplicity")
+	return v
+}
+
+type LightningStrike struct {
+	Time         string   `json:"datetime"`
+	Lat          float64  `json:"lat"`
+	Lon          float64  `json:"lon"`
+	PeakCurrent  *float64 `json:"peak_current"`   // kA, negative for negative flashes
+	CloudToCloud bool     `json:"cloud_to_cloud"` // false for ground strikes
+	Multiplicity int      `json:"multiplicity"`   // Number of strokes in the flash
+	DistanceKm   float64  `json:"distance_km"`
+}
+
+type LightningReport struct {
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	RadiusKm  float64           `json:"radius_km"`
+	Count     int               `json:"count"`
+	NearestKm *float64          `json:"nearest_km"` // null when there are no strikes
+	Strikes   []LightningStrike `json:"strikes"`
+}
+
+// FMI_LightningModel is the lightning multipointcoverage response. Unlike the
+// weather observations an empty response is valid: no strikes.
+type FMI_LightningModel struct {
+	Observations ObservationCollection
+}
+
+func (l *FMI_LightningModel) loadLightning(client HTTPClient, endpoint string, query LightningQuery) error {
+	q := fmt.Sprintf("%s?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::lightning::multipointcoverage&%s",
+		endpoint, query.values().Encode())
+	body, err := client.Get(q)
+	if err != nil {
+		return err
+	}
+	err = xml.Unmarshal(body, &l.Observations)
+	if err != nil {
+		return errors.Wrapf(err, "Error parsing body to FMI_LightningModel. Body: %s", excerpt(body))
+	}
+	return nil
+}
+
+// ConvertToLightningReport returns the strikes inside the query's radius,
+// nearest first.
+func (l FMI_LightningModel) ConvertToLightningReport(query LightningQuery) (LightningReport, error) {
+	report := LightningReport{
+		From:     query.StartTime.UTC().Format(time.RFC3339),
+		To:       query.EndTime.UTC().Format(time.RFC3339),
+		RadiusKm: query.RadiusKm,
+		Strikes:  []LightningStrike{},
+	}
+	obs := l.Observations
+	if strings.TrimSpace(obs.Measures) == "" {
+		return report, nil
+	}
+	positions := splitLines(obs.Positions)
+	lines := splitLines(obs.Measures)
+	if len(positions) != len(lines) {
+		return report, errors.Errorf("The amount of positions doesn't match the measures: Positions len: %d, measures len: %d", len(positions), len(lines))
+	}
+	for i, line := range lines {
+		pos := strings.Fields(positions[i])
+		if len(pos) != 3 {
+			return report, errors.Errorf("Invalid position on line %d: %s", i, positions[i])
+		}
+		lat, latErr := strconv.ParseFloat(pos[0], 64)
+		lon, lonErr := strconv.ParseFloat(pos[1], 64)
+		unix, timeErr := strconv.ParseInt(pos[2], 10, 64)
+		if latErr != nil || lonErr != nil || timeErr != nil {
+			return report, errors.Errorf("Invalid position on line %d: %s", i, positions[i])
+		}
+		strike := LightningStrike{
+			Time:       time.Unix(unix, 0).UTC().Format(time.RFC3339),
+			Lat:        lat,
+			Lon:        lon,
+			DistanceKm: math.Round(Distance(query.Lat, query.Lon, lat, lon)*10) / 10,
+		}
+		if strike.DistanceKm > query.RadiusKm {
+			continue
+		}
+		values := strings.Fields(line)
+		if len(values) != len(obs.Fields) {
+			return report, errors.Errorf("The amount of measures doesn't match the fields: Measures len: %d, fields len: %d", len(values), len(obs.Fields))
+		}
+		for j, field := range obs.Fields {
+			value, err := strconv.ParseFloat(values[j], 64)
+			if err != nil {
+				return report, errors.Wrapf(err, "Failed to parse string measure %s from position %d from line %d", values[j], j, i)
+			}
+			switch field.Name {
+			case "peak_current":
+				strike.PeakCurrent = valueOrNil(value)
+			case "cloud_indicator":
+				strike.CloudToCloud = value == 1
+			case "multiplicity":
+				if !math.IsNaN(value) {
+					strike.Multiplicity = int(value)
+				}
+			}
+		}
+		report.Strikes = append(report.Strikes, strike)
+	}
+	sort.SliceStable(report.Strikes, func(i, j int) bool {
+		return report.Strikes[i].DistanceKm < report.Strikes[j].DistanceKm
+	})
+	report.Count = len(report.Strikes)
+	if report.Count > 0 {
+		nearest := report.Strikes[0].DistanceKm
+		report.NearestKm = &nearest
+	}
+	return report, nil
+}
+
+// LightningSource returns the strikes for a query.
+type LightningSource interface {
+	GetLightning(query LightningQuery) (LightningReport, error)
+}
+
+// LightningCondition describes lightning a scheduled action reacts to: any
+// strike within RadiusKm of the point during the last Window. The result is
+// reused for TTL so that the filters of every schedule don't each query FMI.
+type LightningCondition struct {
+	Source   LightningSource
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+	Window   time.Duration
+	TTL      time.Duration
+
+	mu        sync.Mutex
+	active    bool
+	checked   bool
+	checkedAt time.Time
+}
+
+// Active reports whether lightning has struck inside the radius during the
+// window before now. If FMI is unavailable the previous result is used.
+func (c *LightningCondition) Active(now time.Time) (bool, error) {
+	if c.Source == nil {
+		return false, errors.New("Lightning condition has no source")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checked && now.Sub(c.checkedAt) < c.TTL && !now.Before(c.checkedAt) {
+		return c.active, nil
+	}
+	report, err := c.Source.GetLightning(LightningQuery{
+		Lat:       c.Lat,
+		Lon:       c.Lon,
+		RadiusKm:  c.RadiusKm,
+		StartTime: now.Add(-c.Window),
+		EndTime:   now,
+	})
+	if err != nil {
+		if c.checked {
+			log.Warn().Err(err).Str("event", "lightning_refresh_failed").Msg("using previously checked lightning")
+			return c.active, nil
+		}
+		return false, err
+	}
+	c.active = report.Count > 0
+	c.checked = true
+	c.checkedAt = now
+	return c.active, nil
+}
@@ -0,0 +1,151 @@
+package fmi
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi/mock"
+)
+
+var lightningEnd = time.Date(2024, 7, 15, 15, 0, 0, 0, time.UTC)
+
+func TestGetLightning(t *testing.T) {
+	client := mock.NewMockHTTPClient("testdata/exampleLightning.xml")
+	service := NewWeatherService(client, "http://mock.api")
+
+	report, err := service.GetLightning(LastMinutes(60.2626, 25.0308, 30, 60, lightningEnd))
+	if err != nil {
+		t.Fatalf("GetLightning failed: %v", err)
+	}
+
+	query := client.LastQuery
+	if !strings.Contains(query, "storedquery_id=fmi::observations::lightning::multipointcoverage") {
+		t.Errorf("Query, got %s", query)
+	}
+	u, err := url.Parse(query)
+	if err != nil {
+		t.Fatalf("Invalid query: %v", err)
+	}
+	if got := u.Query().Get("starttime"); got != "2024-07-15T14:00:00Z" {
+		t.Errorf("starttime, got %s, want %s", got, "2024-07-15T14:00:00Z")
+	}
+	// The bounding box spans the radius in both directions, lon first
+	if got := u.Query().Get("bbox"); got != "24.4869,59.9928,25.5747,60.5324" {
+		t.Errorf("bbox, got %s", got)
+	}
+
+	// The strike 47 km away is in the response but outside the radius
+	if report.Count != 3 || len(report.Strikes) != 3 {
+		t.Fatalf("Count, got %d (%d strikes), want 3", report.Count, len(report.Strikes))
+	}
+	if report.NearestKm == nil || *report.NearestKm != 5.6 {
+		t.Errorf("NearestKm, got %v, want 5.6", report.NearestKm)
+	}
+	nearest := report.Strikes[0]
+	if nearest.Time != "2024-07-15T14:02:11Z" || nearest.Multiplicity != 2 || nearest.CloudToCloud {
+		t.Errorf("Nearest strike, got %+v", nearest)
+	}
+	if nearest.PeakCurrent == nil || *nearest.PeakCurrent != -12.3 {
+		t.Errorf("Peak current, got %v, want -12.3", nearest.PeakCurrent)
+	}
+	if report.Strikes[1].PeakCurrent != nil {
+		t.Errorf("Missing peak current should be nil, got %v", *report.Strikes[1].PeakCurrent)
+	}
+	if !report.Strikes[2].CloudToCloud || report.Strikes[2].DistanceKm != 25.6 {
+		t.Errorf("Farthest strike, got %+v", report.Strikes[2])
+	}
+}
+
+func TestGetLightningNoStrikes(t *testing.T) {
+	service := NewWeatherService(mock.NewMockHTTPClient("testdata/exampleEmpty.xml"), "http://mock.api")
+	report, err := service.GetLightning(LastMinutes(60.2626, 25.0308, 30, 60, lightningEnd))
+	if err != nil {
+		t.Fatalf("GetLightning failed: %v", err)
+	}
+	if report.Count != 0 || report.NearestKm != nil || report.Strikes == nil {
+		t.Errorf("Empty report, got %+v", report)
+	}
+}
+
+func TestLightningQueryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query LightningQuery
+		valid bool
+	}{
+		{"valid", LastMinutes(60.2, 25.0, 30, 60, lightningEnd), true},
+		{"no radius", LastMinutes(60.2, 25.0, 0, 60, lightningEnd), false},
+		{"radius too large", LastMinutes(60.2, 25.0, 1000, 60, lightningEnd), false},
+		{"invalid latitude", LastMinutes(95, 25.0, 30, 60, lightningEnd), false},
+		{"no time range", LastMinutes(60.2, 25.0, 30, 0, lightningEnd), false},
+		{"range too long", LastMinutes(60.2, 25.0, 30, 48*60, lightningEnd), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if (err == nil) != tt.valid {
+				t.Errorf("Validate, got %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestLightningCondition(t *testing.T) {
+	service := NewWeatherService(mock.NewMockHTTPClient("testdata/exampleLightning.xml"), "http://mock.api")
+	near := &LightningCondition{Source: service, Lat: 60.2626, Lon: 25.0308, RadiusKm: 10, Window: time.Hour}
+	active, err := near.Active(lightningEnd)
+	if err != nil || !active {
+		t.Errorf("Strikes within 10 km, got %v (%v), want true", active, err)
+	}
+	tight := &LightningCondition{Source: service, Lat: 60.2626, Lon: 25.0308, RadiusKm: 5, Window: time.Hour}
+	active, err = tight.Active(lightningEnd)
+	if err != nil || active {
+		t.Errorf("Strikes within 5 km, got %v (%v), want false", active, err)
+	}
+	if _, err := (&LightningCondition{}).Active(lightningEnd); err == nil {
+		t.Errorf("Expected error without source")
+	}
+}
+
+// countingLightning returns the strikes of report and counts the queries.
+type countingLightning struct {
+	report  LightningReport
+	err     error
+	queries int
+}
+
+func (c *countingLightning) GetLightning(query LightningQuery) (LightningReport, error) {
+	c.queries++
+	return c.report, c.err
+}
+
+func TestLightningConditionCaches(t *testing.T) {
+	source := &countingLightning{report: LightningReport{Count: 2}}
+	condition := &LightningCondition{Source: source, RadiusKm: 10, Window: time.Hour, TTL: 5 * time.Minute}
+
+	for _, at := range []time.Duration{0, time.Minute, 4 * time.Minute} {
+		active, err := condition.Active(lightningEnd.Add(at))
+		if err != nil || !active {
+			t.Errorf("At %s, got %v (%v), want true", at, active, err)
+		}
+	}
+	if source.queries != 1 {
+		t.Errorf("Queries within the ttl, got %d, want 1", source.queries)
+	}
+
+	// Checked again after the ttl, keeping the previous result if FMI fails
+	source.err = errors.New("unavailable")
+	active, err := condition.Active(lightningEnd.Add(5 * time.Minute))
+	if err != nil || !active {
+		t.Errorf("FMI unavailable, got %v (%v), want the previous true", active, err)
+	}
+	if source.queries != 2 {
+		t.Errorf("Queries after the ttl, got %d, want 2", source.queries)
+	}
+	if _, err := (&LightningCondition{Source: source}).Active(lightningEnd); err == nil {
+		t.Errorf("Expected error without a previous result")
+	}
+}
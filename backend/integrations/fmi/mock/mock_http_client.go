@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"os"
+)
+
+type MockHTTPClient struct {
+	GetFunc func(query string) ([]byte, error)
+	// Queries are the queries made, in order, and LastQuery the latest one
+	Queries   []string
+	LastQuery string
+}
+
+func (m *MockHTTPClient) Get(query string) ([]byte, error) {
+	m.Queries = append(m.Queries, query)
+	m.LastQuery = query
+	return m.GetFunc(query)
+}
+
+func NewMockHTTPClient(filename string) *MockHTTPClient {
+	return &MockHTTPClient{
+		GetFunc: func(query string) ([]byte, error) {
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			return content, nil
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package fmi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxObservationRange is the longest time range FMI serves in a single
+// observation request. Longer queries are split into chunks.
+const MaxObservationRange = 168 * time.Hour
+
+// ObservationQuery selects a time range, timestep and parameters for station
+// observations. Zero Timestep and empty Parameters use the stored query defaults
+// (10 minutes, all weather parameters).
+type ObservationQuery struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	Timestep   time.Duration
+	Parameters []string
+}
+
+func (q ObservationQuery) Validate() error {
+	if q.StartTime.IsZero() || q.EndTime.IsZero() {
+		return errors.New("Start and end time are required")
+	}
+	if !q.EndTime.After(q.StartTime) {
+		return errors.Errorf("End time %s is not after start time %s", q.EndTime, q.StartTime)
+	}
+	if q.Timestep < 0 || q.Timestep%time.Minute != 0 {
+		return errors.Errorf("Timestep must be whole minutes, got %s", q.Timestep)
+	}
+	for _, p := range q.Parameters {
+		if p == "" || strings.ContainsAny(p, ", &") {
+			return errors.Errorf("Invalid parameter name %q", p)
+		}
+	}
+	return nil
+}
+
+// Chunks splits the query into consecutive queries no longer than
+// MaxObservationRange. The chunk boundaries overlap by one instant since FMI
+// includes both start and end time.
+func (q ObservationQuery) Chunks() []ObservationQuery {
+	return q.chunks(MaxObservationRange)
+}
+
+func (q ObservationQuery) chunks(maxRange time.Duration) []ObservationQuery {
+	var chunks []ObservationQuery
+	for start := q.StartTime; start.Before(q.EndTime); {
+		end := start.Add(maxRange)
+		if end.After(q.EndTime) {
+			end = q.EndTime
+		}
+		chunk := q
+		chunk.StartTime = start
+		chunk.EndTime = end
+		chunks = append(chunks, chunk)
+		start = end
+	}
+	return chunks
+}
+
+func (q ObservationQuery) values() url.Values {
+	v := url.Values{}
+	v.Set("starttime", q.StartTime.UTC().Format(time.RFC3339))
+	v.Set("endtime", q.EndTime.UTC().Format(time.RFC3339))
+	if q.Timestep > 0 {
+		v.Set("timestep", strconv.Itoa(int(q.Timestep/time.Minute)))
+	}
+	if len(q.Parameters) > 0 {
+		v.Set("parameters", strings.Join(q.Parameters, ","))
+	}
+	return v
+}
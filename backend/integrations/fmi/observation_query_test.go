@@ -0,0 +1,125 @@
+package fmi
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi/mock"
+)
+
+func TestObservationQueryChunks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := ObservationQuery{StartTime: start, EndTime: start.AddDate(0, 0, 10), Timestep: time.Hour}
+	chunks := q.Chunks()
+	if len(chunks) != 2 {
+		t.Fatalf("len(chunks), got %d, want %d", len(chunks), 2)
+	}
+	if !chunks[0].EndTime.Equal(start.Add(MaxObservationRange)) || !chunks[1].StartTime.Equal(chunks[0].EndTime) {
+		t.Errorf("Chunk boundaries, got %v - %v, %v - %v", chunks[0].StartTime, chunks[0].EndTime, chunks[1].StartTime, chunks[1].EndTime)
+	}
+	if !chunks[1].EndTime.Equal(q.EndTime) {
+		t.Errorf("Last chunk end, got %v, want %v", chunks[1].EndTime, q.EndTime)
+	}
+
+	short := ObservationQuery{StartTime: start, EndTime: start.Add(time.Hour)}
+	if len(short.Chunks()) != 1 {
+		t.Errorf("Short query should not be split, got %d chunks", len(short.Chunks()))
+	}
+}
+
+func TestObservationQueryValidate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query ObservationQuery
+		valid bool
+	}{
+		{"valid", ObservationQuery{StartTime: start, EndTime: start.Add(time.Hour), Timestep: time.Hour, Parameters: []string{"t2m"}}, true},
+		{"missing start", ObservationQuery{EndTime: start}, false},
+		{"end before start", ObservationQuery{StartTime: start, EndTime: start.Add(-time.Hour)}, false},
+		{"seconds timestep", ObservationQuery{StartTime: start, EndTime: start.Add(time.Hour), Timestep: 90 * time.Second}, false},
+		{"invalid parameter", ObservationQuery{StartTime: start, EndTime: start.Add(time.Hour), Parameters: []string{"t2m&x=1"}}, false},
+	}
+	for _, tt := range tests {
+		err := tt.query.Validate()
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: Validate, got %v, want valid=%v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestGetObservationsRange(t *testing.T) {
+	client := mock.NewMockHTTPClient("testdata/exampleHistory.xml")
+	service := NewWeatherService(client, "http://mock.api")
+
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	w, err := service.GetObservations(StationId("101004"), ObservationQuery{
+		StartTime:  start,
+		EndTime:    start.AddDate(0, 0, 10),
+		Timestep:   3 * time.Hour,
+		Parameters: []string{"t2m", "rh"},
+	})
+	if err != nil {
+		t.Fatalf("GetObservations failed: %v", err)
+	}
+
+	var queries []url.Values
+	for _, q := range client.Queries {
+		u, err := url.Parse(q)
+		if err != nil {
+			t.Fatalf("Invalid query %s: %v", q, err)
+		}
+		queries = append(queries, u.Query())
+	}
+	if len(queries) != 2 {
+		t.Fatalf("Requests, got %d, want %d", len(queries), 2)
+	}
+	want := map[string]string{
+		"starttime":  "2022-10-01T00:00:00Z",
+		"endtime":    "2022-10-08T00:00:00Z",
+		"timestep":   "180",
+		"parameters": "t2m,rh",
+		"fmisid":     "101004",
+	}
+	for k, v := range want {
+		if got := queries[0].Get(k); got != v {
+			t.Errorf("Query param %s, got %s, want %s", k, got, v)
+		}
+	}
+	if got := queries[1].Get("starttime"); got != "2022-10-08T00:00:00Z" {
+		t.Errorf("Second chunk starttime, got %s, want %s", got, "2022-10-08T00:00:00Z")
+	}
+
+	// The mock returns the same day for both chunks, duplicates are dropped
+	if len(w.WeatherData) != 9 {
+		t.Fatalf("len(WeatherData), got %d, want %d", len(w.WeatherData), 9)
+	}
+	// Step is taken from the response, not from the resolution
+	if w.WeatherData[1].Time != "2022-10-01T03:00:00Z" {
+		t.Errorf("Second time, got %s, want %s", w.WeatherData[1].Time, "2022-10-01T03:00:00Z")
+	}
+	if *w.WeatherData[4].Temp != 13.0 || *w.WeatherData[4].Humidity != 71.0 {
+		t.Errorf("Fifth row, got %+v", w.WeatherData[4])
+	}
+}
+
+func TestConvertWithoutResolution(t *testing.T) {
+	fmiObs := &FMI_ObservationsModel{}
+	LoadXml(t, "testdata/exampleHistory.xml", fmiObs, 0)
+	w, err := fmiObs.ConvertToWeatherData()
+	if err != nil {
+		t.Fatalf("ConvertToWeatherData failed: %v", err)
+	}
+	if last := w.WeatherData[len(w.WeatherData)-1].Time; last != "2022-10-02T00:00:00Z" {
+		t.Errorf("Last time, got %s, want %s", last, "2022-10-02T00:00:00Z")
+	}
+
+	// Without positions the resolution is still required
+	fmiObs.Observations.Positions = ""
+	_, err = fmiObs.ConvertToWeatherData()
+	if err == nil || !strings.Contains(err.Error(), "Resolution") {
+		t.Errorf("Expected resolution error, got %v", err)
+	}
+}
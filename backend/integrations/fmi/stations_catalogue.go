@@ -0,0 +1,94 @@
+package fmi
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const earthRadiusKm = 6371.0
+
+type NearbyStation struct {
+	WeatherStation
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// StationCatalogue caches the FMI station list. The list changes rarely, so it
+// is refreshed only after ttl has passed. A failed refresh keeps serving the
+// previously loaded stations.
+type StationCatalogue struct {
+	client   HTTPClient
+	endpoint string
+	ttl      time.Duration
+	mu       sync.Mutex
+	stations []WeatherStation
+	loadedAt time.Time
+}
+
+func NewStationCatalogue(client HTTPClient, endpoint string, ttl time.Duration) *StationCatalogue {
+	return &StationCatalogue{
+		client:   client,
+		endpoint: endpoint,
+		ttl:      ttl,
+	}
+}
+
+// Stations returns the cached stations, loading them if needed.
+func (c *StationCatalogue) Stations() ([]WeatherStation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stations != nil && time.Since(c.loadedAt) < c.ttl {
+		return c.stations, nil
+	}
+	fmis := &FMI_StationsModel{}
+	err := fmis.loadWeatherStations(c.client, c.endpoint)
+	if err == nil {
+		var ws WeatherStationModel
+		ws, err = fmis.ConvertToWeatherStations()
+		if err == nil {
+			c.stations = ws.WeatherStations
+			c.loadedAt = time.Now()
+			return c.stations, nil
+		}
+	}
+	if c.stations != nil {
+		log.Warn().Err(err).Str("event", "fmi_stations_refresh_failed").Msg("using previously loaded stations")
+		return c.stations, nil
+	}
+	return nil, err
+}
+
+// Nearest returns the n stations closest to the given coordinates.
+func (c *StationCatalogue) Nearest(lat, lon float64, n int) ([]NearbyStation, error) {
+	stations, err := c.Stations()
+	if err != nil {
+		return nil, err
+	}
+	nearby := make([]NearbyStation, 0, len(stations))
+	for _, s := range stations {
+		nearby = append(nearby, NearbyStation{
+			WeatherStation: s,
+			DistanceKm:     Distance(lat, lon, s.Lat, s.Lon),
+		})
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+	if n < len(nearby) {
+		nearby = nearby[:n]
+	}
+	return nearby, nil
+}
+
+// Distance returns the great-circle distance in kilometers between two points.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,100 @@
+package fmi
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi/mock"
+)
+
+func TestDistance(t *testing.T) {
+	// Helsinki Kumpula to Tampere Härmälä is roughly 155 km
+	d := Distance(60.20307, 24.96131, 61.46561, 23.74706)
+	if math.Abs(d-155.0) > 0.5 {
+		t.Errorf("Distance, got %.1f km, want about 155.0 km", d)
+	}
+	if d := Distance(60.2, 24.9, 60.2, 24.9); d != 0 {
+		t.Errorf("Distance to itself, got %f, want 0", d)
+	}
+}
+
+func TestStationCatalogueNearest(t *testing.T) {
+	client := mock.NewMockHTTPClient("testdata/exampleStations.xml")
+	catalogue := NewStationCatalogue(client, "http://mock.api", time.Hour)
+
+	// Tapanila, Helsinki
+	nearby, err := catalogue.Nearest(60.2626, 25.0308, 5)
+	if err != nil {
+		t.Fatalf("Nearest failed: %v", err)
+	}
+	if len(nearby) != 5 {
+		t.Fatalf("len(nearby), got %d, want %d", len(nearby), 5)
+	}
+	for i := 1; i < len(nearby); i++ {
+		if nearby[i].DistanceKm < nearby[i-1].DistanceKm {
+			t.Errorf("Stations not sorted by distance: %v", nearby)
+		}
+	}
+	if nearby[0].DistanceKm > 10 {
+		t.Errorf("Nearest station %s is %.1f km away, expected a station in Helsinki", nearby[0].Name, nearby[0].DistanceKm)
+	}
+
+	_, err = catalogue.Nearest(65.67, 24.51, 1)
+	if err != nil {
+		t.Fatalf("Nearest failed: %v", err)
+	}
+	if len(client.Queries) != 1 {
+		t.Errorf("Station list loads, got %d, want %d", len(client.Queries), 1)
+	}
+}
+
+func TestStationCatalogueCapabilities(t *testing.T) {
+	catalogue := NewStationCatalogue(mock.NewMockHTTPClient("testdata/exampleStations.xml"), "http://mock.api", time.Hour)
+	stations, err := catalogue.Stations()
+	if err != nil {
+		t.Fatalf("Stations failed: %v", err)
+	}
+	found := false
+	for _, s := range stations {
+		if s.Id == "101004" {
+			found = true
+			if !s.Temperature || !s.Wind {
+				t.Errorf("Helsinki Kumpula should report temperature and wind: %+v", s)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Helsinki Kumpula (101004) not found in stations")
+	}
+}
+
+func TestStationCatalogueStale(t *testing.T) {
+	fail := false
+	client := mock.NewMockHTTPClient("testdata/exampleStations.xml")
+	get := client.GetFunc
+	client.GetFunc = func(q string) ([]byte, error) {
+		if fail {
+			return nil, errors.New("FMI is down")
+		}
+		return get(q)
+	}
+	catalogue := NewStationCatalogue(client, "http://mock.api", 0)
+	if _, err := catalogue.Stations(); err != nil {
+		t.Fatalf("Stations failed: %v", err)
+	}
+	fail = true
+	stations, err := catalogue.Stations()
+	if err != nil {
+		t.Errorf("Expected stale stations on refresh failure, got %v", err)
+	}
+	if len(stations) != 452 {
+		t.Errorf("len(stations), got %d, want %d", len(stations), 452)
+	}
+
+	empty := NewStationCatalogue(client, "http://mock.api", time.Hour)
+	if _, err := empty.Stations(); err == nil {
+		t.Errorf("Expected error without previously loaded stations")
+	}
+}
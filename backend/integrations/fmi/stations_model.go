@@ -9,9 +9,15 @@ type WeatherStationModel struct {
 	WeatherStations []WeatherStation `validate:"required,dive"`
 }
 type WeatherStation struct {
-	Id     string `validate:"required"`
-	Region string
-	Name   string `validate:"required"`
+	Id          string   `json:"id" validate:"required"`
+	Region      string   `json:"region"`
+	Name        string   `json:"name" validate:"required"`
+	Lat         float64  `json:"lat" validate:"latitude"`
+	Lon         float64  `json:"lon" validate:"longitude"`
+	Networks    []string `json:"networks"`
+	Temperature bool     `json:"temperature"`
+	Wind        bool     `json:"wind"`
+	Snow        bool     `json:"snow"`
 }
 
 func (ws WeatherStationModel) Validate() error {
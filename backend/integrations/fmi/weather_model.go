@@ -1,24 +1,192 @@
 package fmi
 
+import (
+	"math"
+	"time"
+)
+
 type WeatherDataModel struct {
 	WeatherData []WeatherData
 }
+
+// WeatherData holds one observation or forecast step. Values missing from the
+// source are nil and serialize as null, so a broken sensor is not mistaken
+// for a real zero.
 type WeatherData struct {
-	Time             string  `json:"datetime"`
-	Temp             float64 `json:"temperature"`
-	TempMax          float64 `json:"temp_max"`
-	TempMin          float64 `json:"temp_min"`
-	Humidity         float64 `json:"humidity"`
-	WindSpeed        float64 `json:"wind_speed"`
-	MaxWindSpeed     float64 `json:"max_wind"`
-	MinWindSpeed     float64 `json:"min_wind"`
-	WindDirection    float64 `json:"wind_dir"`
-	Rain             float64 `json:"rain,omitempty"`
-	MaxRainIntensity float64 `json:"max_rain"`
-	Pressure         float64 `json:"pressure"`
-	Weather          int     `json:"weather"`
-	DewPoint         float64 `json:"dew"`
-	SnowDepth        float64 `json:"snow"`
-	Visibility       float64 `json:"visibility"`
-	CloudCover       float64 `json:"clouds"`
+	Time              string              `json:"datetime"`
+	Temp              *float64            `json:"temperature"`
+	TempMax           *float64            `json:"temp_max"`
+	TempMin           *float64            `json:"temp_min"`
+	Humidity          *float64            `json:"humidity"`
+	WindSpeed         *float64            `json:"wind_speed"`
+	MaxWindSpeed      *float64            `json:"max_wind"`
+	MinWindSpeed      *float64            `json:"min_wind"`
+	WindDirection     *float64            `json:"wind_dir"`
+	Rain              *float64            `json:"rain"`
+	MaxRainIntensity  *float64            `json:"max_rain"`
+	Pressure          *float64            `json:"pressure"`
+	Weather           *int                `json:"weather"` // SmartSymbol in forecasts, WaWa code in observations
+	Condition         *WeatherCondition   `json:"condition"`
+	DewPoint          *float64            `json:"dew"`
+	SnowDepth         *float64            `json:"snow"`
+	Visibility        *float64            `json:"visibility"`
+	CloudCover        *float64            `json:"clouds"`
+	FeelsLike         *float64            `json:"feels_like"`
+	UVIndex           *float64            `json:"uv"`
+	PrecipProbability *float64            `json:"precip_prob"`       // %
+	Extra             map[string]*float64 `json:"extra,omitempty"`   // Measures without a field, by FMI parameter name
+	Quality           map[string]Quality  `json:"quality,omitempty"` // Only values that are not good, by JSON name
+	wawa              bool                // Weather is a WaWa code
+}
+
+// Quality flags a single value in WeatherData.
+type Quality string
+
+const (
+	QualityMissing Quality = "missing" // No value in the source data
+	QualitySuspect Quality = "suspect" // Value outside the physically plausible range
+)
+
+type valueRange struct {
+	min, max float64
+}
+
+// plausibleRanges are generous limits for Finnish conditions. Values outside
+// them are kept but flagged as suspect.
+var plausibleRanges = map[string]valueRange{
+	"temperature": {-60, 45},
+	"temp_max":    {-60, 45},
+	"temp_min":    {-60, 45},
+	"humidity":    {0, 100},
+	"wind_speed":  {0, 75},
+	"max_wind":    {0, 75},
+	"min_wind":    {0, 75},
+	"wind_dir":    {0, 360},
+	"rain":        {0, 300},
+	"max_rain":    {0, 1000},
+	"pressure":    {870, 1085},
+	"dew":         {-70, 35},
+	"snow":        {0, 400},
+	"visibility":  {0, 100000},
+	"clouds":      {0, 100},
+	"feels_like":  {-80, 50},
+	"uv":          {0, 15},
+	"precip_prob": {0, 100},
+}
+
+// weatherField fills one WeatherData field from the FMI parameters listed.
+type weatherField struct {
+	name   string // JSON name, also the key of quality flags
+	params []string
+	set    func(w *WeatherData, v float64)
+}
+
+func floatField(name string, field func(w *WeatherData) **float64, params ...string) weatherField {
+	return weatherField{name: name, params: params, set: func(w *WeatherData, v float64) {
+		*field(w) = w.measure(name, v)
+	}}
+}
+
+func symbolField(wawa bool, params ...string) weatherField {
+	return weatherField{name: "weather", params: params, set: func(w *WeatherData, v float64) {
+		w.Weather = w.symbol(v)
+		w.wawa = wawa
+	}}
+}
+
+// weatherFields maps the FMI parameter names of observations and forecasts to
+// WeatherData fields. Parameters not listed here are kept in Extra.
+var weatherFields = byParameter(
+	floatField("temperature", func(w *WeatherData) **float64 { return &w.Temp }, "TA_PT1H_AVG", "t2m", "Temperature"),
+	floatField("temp_max", func(w *WeatherData) **float64 { return &w.TempMax }, "TA_PT1H_MAX"),
+	floatField("temp_min", func(w *WeatherData) **float64 { return &w.TempMin }, "TA_PT1H_MIN"),
+	floatField("humidity", func(w *WeatherData) **float64 { return &w.Humidity }, "RH_PT1H_AVG", "rh", "Humidity"),
+	floatField("wind_speed", func(w *WeatherData) **float64 { return &w.WindSpeed }, "WS_PT1H_AVG", "ws_10min", "WindSpeedMS"),
+	floatField("max_wind", func(w *WeatherData) **float64 { return &w.MaxWindSpeed }, "WS_PT1H_MAX", "wg_10min", "WindGust"),
+	floatField("min_wind", func(w *WeatherData) **float64 { return &w.MinWindSpeed }, "WS_PT1H_MIN"),
+	floatField("wind_dir", func(w *WeatherData) **float64 { return &w.WindDirection }, "WD_PT1H_AVG", "wd_10min", "WindDirection"),
+	floatField("rain", func(w *WeatherData) **float64 { return &w.Rain }, "PRA_PT1H_ACC", "r_1h", "precipitation1h", "Precipitation1h"),
+	floatField("max_rain", func(w *WeatherData) **float64 { return &w.MaxRainIntensity }, "PRI_PT1H_MAX", "ri_10min"),
+	floatField("pressure", func(w *WeatherData) **float64 { return &w.Pressure }, "PA_PT1H_AVG", "p_sea", "Pressure"),
+	floatField("dew", func(w *WeatherData) **float64 { return &w.DewPoint }, "td", "DewPoint"),
+	floatField("snow", func(w *WeatherData) **float64 { return &w.SnowDepth }, "snow_aws"),
+	floatField("visibility", func(w *WeatherData) **float64 { return &w.Visibility }, "vis", "Visibility"),
+	floatField("clouds", func(w *WeatherData) **float64 { return &w.CloudCover }, "n_man", "TotalCloudCover"),
+	floatField("feels_like", func(w *WeatherData) **float64 { return &w.FeelsLike }, "FeelsLike"),
+	floatField("uv", func(w *WeatherData) **float64 { return &w.UVIndex }, "UVIndex"),
+	floatField("precip_prob", func(w *WeatherData) **float64 { return &w.PrecipProbability }, "PoP", "ProbabilityOfPrecipitation"),
+	symbolField(true, "WAWA_PT1H_RANK", "wawa"),
+	symbolField(false, "SmartSymbol"),
+)
+
+func byParameter(fields ...weatherField) map[string]weatherField {
+	m := map[string]weatherField{}
+	for _, f := range fields {
+		for _, p := range f.params {
+			m[p] = f
+		}
+	}
+	return m
+}
+
+// condition normalizes the weather code. WaWa codes do not tell day from
+// night, so the sun at t does.
+func (w *WeatherData) condition(t time.Time) *WeatherCondition {
+	if w.Weather == nil {
+		return nil
+	}
+	if w.wawa {
+		return FromWaWa(*w.Weather, w.CloudCover, t)
+	}
+	return FromSmartSymbol(*w.Weather)
+}
+
+// setQuality flags the value of the named field if it is missing or suspect.
+func (w *WeatherData) setQuality(name string, v float64) {
+	q := quality(name, v)
+	if q == "" {
+		return
+	}
+	if w.Quality == nil {
+		w.Quality = map[string]Quality{}
+	}
+	w.Quality[name] = q
+}
+
+// measure flags the value of the named field and returns it, nil if missing.
+func (w *WeatherData) measure(name string, v float64) *float64 {
+	w.setQuality(name, v)
+	return valueOrNil(v)
+}
+
+// symbol flags and returns a weather symbol or code, nil if missing.
+func (w *WeatherData) symbol(v float64) *int {
+	w.setQuality("weather", v)
+	return intOrNil(v)
+}
+
+func quality(name string, v float64) Quality {
+	if math.IsNaN(v) {
+		return QualityMissing
+	}
+	r, ok := plausibleRanges[name]
+	if ok && (v < r.min || v > r.max) {
+		return QualitySuspect
+	}
+	return ""
+}
+
+func valueOrNil(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+func intOrNil(v float64) *int {
+	if math.IsNaN(v) {
+		return nil
+	}
+	i := int(v)
+	return &i
 }
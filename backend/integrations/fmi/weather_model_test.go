@@ -0,0 +1,132 @@
+package fmi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConvertKeepsMissingValues(t *testing.T) {
+	fmiObs := &FMI_ObservationsModel{}
+	LoadXml(t, "testdata/exampleHistory.xml", fmiObs, 0)
+	w, err := fmiObs.ConvertToWeatherData()
+	if err != nil {
+		t.Fatalf("ConvertToWeatherData failed: %v", err)
+	}
+
+	// The last row is all NaN
+	last := w.WeatherData[len(w.WeatherData)-1]
+	if last.Temp != nil || last.Humidity != nil {
+		t.Errorf("Missing values, got temperature %v, humidity %v, want nil", last.Temp, last.Humidity)
+	}
+	if last.Quality["temperature"] != QualityMissing || last.Quality["humidity"] != QualityMissing {
+		t.Errorf("Quality, got %v, want both missing", last.Quality)
+	}
+	// Fields not in the response are nil but not flagged
+	if _, ok := last.Quality["pressure"]; ok {
+		t.Errorf("Pressure was not requested and should not be flagged, got %v", last.Quality)
+	}
+	if w.WeatherData[0].Quality != nil {
+		t.Errorf("Good row should not have quality flags, got %v", w.WeatherData[0].Quality)
+	}
+
+	body, err := json.Marshal(last)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(body), `"temperature":null`) {
+		t.Errorf("Missing temperature should serialize as null, got %s", body)
+	}
+}
+
+func TestConvertQualityAndExtra(t *testing.T) {
+	obs := FMI_ObservationsModel{
+		Observations: ObservationCollection{
+			Resolution:    Hours,
+			BeginPosition: "2024-01-01T00:00:00Z",
+			Fields:        []Field{{Name: "t2m"}, {Name: "rh"}, {Name: "wawa"}, {Name: "uv"}},
+			Measures:      "-12.5 130.0 NaN 0.5\n0.0 95.0 61.0 NaN\n",
+		},
+	}
+	w, err := obs.ConvertToWeatherData()
+	if err != nil {
+		t.Fatalf("ConvertToWeatherData failed: %v", err)
+	}
+	first, second := w.WeatherData[0], w.WeatherData[1]
+
+	if first.Humidity == nil || *first.Humidity != 130.0 {
+		t.Errorf("Suspect value should be kept, got %v", first.Humidity)
+	}
+	if first.Quality["humidity"] != QualitySuspect {
+		t.Errorf("Humidity quality, got %q, want %q", first.Quality["humidity"], QualitySuspect)
+	}
+	if first.Weather != nil || first.Quality["weather"] != QualityMissing {
+		t.Errorf("Weather, got %v (%q), want nil (missing)", first.Weather, first.Quality["weather"])
+	}
+
+	// A real zero stays zero
+	if second.Temp == nil || *second.Temp != 0 {
+		t.Errorf("Zero temperature, got %v", second.Temp)
+	}
+	if second.Weather == nil || *second.Weather != 61 {
+		t.Errorf("Weather, got %v, want 61", second.Weather)
+	}
+	// WaWa 61 at 03 Helsinki time
+	if c := second.Condition; c == nil || c.Condition != ConditionLightRain || !c.Night || c.Symbol != 133 {
+		t.Errorf("Condition, got %+v, want light rain at night", c)
+	}
+	if first.Condition != nil {
+		t.Errorf("Missing weather should have no condition, got %+v", first.Condition)
+	}
+
+	// Unrecognized fields are kept as is
+	if v, ok := first.Extra["uv"]; !ok || v == nil || *v != 0.5 {
+		t.Errorf("Extra uv, got %v", first.Extra)
+	}
+	if v, ok := second.Extra["uv"]; !ok || v != nil {
+		t.Errorf("Missing extra uv should be nil, got %v", second.Extra)
+	}
+}
+
+func TestConvertForecastFields(t *testing.T) {
+	obs := FMI_ObservationsModel{
+		Observations: ObservationCollection{
+			Resolution:    Hours,
+			BeginPosition: "2024-07-01T12:00:00Z",
+			Fields:        []Field{{Name: "Temperature"}, {Name: "FeelsLike"}, {Name: "UVIndex"}, {Name: "PoP"}, {Name: "SmartSymbol"}},
+			Measures:      "24.0 25.5 6.2 20.0 2.0\n",
+		},
+	}
+	w, err := obs.ConvertToWeatherData()
+	if err != nil {
+		t.Fatalf("ConvertToWeatherData failed: %v", err)
+	}
+	d := w.WeatherData[0]
+	if d.FeelsLike == nil || *d.FeelsLike != 25.5 {
+		t.Errorf("FeelsLike, got %v, want 25.5", d.FeelsLike)
+	}
+	if d.UVIndex == nil || *d.UVIndex != 6.2 {
+		t.Errorf("UVIndex, got %v, want 6.2", d.UVIndex)
+	}
+	if d.PrecipProbability == nil || *d.PrecipProbability != 20 {
+		t.Errorf("PrecipProbability, got %v, want 20", d.PrecipProbability)
+	}
+	if d.Weather == nil || *d.Weather != 2 || d.Extra != nil {
+		t.Errorf("Weather, got %v, extra %v", d.Weather, d.Extra)
+	}
+	if c := d.Condition; c == nil || c.Condition != ConditionMostlyClear || c.Night || c.Description.En != "Mostly clear" {
+		t.Errorf("Condition, got %+v, want mostly clear by day", c)
+	}
+
+	// The cumulated UV dose is not an index
+	obs.Observations.Fields = []Field{{Name: "UVCumulated"}}
+	obs.Observations.Measures = "3.1\n"
+	w, err = obs.ConvertToWeatherData()
+	if err != nil {
+		t.Fatalf("ConvertToWeatherData failed: %v", err)
+	}
+	d = w.WeatherData[0]
+	if v, ok := d.Extra["UVCumulated"]; d.UVIndex != nil || !ok || v == nil || *v != 3.1 {
+		t.Errorf("UVCumulated, got index %v, extra %v", d.UVIndex, d.Extra)
+	}
+}
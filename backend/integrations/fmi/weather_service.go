@@ -0,0 +1,112 @@
+package fmi
+
+import "time"
+
+type WeatherService struct {
+	client      HTTPClient
+	apiEndpoint string
+}
+
+func NewWeatherService(client HTTPClient, apiEndpoint string) *WeatherService {
+	return &WeatherService{
+		client:      client,
+		apiEndpoint: apiEndpoint,
+	}
+}
+
+func (s *WeatherService) GetWeatherData(id StationId, requestType RequestType) (WeatherDataModel, error) {
+	fmi := &FMI_ObservationsModel{}
+	err := fmi.loadObservations(s.client, s.apiEndpoint, id, requestType)
+	if err != nil {
+		return WeatherDataModel{}, err
+	}
+	w, err := fmi.ConvertToWeatherData()
+	if err != nil {
+		return WeatherDataModel{}, err
+	}
+	return w, nil
+}
+
+// GetForecast returns the point forecast for a place name or coordinates
+// (see LatLon) from the query's model.
+func (s *WeatherService) GetForecast(location StationId, query ForecastQuery) (WeatherDataModel, error) {
+	if err := query.Validate(); err != nil {
+		return WeatherDataModel{}, err
+	}
+	fmi := &FMI_ObservationsModel{}
+	err := fmi.loadForecast(s.client, s.apiEndpoint, location, query, time.Now())
+	if err != nil {
+		return WeatherDataModel{}, err
+	}
+	return fmi.ConvertToWeatherData()
+}
+
+// GetObservations returns the station's observations for the query's time
+// range. Ranges longer than MaxObservationRange are fetched in chunks.
+func (s *WeatherService) GetObservations(id StationId, query ObservationQuery) (WeatherDataModel, error) {
+	if err := query.Validate(); err != nil {
+		return WeatherDataModel{}, err
+	}
+	result := WeatherDataModel{}
+	for _, chunk := range query.Chunks() {
+		fmi := &FMI_ObservationsModel{}
+		err := fmi.loadObservationRange(s.client, s.apiEndpoint, id, chunk)
+		if err != nil {
+			return WeatherDataModel{}, err
+		}
+		w, err := fmi.ConvertToWeatherData()
+		if err != nil {
+			return WeatherDataModel{}, err
+		}
+		for _, d := range w.WeatherData {
+			// Skip the instant shared with the previous chunk
+			if n := len(result.WeatherData); n > 0 && d.Time <= result.WeatherData[n-1].Time {
+				continue
+			}
+			result.WeatherData = append(result.WeatherData, d)
+		}
+	}
+	return result, nil
+}
+
+// GetDailyWeather returns daily temperature, precipitation and snow values with
+// heating degree days calculated against base.
+func (s *WeatherService) GetDailyWeather(id StationId, start, end time.Time, base float64) ([]DailyWeather, error) {
+	query := ObservationQuery{StartTime: start, EndTime: end}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	var days []DailyWeather
+	for _, chunk := range query.chunks(MaxDailyObservationRange) {
+		fmi := &FMI_ObservationsModel{}
+		err := fmi.loadDailyObservations(s.client, s.apiEndpoint, id, chunk)
+		if err != nil {
+			return nil, err
+		}
+		d, err := fmi.ConvertToDailyWeather(base)
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range d {
+			// Skip the day shared with the previous chunk
+			if n := len(days); n > 0 && day.Date <= days[n-1].Date {
+				continue
+			}
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// GetLightning returns the lightning strikes within the query's radius.
+func (s *WeatherService) GetLightning(query LightningQuery) (LightningReport, error) {
+	if err := query.Validate(); err != nil {
+		return LightningReport{}, err
+	}
+	fmi := &FMI_LightningModel{}
+	err := fmi.loadLightning(s.client, s.apiEndpoint, query)
+	if err != nil {
+		return LightningReport{}, err
+	}
+	return fmi.ConvertToLightningReport(query)
+}
@@ -0,0 +1,36 @@
+package fmi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mikahozz/gohome/integrations/fmi/mock"
+)
+
+func TestWeatherServiceObservations(t *testing.T) {
+	client := mock.NewMockHTTPClient("testdata/exampleMinutes.xml")
+	service := NewWeatherService(client, "http://mock.api")
+
+	w, err := service.GetWeatherData(StationId("101004"), Observations)
+	if err != nil {
+		t.Fatalf("GetWeatherData failed: %v", err)
+	}
+	if want := "http://mock.api?service=WFS&version=2.0.0&request=getFeature&storedquery_id=fmi::observations::weather::multipointcoverage&fmisid=101004"; client.LastQuery != want {
+		t.Errorf("Query, got %s, want %s", client.LastQuery, want)
+	}
+	if len(w.WeatherData) != 73 {
+		t.Errorf("len(WeatherData), got %d, want %d", len(w.WeatherData), 73)
+	}
+}
+
+func TestWeatherServiceError(t *testing.T) {
+	client := &mock.MockHTTPClient{GetFunc: func(q string) ([]byte, error) {
+		return nil, errors.New("connection refused")
+	}}
+	service := NewWeatherService(client, "http://mock.api")
+
+	_, err := service.GetWeatherData(StationId("101004"), Observations)
+	if err == nil {
+		t.Errorf("Expected error from failing client")
+	}
+}
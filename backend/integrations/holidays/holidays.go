@@ -0,0 +1,192 @@
+package holidays
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+type Kind string
+
+const (
+	Public  Kind = "public"   // public holiday
+	DayOff  Kind = "day_off"  // not a public holiday but a day off by law, Midsummer Eve and Christmas Eve
+	FlagDay Kind = "flag_day" // official flag day, a working day unless also a holiday
+)
+
+// Holiday is a public holiday, day off or flag day in Finland. A holiday
+// that is also a flag day, such as Independence Day, has Flag set.
+type Holiday struct {
+	// The day as midnight UTC, serialized as YYYY-MM-DD
+	Date   time.Time `json:"date"`
+	Name   string    `json:"name"`
+	NameFi string    `json:"nameFi"`
+	Kind   Kind      `json:"kind"`
+	Flag   bool      `json:"flag"`
+}
+
+func (h Holiday) MarshalJSON() ([]byte, error) {
+	type holiday Holiday
+	return json.Marshal(struct {
+		Date string `json:"date"`
+		holiday
+	}{h.Date.Format(time.DateOnly), holiday(h)})
+}
+
+// Off reports whether the holiday is a day off, Public or DayOff.
+func (h Holiday) Off() bool {
+	return h.Kind == Public || h.Kind == DayOff
+}
+
+// day is a holiday of a year, since the year it has been observed.
+type day struct {
+	name, nameFi string
+	kind         Kind
+	flag         bool
+	since        int
+	date         func(year int) time.Time
+}
+
+func fixed(month time.Month, d int) func(int) time.Time {
+	return func(year int) time.Time {
+		return date(year, month, d)
+	}
+}
+
+func fromEaster(days int) func(int) time.Time {
+	return func(year int) time.Time {
+		return Easter(year).AddDate(0, 0, days)
+	}
+}
+
+// first returns the first weekday on or after the day of the month, e.g.
+// Midsummer Day on the Saturday between 20 and 26 June.
+func first(weekday time.Weekday, month time.Month, d int) func(int) time.Time {
+	return func(year int) time.Time {
+		t := date(year, month, d)
+		return t.AddDate(0, 0, int(weekday-t.Weekday()+7)%7)
+	}
+}
+
+// The holidays by the rules in force since 1992, when Epiphany and
+// Ascension Day returned to their own days
+var days = []day{
+	{"New Year's Day", "Uudenvuodenpäivä", Public, false, 0, fixed(time.January, 1)},
+	{"Epiphany", "Loppiainen", Public, false, 0, fixed(time.January, 6)},
+	{"Runeberg Day", "J. L. Runebergin päivä", FlagDay, true, 0, fixed(time.February, 5)},
+	{"Kalevala Day", "Kalevalan päivä", FlagDay, true, 0, fixed(time.February, 28)},
+	{"Minna Canth Day", "Minna Canthin päivä", FlagDay, true, 2007, fixed(time.March, 19)},
+	{"Mikael Agricola Day", "Mikael Agricolan päivä", FlagDay, true, 0, fixed(time.April, 9)},
+	{"National Veterans' Day", "Kansallinen veteraanipäivä", FlagDay, true, 1987, fixed(time.April, 27)},
+	{"Good Friday", "Pitkäperjantai", Public, false, 0, fromEaster(-2)},
+	{"Easter Sunday", "Pääsiäispäivä", Public, false, 0, fromEaster(0)},
+	{"Easter Monday", "2. pääsiäispäivä", Public, false, 0, fromEaster(1)},
+	{"May Day", "Vappu", Public, true, 0, fixed(time.May, 1)},
+	{"Europe Day", "Eurooppa-päivä", FlagDay, true, 1995, fixed(time.May, 9)},
+	{"J. V. Snellman Day", "J. V. Snellmanin päivä", FlagDay, true, 0, fixed(time.May, 12)},
+	{"Mother's Day", "Äitienpäivä", FlagDay, true, 0, first(time.Sunday, time.May, 8)},
+	{"Remembrance Day", "Kaatuneitten muistopäivä", FlagDay, true, 0, first(time.Sunday, time.May, 15)},
+	{"Ascension Day", "Helatorstai", Public, false, 0, fromEaster(39)},
+	{"Whit Sunday", "Helluntaipäivä", Public, false, 0, fromEaster(49)},
+	{"Flag Day of the Finnish Defence Forces", "Puolustusvoimain lippujuhlan päivä", FlagDay, true, 0, fixed(time.June, 4)},
+	{"Midsummer Eve", "Juhannusaatto", DayOff, false, 0, first(time.Friday, time.June, 19)},
+	{"Midsummer Day", "Juhannuspäivä", Public, true, 0, first(time.Saturday, time.June, 20)},
+	{"Eino Leino Day", "Eino Leinon päivä", FlagDay, true, 0, fixed(time.July, 6)},
+	{"Finnish Nature Day", "Suomen luonnon päivä", FlagDay, true, 2020, first(time.Saturday, time.August, 25)},
+	{"Miina Sillanpää Day", "Miina Sillanpään päivä", FlagDay, true, 2016, fixed(time.October, 1)},
+	{"Aleksis Kivi Day", "Aleksis Kiven päivä", FlagDay, true, 0, fixed(time.October, 10)},
+	{"United Nations Day", "YK:n päivä", FlagDay, true, 0, fixed(time.October, 24)},
+	{"All Saints' Day", "Pyhäinpäivä", Public, false, 0, first(time.Saturday, time.October, 31)},
+	{"Finnish Swedish Heritage Day", "Svenska dagen", FlagDay, true, 0, fixed(time.November, 6)},
+	{"Father's Day", "Isänpäivä", FlagDay, true, 0, first(time.Sunday, time.November, 8)},
+	{"Independence Day", "Itsenäisyyspäivä", Public, true, 0, fixed(time.December, 6)},
+	{"Jean Sibelius Day", "Jean Sibeliuksen päivä", FlagDay, true, 2011, fixed(time.December, 8)},
+	{"Christmas Eve", "Jouluaatto", DayOff, false, 0, fixed(time.December, 24)},
+	{"Christmas Day", "Joulupäivä", Public, false, 0, fixed(time.December, 25)},
+	{"St. Stephen's Day", "Tapaninpäivä", Public, false, 0, fixed(time.December, 26)},
+}
+
+func date(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Easter returns Easter Sunday of the year in the Gregorian calendar, as
+// midnight UTC.
+func Easter(year int) time.Time {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	return date(year, time.Month(month), (h+l-7*m+114)%31+1)
+}
+
+// Year returns the public holidays, days off and flag days of the year in
+// date order.
+func Year(year int) []Holiday {
+	holidays := []Holiday{}
+	for _, d := range days {
+		if year < d.since {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: d.date(year), Name: d.name, NameFi: d.nameFi, Kind: d.kind, Flag: d.flag})
+	}
+	// Holidays from Easter fall among the fixed ones
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// Between returns the holidays on the days overlapping start and end, in
+// date order. Days are those of start's location.
+func Between(start, end time.Time) []Holiday {
+	from := date(start.Year(), start.Month(), start.Day())
+	end = end.In(start.Location())
+	to := date(end.Year(), end.Month(), end.Day())
+	if end.Hour() != 0 || end.Minute() != 0 || end.Second() != 0 || end.Nanosecond() != 0 {
+		// The day end falls on is partly in the range
+		to = to.AddDate(0, 0, 1)
+	}
+	holidays := []Holiday{}
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, h := range Year(year) {
+			if !h.Date.Before(from) && h.Date.Before(to) {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	return holidays
+}
+
+// On returns the public holiday or day off on the day of t in t's location.
+// Flag days are not days off and are not returned.
+func On(t time.Time) (Holiday, bool) {
+	day := date(t.Year(), t.Month(), t.Day())
+	for _, h := range Year(t.Year()) {
+		if h.Off() && h.Date.Equal(day) {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// Condition is active on public holidays and days off, on the days of
+// Location or, if nil, of the time it is checked at.
+type Condition struct {
+	Location *time.Location
+}
+
+func (c Condition) Active(now time.Time) (bool, error) {
+	if c.Location != nil {
+		now = now.In(c.Location)
+	}
+	_, ok := On(now)
+	return ok, nil
+}
@@ -0,0 +1,120 @@
+package holidays
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEaster(t *testing.T) {
+	for year, want := range map[int]string{
+		2000: "2000-04-23",
+		2008: "2008-03-23",
+		2011: "2011-04-24",
+		2019: "2019-04-21",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2038: "2038-04-25",
+	} {
+		assert.Equal(t, want, Easter(year).Format(time.DateOnly), "%d", year)
+	}
+}
+
+func TestYear(t *testing.T) {
+	var off []string
+	for _, h := range Year(2025) {
+		if h.Off() {
+			off = append(off, h.Date.Format(time.DateOnly)+" "+h.Name)
+		}
+	}
+	assert.Equal(t, []string{
+		"2025-01-01 New Year's Day",
+		"2025-01-06 Epiphany",
+		"2025-04-18 Good Friday",
+		"2025-04-20 Easter Sunday",
+		"2025-04-21 Easter Monday",
+		"2025-05-01 May Day",
+		"2025-05-29 Ascension Day",
+		"2025-06-08 Whit Sunday",
+		"2025-06-20 Midsummer Eve",
+		"2025-06-21 Midsummer Day",
+		"2025-11-01 All Saints' Day",
+		"2025-12-06 Independence Day",
+		"2025-12-24 Christmas Eve",
+		"2025-12-25 Christmas Day",
+		"2025-12-26 St. Stephen's Day",
+	}, off)
+
+	// Movable days by weekday
+	for year, want := range map[int][]string{
+		2024: {"2024-05-12", "2024-05-19", "2024-06-21", "2024-06-22", "2024-08-31", "2024-11-02", "2024-11-10"},
+		2026: {"2026-05-10", "2026-05-17", "2026-06-19", "2026-06-20", "2026-08-29", "2026-10-31", "2026-11-08"},
+	} {
+		var got []string
+		for _, h := range Year(year) {
+			switch h.Name {
+			case "Mother's Day", "Remembrance Day", "Midsummer Eve", "Midsummer Day", "Finnish Nature Day", "All Saints' Day", "Father's Day":
+				got = append(got, h.Date.Format(time.DateOnly))
+			}
+		}
+		assert.Equal(t, want, got, "%d", year)
+	}
+
+	// Flag days established later are left out of earlier years
+	names := func(year int) map[string]bool {
+		found := map[string]bool{}
+		for _, h := range Year(year) {
+			found[h.Name] = true
+		}
+		return found
+	}
+	assert.True(t, names(2016)["Miina Sillanpää Day"])
+	assert.False(t, names(2015)["Miina Sillanpää Day"])
+	assert.False(t, names(2019)["Finnish Nature Day"])
+}
+
+func TestBetween(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+	start := time.Date(2025, 12, 24, 0, 0, 0, 0, helsinki)
+
+	var got []string
+	for _, h := range Between(start, start.AddDate(0, 0, 15)) {
+		got = append(got, h.Date.Format(time.DateOnly)+" "+string(h.Kind))
+	}
+	assert.Equal(t, []string{"2025-12-24 day_off", "2025-12-25 public", "2025-12-26 public", "2026-01-01 public", "2026-01-06 public"}, got)
+
+	// A day partly in the range is included
+	holidays := Between(start.Add(-time.Hour), start.Add(time.Hour))
+	require.Len(t, holidays, 1)
+	assert.Equal(t, "Christmas Eve", holidays[0].Name)
+	assert.Empty(t, Between(start.AddDate(0, 0, -1), start))
+}
+
+func TestOn(t *testing.T) {
+	helsinki, err := time.LoadLocation("Europe/Helsinki")
+	require.NoError(t, err)
+
+	h, ok := On(time.Date(2025, 6, 20, 6, 45, 0, 0, helsinki))
+	assert.True(t, ok)
+	assert.Equal(t, "Juhannusaatto", h.NameFi)
+	// The day is that of t's location
+	_, ok = On(time.Date(2025, 12, 6, 0, 30, 0, 0, helsinki).UTC())
+	assert.False(t, ok)
+	_, ok = On(time.Date(2025, 12, 6, 0, 30, 0, 0, helsinki))
+	assert.True(t, ok)
+	// Flag days are working days
+	_, ok = On(time.Date(2025, 2, 5, 12, 0, 0, 0, helsinki))
+	assert.False(t, ok)
+}
+
+func TestHolidayJSON(t *testing.T) {
+	h, ok := On(time.Date(2025, 12, 6, 12, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	data, err := json.Marshal(h)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"date":"2025-12-06","name":"Independence Day","nameFi":"Itsenäisyyspäivä","kind":"public","flag":true}`, string(data))
+}
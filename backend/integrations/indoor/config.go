@@ -0,0 +1,37 @@
+package indoor
+
+import (
+	"os"
+	"strings"
+)
+
+// LoadSensors reads the sensors to register from the environment:
+//
+//	INDOOR_SENSORS=dev_upstairs,Shelly
+//	INDOOR_SENSOR_DEV_UPSTAIRS_NAME=Upstairs
+//	INDOOR_SENSOR_DEV_UPSTAIRS_ROOM=upstairs
+//
+// The name defaults to the id.
+func LoadSensors() ([]Sensor, error) {
+	var sensors []Sensor
+	for _, id := range strings.Split(os.Getenv("INDOOR_SENSORS"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		prefix := "INDOOR_SENSOR_" + strings.ToUpper(id) + "_"
+		s := Sensor{
+			ID:   id,
+			Name: os.Getenv(prefix + "NAME"),
+			Room: os.Getenv(prefix + "ROOM"),
+		}
+		if s.Name == "" {
+			s.Name = id
+		}
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, s)
+	}
+	return sensors, nil
+}
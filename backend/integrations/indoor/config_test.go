@@ -0,0 +1,27 @@
+package indoor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSensors(t *testing.T) {
+	t.Setenv("INDOOR_SENSORS", "dev_upstairs, Shelly")
+	t.Setenv("INDOOR_SENSOR_DEV_UPSTAIRS_NAME", "Upstairs")
+	t.Setenv("INDOOR_SENSOR_DEV_UPSTAIRS_ROOM", "upstairs")
+
+	sensors, err := LoadSensors()
+	require.NoError(t, err)
+	assert.Equal(t, []Sensor{
+		{ID: "dev_upstairs", Name: "Upstairs", Room: "upstairs"},
+		{ID: "Shelly", Name: "Shelly"},
+	}, sensors)
+}
+
+func TestLoadSensorsInvalid(t *testing.T) {
+	t.Setenv("INDOOR_SENSORS", "living room")
+	_, err := LoadSensors()
+	assert.Error(t, err)
+}
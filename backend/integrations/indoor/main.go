@@ -0,0 +1,78 @@
+// Package indoor keeps the registry of indoor climate sensors and their
+// temperature, humidity and battery readings.
+package indoor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrUnknownSensor  = errors.New("unknown sensor")
+	ErrNoReadings     = errors.New("no readings")
+	ErrInvalidReading = errors.New("invalid reading")
+)
+
+// MaxHistoryRange is the longest time range served in one history query.
+const MaxHistoryRange = 31 * 24 * time.Hour
+
+// Sensor is a registered indoor sensor. The ID is used in the API path.
+type Sensor struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Room string `json:"room"`
+}
+
+var sensorID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,50}$`)
+
+func (s Sensor) Validate() error {
+	if !sensorID.MatchString(s.ID) {
+		return fmt.Errorf("invalid sensor id %q", s.ID)
+	}
+	return nil
+}
+
+// Reading is one measurement of a sensor. Values the sensor does not report
+// are nil.
+type Reading struct {
+	Time        time.Time `json:"time"`
+	Temperature *float64  `json:"temperature"` // °C
+	Humidity    *float64  `json:"humidity"`    // %
+	Battery     *float64  `json:"battery"`     // %
+}
+
+// Validate checks the reading has a time, at least one value and values in
+// plausible ranges.
+func (r Reading) Validate() error {
+	if r.Time.IsZero() {
+		return fmt.Errorf("%w: time is required", ErrInvalidReading)
+	}
+	if r.Temperature == nil && r.Humidity == nil && r.Battery == nil {
+		return fmt.Errorf("%w: no values", ErrInvalidReading)
+	}
+	if r.Temperature != nil && (*r.Temperature < -50 || *r.Temperature > 100) {
+		return fmt.Errorf("%w: temperature %.1f out of range", ErrInvalidReading, *r.Temperature)
+	}
+	if r.Humidity != nil && (*r.Humidity < 0 || *r.Humidity > 100) {
+		return fmt.Errorf("%w: humidity %.1f out of range", ErrInvalidReading, *r.Humidity)
+	}
+	if r.Battery != nil && (*r.Battery < 0 || *r.Battery > 100) {
+		return fmt.Errorf("%w: battery %.1f out of range", ErrInvalidReading, *r.Battery)
+	}
+	return nil
+}
+
+// Store persists sensors and readings.
+type Store interface {
+	Sensors(ctx context.Context) ([]Sensor, error)
+	Sensor(ctx context.Context, id string) (Sensor, bool, error)
+	SaveSensor(ctx context.Context, sensor Sensor) error
+	SaveReading(ctx context.Context, sensorID string, reading Reading) error
+	// Latest returns ErrNoReadings when the sensor has none.
+	Latest(ctx context.Context, sensorID string) (Reading, error)
+	// History returns the readings from (inclusive) to (exclusive), oldest first.
+	History(ctx context.Context, sensorID string, from, to time.Time) ([]Reading, error)
+}
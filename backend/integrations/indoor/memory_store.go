@@ -0,0 +1,83 @@
+package indoor
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps sensors and readings in memory, for tests and for
+// running without a database.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sensors  map[string]Sensor
+	readings map[string][]Reading // by sensor, oldest first
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sensors: map[string]Sensor{}, readings: map[string][]Reading{}}
+}
+
+func (m *MemoryStore) Sensors(ctx context.Context) ([]Sensor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sensors := make([]Sensor, 0, len(m.sensors))
+	for _, s := range m.sensors {
+		sensors = append(sensors, s)
+	}
+	sort.Slice(sensors, func(i, j int) bool { return sensors[i].ID < sensors[j].ID })
+	return sensors, nil
+}
+
+func (m *MemoryStore) Sensor(ctx context.Context, id string) (Sensor, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.sensors[id]
+	return s, ok, nil
+}
+
+func (m *MemoryStore) SaveSensor(ctx context.Context, sensor Sensor) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sensors[sensor.ID] = sensor
+	return nil
+}
+
+func (m *MemoryStore) SaveReading(ctx context.Context, sensorID string, reading Reading) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	readings := m.readings[sensorID]
+	i := sort.Search(len(readings), func(i int) bool { return !readings[i].Time.Before(reading.Time) })
+	if i < len(readings) && readings[i].Time.Equal(reading.Time) {
+		readings[i] = reading
+		return nil
+	}
+	readings = append(readings, Reading{})
+	copy(readings[i+1:], readings[i:])
+	readings[i] = reading
+	m.readings[sensorID] = readings
+	return nil
+}
+
+func (m *MemoryStore) Latest(ctx context.Context, sensorID string) (Reading, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	readings := m.readings[sensorID]
+	if len(readings) == 0 {
+		return Reading{}, ErrNoReadings
+	}
+	return readings[len(readings)-1], nil
+}
+
+func (m *MemoryStore) History(ctx context.Context, sensorID string, from, to time.Time) ([]Reading, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	history := []Reading{}
+	for _, r := range m.readings[sensorID] {
+		if !r.Time.Before(from) && r.Time.Before(to) {
+			history = append(history, r)
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,141 @@
+package indoor
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PostgresStore keeps the sensors in the sensors table and the readings in
+// measurements, with the temperature as the main value.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// readingValue is the JSON stored in measurements.value.
+type readingValue struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	Humidity    *float64 `json:"humidity,omitempty"`
+	Battery     *float64 `json:"battery,omitempty"`
+}
+
+func (p *PostgresStore) Sensors(ctx context.Context) ([]Sensor, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT id, name, room FROM sensors ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("query sensors: %w", err)
+	}
+	defer rows.Close()
+	sensors := []Sensor{}
+	for rows.Next() {
+		var s Sensor
+		if err := rows.Scan(&s.ID, &s.Name, &s.Room); err != nil {
+			return nil, fmt.Errorf("scan sensor: %w", err)
+		}
+		sensors = append(sensors, s)
+	}
+	return sensors, rows.Err()
+}
+
+func (p *PostgresStore) Sensor(ctx context.Context, id string) (Sensor, bool, error) {
+	var s Sensor
+	err := p.db.QueryRowContext(ctx, `SELECT id, name, room FROM sensors WHERE id = $1`, id).Scan(&s.ID, &s.Name, &s.Room)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Sensor{}, false, nil
+	}
+	if err != nil {
+		return Sensor{}, false, fmt.Errorf("query sensor %s: %w", id, err)
+	}
+	return s, true, nil
+}
+
+func (p *PostgresStore) SaveSensor(ctx context.Context, sensor Sensor) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO sensors (id, name, room) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, room = EXCLUDED.room`,
+		sensor.ID, sensor.Name, sensor.Room)
+	if err != nil {
+		return fmt.Errorf("save sensor %s: %w", sensor.ID, err)
+	}
+	return nil
+}
+
+func (p *PostgresStore) SaveReading(ctx context.Context, sensorID string, reading Reading) error {
+	value, err := json.Marshal(readingValue{
+		Temperature: reading.Temperature,
+		Humidity:    reading.Humidity,
+		Battery:     reading.Battery,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO measurements (timestamp, sensor_id, main_value, value) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (timestamp, sensor_id) DO UPDATE SET main_value = EXCLUDED.main_value, value = EXCLUDED.value`,
+		reading.Time, sensorID, reading.Temperature, value)
+	if err != nil {
+		return fmt.Errorf("save reading of %s: %w", sensorID, err)
+	}
+	return nil
+}
+
+func (p *PostgresStore) Latest(ctx context.Context, sensorID string) (Reading, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT timestamp, value FROM measurements
+		WHERE sensor_id = $1 ORDER BY timestamp DESC LIMIT 1`, sensorID)
+	r, err := scanReading(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Reading{}, ErrNoReadings
+	}
+	if err != nil {
+		return Reading{}, fmt.Errorf("query latest reading of %s: %w", sensorID, err)
+	}
+	return r, nil
+}
+
+func (p *PostgresStore) History(ctx context.Context, sensorID string, from, to time.Time) ([]Reading, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT timestamp, value FROM measurements
+		WHERE sensor_id = $1 AND timestamp >= $2 AND timestamp < $3
+		ORDER BY timestamp`, sensorID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query history of %s: %w", sensorID, err)
+	}
+	defer rows.Close()
+	history := []Reading{}
+	for rows.Next() {
+		r, err := scanReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reading of %s: %w", sensorID, err)
+		}
+		history = append(history, r)
+	}
+	return history, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReading(s scanner) (Reading, error) {
+	var (
+		r     Reading
+		value []byte
+		v     readingValue
+	)
+	if err := s.Scan(&r.Time, &value); err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return r, err
+	}
+	r.Time = r.Time.UTC()
+	r.Temperature, r.Humidity, r.Battery = v.Temperature, v.Humidity, v.Battery
+	return r, nil
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package indoor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/config"
+	"github.com/mikahozz/gohome/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore(t *testing.T) {
+	config.LoadEnv()
+	conn, err := db.Open()
+	if err != nil {
+		t.Skipf("database not available: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	store := NewPostgresStore(conn)
+	r := NewRegistry(store)
+
+	id := "integ_test"
+	require.NoError(t, r.Register(ctx, Sensor{ID: id, Name: "Integration test"}))
+	sensor, err := r.Sensor(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Integration test", sensor.Name)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, r.Record(ctx, id, Reading{Time: now.Add(-time.Minute), Temperature: float(21.5), Humidity: float(30)}))
+	require.NoError(t, r.Record(ctx, id, Reading{Time: now, Temperature: float(21.7), Battery: float(88)}))
+
+	latest, err := r.Latest(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(latest.Time))
+	assert.Equal(t, 21.7, *latest.Temperature)
+	assert.Nil(t, latest.Humidity)
+
+	history, err := r.History(ctx, id, now.Add(-time.Hour), now.Add(time.Second))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(history), 2)
+	assert.True(t, history[0].Time.Before(history[len(history)-1].Time))
+}
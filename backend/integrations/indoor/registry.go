@@ -0,0 +1,126 @@
+package indoor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Registry accepts and serves readings of registered sensors only. Recorded
+// readings are also sent to the subscribers of the live stream.
+type Registry struct {
+	store       Store
+	mu          sync.Mutex
+	subscribers map[chan Update]struct{}
+}
+
+// Update is a recorded reading of a sensor.
+type Update struct {
+	Sensor string `json:"sensor"`
+	Reading
+}
+
+// subscriberBuffer is how many updates a subscriber can fall behind before
+// updates to it are dropped.
+const subscriberBuffer = 16
+
+func NewRegistry(store Store) *Registry {
+	return &Registry{store: store, subscribers: map[chan Update]struct{}{}}
+}
+
+// Subscribe returns the readings recorded from now on and a function that
+// ends the subscription.
+func (r *Registry) Subscribe() (<-chan Update, func()) {
+	ch := make(chan Update, subscriberBuffer)
+	r.mu.Lock()
+	r.subscribers[ch] = struct{}{}
+	r.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.subscribers, ch)
+			r.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (r *Registry) publish(u Update) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ch := range r.subscribers {
+		select {
+		case ch <- u:
+		default:
+			// Slow subscriber, it misses this update
+		}
+	}
+}
+
+// Register adds the sensors or updates their names and rooms.
+func (r *Registry) Register(ctx context.Context, sensors ...Sensor) error {
+	for _, s := range sensors {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if err := r.store.SaveSensor(ctx, s); err != nil {
+			return fmt.Errorf("register sensor %s: %w", s.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) Sensors(ctx context.Context) ([]Sensor, error) {
+	return r.store.Sensors(ctx)
+}
+
+func (r *Registry) Sensor(ctx context.Context, id string) (Sensor, error) {
+	s, ok, err := r.store.Sensor(ctx, id)
+	if err != nil {
+		return Sensor{}, err
+	}
+	if !ok {
+		return Sensor{}, fmt.Errorf("%w %s", ErrUnknownSensor, id)
+	}
+	return s, nil
+}
+
+// Record stores a reading of a registered sensor. A reading for the same
+// time replaces the earlier one.
+func (r *Registry) Record(ctx context.Context, id string, reading Reading) error {
+	if _, err := r.Sensor(ctx, id); err != nil {
+		return err
+	}
+	if err := reading.Validate(); err != nil {
+		return err
+	}
+	reading.Time = reading.Time.UTC()
+	if err := r.store.SaveReading(ctx, id, reading); err != nil {
+		return err
+	}
+	r.publish(Update{Sensor: id, Reading: reading})
+	return nil
+}
+
+func (r *Registry) Latest(ctx context.Context, id string) (Reading, error) {
+	if _, err := r.Sensor(ctx, id); err != nil {
+		return Reading{}, err
+	}
+	return r.store.Latest(ctx, id)
+}
+
+// History returns the readings between from and to, oldest first.
+func (r *Registry) History(ctx context.Context, id string, from, to time.Time) ([]Reading, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("end time %s is not after start time %s", to, from)
+	}
+	if to.Sub(from) > MaxHistoryRange {
+		return nil, fmt.Errorf("time range is longer than %s", MaxHistoryRange)
+	}
+	if _, err := r.Sensor(ctx, id); err != nil {
+		return nil, err
+	}
+	return r.store.History(ctx, id, from, to)
+}
@@ -0,0 +1,131 @@
+package indoor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float(v float64) *float64 { return &v }
+
+var readingTime = time.Date(2024, 11, 2, 18, 0, 0, 0, time.UTC)
+
+func newTestRegistry(t *testing.T) *Registry {
+	r := NewRegistry(NewMemoryStore())
+	require.NoError(t, r.Register(context.Background(), Sensor{ID: "dev_upstairs", Name: "Upstairs", Room: "upstairs"}))
+	return r
+}
+
+func TestRecordAndLatest(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+
+	_, err := r.Latest(ctx, "dev_upstairs")
+	assert.ErrorIs(t, err, ErrNoReadings)
+
+	require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime, Temperature: float(22.5), Humidity: float(27.4), Battery: float(100)}))
+	require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime.Add(-time.Hour), Temperature: float(21.0)}))
+
+	latest, err := r.Latest(ctx, "dev_upstairs")
+	require.NoError(t, err)
+	assert.Equal(t, readingTime, latest.Time)
+	assert.Equal(t, 22.5, *latest.Temperature)
+	assert.Equal(t, 100.0, *latest.Battery)
+
+	// A reading for the same time replaces the earlier one
+	require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime, Temperature: float(22.7)}))
+	latest, err = r.Latest(ctx, "dev_upstairs")
+	require.NoError(t, err)
+	assert.Equal(t, 22.7, *latest.Temperature)
+	assert.Nil(t, latest.Humidity)
+}
+
+func TestUnknownSensor(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+	assert.ErrorIs(t, r.Record(ctx, "attic", Reading{Time: readingTime, Temperature: float(10)}), ErrUnknownSensor)
+	_, err := r.Latest(ctx, "attic")
+	assert.ErrorIs(t, err, ErrUnknownSensor)
+	_, err = r.History(ctx, "attic", readingTime.Add(-time.Hour), readingTime)
+	assert.ErrorIs(t, err, ErrUnknownSensor)
+}
+
+func TestInvalidReadings(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+	tests := []struct {
+		name    string
+		reading Reading
+	}{
+		{"no time", Reading{Temperature: float(20)}},
+		{"no values", Reading{Time: readingTime}},
+		{"temperature", Reading{Time: readingTime, Temperature: float(150)}},
+		{"humidity", Reading{Time: readingTime, Humidity: float(101)}},
+		{"battery", Reading{Time: readingTime, Battery: float(-1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, r.Record(ctx, "dev_upstairs", tt.reading), ErrInvalidReading)
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime.Add(time.Duration(i) * time.Hour), Temperature: float(20 + float64(i))}))
+	}
+
+	history, err := r.History(ctx, "dev_upstairs", readingTime.Add(time.Hour), readingTime.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 21.0, *history[0].Temperature)
+	assert.Equal(t, 22.0, *history[1].Temperature)
+
+	empty, err := r.History(ctx, "dev_upstairs", readingTime.Add(-48*time.Hour), readingTime.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+
+	_, err = r.History(ctx, "dev_upstairs", readingTime, readingTime)
+	assert.Error(t, err)
+	_, err = r.History(ctx, "dev_upstairs", readingTime.Add(-MaxHistoryRange-time.Hour), readingTime)
+	assert.Error(t, err)
+}
+
+func TestRegisterInvalidSensor(t *testing.T) {
+	r := NewRegistry(NewMemoryStore())
+	assert.Error(t, r.Register(context.Background(), Sensor{ID: "living room"}))
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRegistry(t)
+	updates, cancel := r.Subscribe()
+
+	require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime, Temperature: float(22.5)}))
+	assert.Error(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime}))
+
+	select {
+	case u := <-updates:
+		assert.Equal(t, "dev_upstairs", u.Sensor)
+		assert.Equal(t, 22.5, *u.Temperature)
+	default:
+		t.Fatal("Expected an update")
+	}
+	select {
+	case u := <-updates:
+		t.Fatalf("Invalid reading should not be sent, got %+v", u)
+	default:
+	}
+
+	cancel()
+	cancel()
+	_, open := <-updates
+	assert.False(t, open)
+	require.NoError(t, r.Record(ctx, "dev_upstairs", Reading{Time: readingTime.Add(time.Minute), Temperature: float(22.6)}))
+}
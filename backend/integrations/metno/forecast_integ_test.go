@@ -0,0 +1,15 @@
+//go:build integration
+
+package metno
+
+import "testing"
+
+func TestGetForecastIntegration(t *testing.T) {
+	forecast, err := GetForecast(60.2626, 25.0308)
+	if err != nil {
+		t.Fatalf("GetForecast failed: %v", err)
+	}
+	if len(forecast.Properties.Timeseries) == 0 {
+		t.Errorf("Expected timeseries, got none")
+	}
+}
@@ -0,0 +1,67 @@
+package metno
+
+import "time"
+
+// LocationforecastModel is the GeoJSON document returned by the
+// locationforecast/2.0/complete endpoint.
+type LocationforecastModel struct {
+	Properties ForecastProperties `json:"properties"`
+}
+
+type ForecastProperties struct {
+	Meta       ForecastMeta `json:"meta"`
+	Timeseries []TimeStep   `json:"timeseries"`
+}
+
+type ForecastMeta struct {
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TimeStep struct {
+	Time time.Time    `json:"time"`
+	Data TimeStepData `json:"data"`
+}
+
+type TimeStepData struct {
+	Instant     InstantData `json:"instant"`
+	Next1Hours  *PeriodData `json:"next_1_hours,omitempty"`
+	Next6Hours  *PeriodData `json:"next_6_hours,omitempty"`
+	Next12Hours *PeriodData `json:"next_12_hours,omitempty"`
+}
+
+type InstantData struct {
+	Details InstantDetails `json:"details"`
+}
+
+// InstantDetails uses pointers since the available parameters vary between
+// time steps and locations.
+type InstantDetails struct {
+	AirPressureAtSeaLevel *float64 `json:"air_pressure_at_sea_level"`
+	AirTemperature        *float64 `json:"air_temperature"`
+	CloudAreaFraction     *float64 `json:"cloud_area_fraction"`
+	DewPointTemperature   *float64 `json:"dew_point_temperature"`
+	FogAreaFraction       *float64 `json:"fog_area_fraction"`
+	RelativeHumidity      *float64 `json:"relative_humidity"`
+	UVIndexClearSky       *float64 `json:"ultraviolet_index_clear_sky"`
+	WindFromDirection     *float64 `json:"wind_from_direction"`
+	WindSpeed             *float64 `json:"wind_speed"`
+	WindSpeedOfGust       *float64 `json:"wind_speed_of_gust"`
+}
+
+type PeriodData struct {
+	Summary PeriodSummary `json:"summary"`
+	Details PeriodDetails `json:"details"`
+}
+
+type PeriodSummary struct {
+	SymbolCode string `json:"symbol_code"`
+}
+
+type PeriodDetails struct {
+	AirTemperatureMax          *float64 `json:"air_temperature_max"`
+	AirTemperatureMin          *float64 `json:"air_temperature_min"`
+	PrecipitationAmount        *float64 `json:"precipitation_amount"`
+	PrecipitationAmountMax     *float64 `json:"precipitation_amount_max"`
+	ProbabilityOfPrecipitation *float64 `json:"probability_of_precipitation"`
+	ProbabilityOfThunder       *float64 `json:"probability_of_thunder"`
+}
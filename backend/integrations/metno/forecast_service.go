@@ -0,0 +1,36 @@
+package metno
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type ForecastService struct {
+	client      HTTPClient
+	apiEndpoint string
+}
+
+func NewForecastService(client HTTPClient, apiEndpoint string) *ForecastService {
+	return &ForecastService{
+		client:      client,
+		apiEndpoint: apiEndpoint,
+	}
+}
+
+func (s *ForecastService) GetForecast(lat, lon float64) (*LocationforecastModel, error) {
+	body, err := s.client.Get(s.apiEndpoint, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var forecast LocationforecastModel
+	err = json.Unmarshal(body, &forecast)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling API response: %w", err)
+	}
+	if len(forecast.Properties.Timeseries) == 0 {
+		return nil, fmt.Errorf("no timeseries in locationforecast response")
+	}
+
+	return &forecast, nil
+}
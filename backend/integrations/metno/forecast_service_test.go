@@ -0,0 +1,48 @@
+package metno
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/metno/mock"
+)
+
+func TestGetForecast(t *testing.T) {
+	mockClient := mock.NewMockHTTPClient("testdata/exampleForecast.json")
+	service := NewForecastService(mockClient, "http://mock.api")
+
+	forecast, err := service.GetForecast(60.2626, 25.0308)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ts := forecast.Properties.Timeseries
+	if len(ts) != 6 {
+		t.Fatalf("Timeseries length, got %d, want %d", len(ts), 6)
+	}
+	if want := time.Date(2024, 11, 2, 18, 0, 0, 0, time.UTC); !ts[0].Time.Equal(want) {
+		t.Errorf("First time step, got %s, want %s", ts[0].Time, want)
+	}
+	if temp := ts[0].Data.Instant.Details.AirTemperature; temp == nil || *temp != 4.2 {
+		t.Errorf("First air temperature, got %v, want 4.2", temp)
+	}
+	if ts[0].Data.Next1Hours == nil || ts[0].Data.Next1Hours.Summary.SymbolCode != "cloudy" {
+		t.Errorf("First next_1_hours symbol, got %+v, want cloudy", ts[0].Data.Next1Hours)
+	}
+	if ts[5].Data.Next1Hours != nil {
+		t.Errorf("Last time step should only have a 6 hour period, got %+v", ts[5].Data.Next1Hours)
+	}
+	if ts[5].Data.Instant.Details.DewPointTemperature != nil {
+		t.Errorf("Last dew point should be missing, got %v", *ts[5].Data.Instant.Details.DewPointTemperature)
+	}
+}
+
+func TestGetForecast_Empty(t *testing.T) {
+	mockClient := mock.NewMockHTTPClient("testdata/exampleEmpty.json")
+	service := NewForecastService(mockClient, "http://mock.api")
+
+	_, err := service.GetForecast(60.2626, 25.0308)
+	if err == nil {
+		t.Fatal("Expected error for empty timeseries")
+	}
+}
@@ -0,0 +1,60 @@
+package metno
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// MET Norway requires an identifying User-Agent on every request.
+const userAgent = "gohome github.com/mikahozz/villa73"
+
+type HTTPClient interface {
+	Get(endpoint string, lat, lon float64) ([]byte, error)
+}
+
+type DefaultHTTPClient struct {
+	http *http.Client
+}
+
+func NewDefaultHTTPClient() *DefaultHTTPClient {
+	return &DefaultHTTPClient{http: &http.Client{}}
+}
+
+func (c *DefaultHTTPClient) Get(endpoint string, lat, lon float64) ([]byte, error) {
+	apiURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API endpoint: %w", err)
+	}
+
+	// The API asks clients to use at most four decimals to keep the cache efficient
+	params := url.Values{}
+	params.Add("lat", strconv.FormatFloat(lat, 'f', 4, 64))
+	params.Add("lon", strconv.FormatFloat(lon, 'f', 4, 64))
+	apiURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, apiURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,11 @@
+// Package metno fetches point forecasts from MET Norway's locationforecast API.
+// API reference: https://api.met.no/weatherapi/locationforecast/2.0/documentation
+package metno
+
+const (
+	APIEndpoint = "https://api.met.no/weatherapi/locationforecast/2.0/complete"
+)
+
+func GetForecast(lat, lon float64) (*LocationforecastModel, error) {
+	return NewForecastService(NewDefaultHTTPClient(), APIEndpoint).GetForecast(lat, lon)
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"os"
+)
+
+type MockHTTPClient struct {
+	GetFunc func(endpoint string, lat, lon float64) ([]byte, error)
+}
+
+func (m *MockHTTPClient) Get(endpoint string, lat, lon float64) ([]byte, error) {
+	return m.GetFunc(endpoint, lat, lon)
+}
+
+func NewMockHTTPClient(filename string) *MockHTTPClient {
+	return &MockHTTPClient{
+		GetFunc: func(endpoint string, lat, lon float64) ([]byte, error) {
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			return content, nil
+		},
+	}
+}
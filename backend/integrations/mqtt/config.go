@@ -0,0 +1,57 @@
+package mqtt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+)
+
+// LoadConfig reads the broker from the environment and the topic of each
+// sensor that publishes over MQTT:
+//
+//	MQTT_BROKER=tcp://localhost:1883
+//	MQTT_CLIENT_ID=gohome
+//	MQTT_USERNAME=
+//	MQTT_PASSWORD=
+//	INDOOR_SENSOR_DEV_UPSTAIRS_MQTT_TOPIC=zigbee2mqtt/Upstairs
+//	INDOOR_SENSOR_DEV_UPSTAIRS_MQTT_FORMAT=zigbee2mqtt
+//
+// The format defaults by the topic prefix, zigbee2mqtt/ or ruuvi/.
+func LoadConfig(sensors []indoor.Sensor) (Config, error) {
+	config := Config{
+		Broker:   os.Getenv("MQTT_BROKER"),
+		ClientID: os.Getenv("MQTT_CLIENT_ID"),
+		Username: os.Getenv("MQTT_USERNAME"),
+		Password: os.Getenv("MQTT_PASSWORD"),
+	}
+	for _, sensor := range sensors {
+		prefix := "INDOOR_SENSOR_" + strings.ToUpper(sensor.ID) + "_MQTT_"
+		topic := os.Getenv(prefix + "TOPIC")
+		if topic == "" {
+			continue
+		}
+		format := Format(os.Getenv(prefix + "FORMAT"))
+		if format == "" {
+			format = formatOf(topic)
+		}
+		switch format {
+		case FormatZigbee2MQTT, FormatRuuvi:
+		default:
+			return Config{}, fmt.Errorf("invalid %sFORMAT %q, use zigbee2mqtt or ruuvi", prefix, format)
+		}
+		config.Routes = append(config.Routes, Route{Topic: topic, Sensor: sensor.ID, Format: format})
+	}
+	return config, nil
+}
+
+func formatOf(topic string) Format {
+	switch {
+	case strings.HasPrefix(topic, "zigbee2mqtt/"):
+		return FormatZigbee2MQTT
+	case strings.HasPrefix(topic, "ruuvi/"):
+		return FormatRuuvi
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("MQTT_BROKER", "tcp://broker:1883")
+	t.Setenv("INDOOR_SENSOR_DEV_UPSTAIRS_MQTT_TOPIC", "zigbee2mqtt/Upstairs")
+	t.Setenv("INDOOR_SENSOR_SHELLY_MQTT_TOPIC", "ruuvi/+/D1:2B:3C:4D:5E:6F")
+	t.Setenv("INDOOR_SENSOR_CABIN_MQTT_TOPIC", "home/cabin/climate")
+	t.Setenv("INDOOR_SENSOR_CABIN_MQTT_FORMAT", "zigbee2mqtt")
+
+	config, err := LoadConfig([]indoor.Sensor{{ID: "dev_upstairs"}, {ID: "Shelly"}, {ID: "cabin"}, {ID: "attic"}})
+	require.NoError(t, err)
+	assert.True(t, config.Enabled())
+	assert.Equal(t, []Route{
+		{Topic: "zigbee2mqtt/Upstairs", Sensor: "dev_upstairs", Format: FormatZigbee2MQTT},
+		{Topic: "ruuvi/+/D1:2B:3C:4D:5E:6F", Sensor: "Shelly", Format: FormatRuuvi},
+		{Topic: "home/cabin/climate", Sensor: "cabin", Format: FormatZigbee2MQTT},
+	}, config.Routes)
+}
+
+func TestLoadConfigUnknownFormat(t *testing.T) {
+	t.Setenv("INDOOR_SENSOR_CABIN_MQTT_TOPIC", "home/cabin/climate")
+	_, err := LoadConfig([]indoor.Sensor{{ID: "cabin"}})
+	assert.Error(t, err)
+}
+
+func TestLoadConfigDisabled(t *testing.T) {
+	t.Setenv("MQTT_BROKER", "")
+	config, err := LoadConfig(nil)
+	require.NoError(t, err)
+	assert.False(t, config.Enabled())
+}
@@ -0,0 +1,53 @@
+// Package mqtt subscribes to the local MQTT broker and records the readings
+// of indoor sensors published by Zigbee2MQTT and the Ruuvi gateway.
+package mqtt
+
+import (
+	"context"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+)
+
+// Format is the payload format published on a topic.
+type Format string
+
+const (
+	FormatZigbee2MQTT Format = "zigbee2mqtt"
+	FormatRuuvi       Format = "ruuvi"
+)
+
+// Route maps a topic to a named sensor. The topic may contain MQTT
+// wildcards.
+type Route struct {
+	Topic  string
+	Sensor string
+	Format Format
+}
+
+// Config of the subscriber. Zero durations use the defaults.
+type Config struct {
+	Broker               string // e.g. tcp://localhost:1883
+	ClientID             string
+	Username             string
+	Password             string
+	Routes               []Route
+	ConnectRetryInterval time.Duration // between attempts before the first connect
+	MaxReconnectInterval time.Duration // backoff cap after the connection is lost
+}
+
+const (
+	DefaultClientID             = "gohome"
+	DefaultConnectRetryInterval = 10 * time.Second
+	DefaultMaxReconnectInterval = time.Minute
+)
+
+// Enabled reports whether there is a broker and something to subscribe to.
+func (c Config) Enabled() bool {
+	return c.Broker != "" && len(c.Routes) > 0
+}
+
+// Recorder stores readings, implemented by indoor.Registry.
+type Recorder interface {
+	Record(ctx context.Context, sensorID string, reading indoor.Reading) error
+}
@@ -0,0 +1,170 @@
+package mqtt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+)
+
+// ErrNoValues is returned for payloads without any climate values, such as
+// Zigbee2MQTT availability messages.
+var ErrNoValues = errors.New("no climate values in payload")
+
+// Parse converts a payload to a reading. Payloads without their own time are
+// read at now.
+func Parse(format Format, payload []byte, now time.Time) (indoor.Reading, error) {
+	var (
+		reading indoor.Reading
+		err     error
+	)
+	switch format {
+	case FormatZigbee2MQTT:
+		reading, err = parseZigbee2MQTT(payload, now)
+	case FormatRuuvi:
+		reading, err = parseRuuvi(payload, now)
+	default:
+		return reading, fmt.Errorf("unknown payload format %q", format)
+	}
+	if err != nil {
+		return reading, err
+	}
+	if reading.Temperature == nil && reading.Humidity == nil && reading.Battery == nil {
+		return reading, ErrNoValues
+	}
+	return reading, nil
+}
+
+// zigbee2MQTTPayload is the device state Zigbee2MQTT publishes on
+// zigbee2mqtt/<friendly name>. Battery is in percent.
+type zigbee2MQTTPayload struct {
+	Temperature *float64        `json:"temperature"`
+	Humidity    *float64        `json:"humidity"`
+	Battery     *float64        `json:"battery"`
+	LastSeen    json.RawMessage `json:"last_seen"` // ISO 8601 or epoch milliseconds, when enabled
+}
+
+func parseZigbee2MQTT(payload []byte, now time.Time) (indoor.Reading, error) {
+	var p zigbee2MQTTPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return indoor.Reading{}, fmt.Errorf("parse Zigbee2MQTT payload: %w", err)
+	}
+	reading := indoor.Reading{
+		Time:        now,
+		Temperature: p.Temperature,
+		Humidity:    p.Humidity,
+		Battery:     p.Battery,
+	}
+	if t, ok := parseTime(p.LastSeen, time.Millisecond); ok {
+		reading.Time = t
+	}
+	return reading, nil
+}
+
+// ruuviPayload is a tag's advertisement relayed by the Ruuvi gateway on
+// ruuvi/<gateway mac>/<tag mac>. The gateway sends the raw advertisement in
+// data, decoded values only when decoding is enabled.
+type ruuviPayload struct {
+	Data           string          `json:"data"`
+	Timestamp      json.RawMessage `json:"ts"` // epoch seconds, as a string from the gateway
+	Temperature    *float64        `json:"temperature"`
+	Humidity       *float64        `json:"humidity"`
+	BatteryVoltage *float64        `json:"batteryVoltage"` // V
+}
+
+func parseRuuvi(payload []byte, now time.Time) (indoor.Reading, error) {
+	var p ruuviPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return indoor.Reading{}, fmt.Errorf("parse Ruuvi payload: %w", err)
+	}
+	reading := indoor.Reading{
+		Time:        now,
+		Temperature: p.Temperature,
+		Humidity:    p.Humidity,
+	}
+	if p.BatteryVoltage != nil {
+		reading.Battery = batteryPercent(*p.BatteryVoltage * 1000)
+	}
+	if t, ok := parseTime(p.Timestamp, time.Second); ok {
+		reading.Time = t
+	}
+	if reading.Temperature == nil && p.Data != "" {
+		raw, err := decodeRAWv2(p.Data)
+		if err != nil {
+			return reading, err
+		}
+		reading.Temperature, reading.Humidity, reading.Battery = raw.Temperature, raw.Humidity, raw.Battery
+	}
+	return reading, nil
+}
+
+// ruuviManufacturerData starts the manufacturer specific data of a Ruuvi
+// advertisement: length-prefixed type 0xFF and company id 0x0499.
+var ruuviManufacturerData = []byte{0xFF, 0x99, 0x04}
+
+// decodeRAWv2 decodes data format 5 from a hex encoded advertisement.
+// https://docs.ruuvi.com/communication/bluetooth-advertisements/data-format-5-rawv2
+func decodeRAWv2(data string) (indoor.Reading, error) {
+	var reading indoor.Reading
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return reading, fmt.Errorf("invalid Ruuvi data %q: %w", data, err)
+	}
+	if i := bytes.Index(b, ruuviManufacturerData); i >= 0 {
+		b = b[i+len(ruuviManufacturerData):]
+	}
+	if len(b) < 15 || b[0] != 5 {
+		return reading, fmt.Errorf("unsupported Ruuvi data %q, only format 5 is supported", data)
+	}
+	if t := int16(binary.BigEndian.Uint16(b[1:3])); t != math.MinInt16 {
+		reading.Temperature = round(float64(t)*0.005, 2)
+	}
+	if h := binary.BigEndian.Uint16(b[3:5]); h != math.MaxUint16 {
+		reading.Humidity = round(float64(h)*0.0025, 2)
+	}
+	if v := binary.BigEndian.Uint16(b[13:15]) >> 5; v != 2047 {
+		reading.Battery = batteryPercent(float64(v) + 1600)
+	}
+	return reading, nil
+}
+
+// batteryPercent estimates the charge of the CR2477 cell in a Ruuvi tag,
+// linear from 2.5 V (empty) to 3.0 V (full).
+func batteryPercent(millivolts float64) *float64 {
+	p := math.Max(0, math.Min(100, math.Round((millivolts-2500)/5)))
+	return &p
+}
+
+func round(v float64, decimals int) *float64 {
+	pow := math.Pow(10, float64(decimals))
+	r := math.Round(v*pow) / pow
+	return &r
+}
+
+// parseTime reads an RFC 3339 time or an epoch number in unit, also as a
+// string.
+func parseTime(raw json.RawMessage, unit time.Duration) (time.Time, bool) {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "null" {
+		return time.Time{}, false
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n <= 0 {
+			return time.Time{}, false
+		}
+		return time.Unix(0, n*int64(unit)), true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,76 @@
+package mqtt
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var receivedAt = time.Date(2024, 11, 2, 18, 5, 0, 0, time.UTC)
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseZigbee2MQTT(t *testing.T) {
+	r, err := Parse(FormatZigbee2MQTT, readFixture(t, "zigbee2mqtt.json"), receivedAt)
+	require.NoError(t, err)
+	assert.True(t, r.Time.Equal(time.Date(2024, 11, 2, 18, 0, 0, 0, time.UTC)), "last_seen, got %s", r.Time)
+	assert.Equal(t, 22.5, *r.Temperature)
+	assert.Equal(t, 27.4, *r.Humidity)
+	assert.Equal(t, 87.0, *r.Battery)
+
+	r, err = Parse(FormatZigbee2MQTT, []byte(`{"temperature":21,"last_seen":1730570400000}`), receivedAt)
+	require.NoError(t, err)
+	assert.True(t, r.Time.Equal(time.Unix(1730570400, 0)), "epoch last_seen, got %s", r.Time)
+	assert.Nil(t, r.Humidity)
+
+	r, err = Parse(FormatZigbee2MQTT, []byte(`{"humidity":40}`), receivedAt)
+	require.NoError(t, err)
+	assert.Equal(t, receivedAt, r.Time)
+}
+
+func TestParseRuuviRaw(t *testing.T) {
+	// The valid data test vector of the data format 5 specification
+	r, err := Parse(FormatRuuvi, readFixture(t, "ruuviGateway.json"), receivedAt)
+	require.NoError(t, err)
+	assert.True(t, r.Time.Equal(time.Unix(1730570400, 0)))
+	assert.Equal(t, 24.3, *r.Temperature)
+	assert.Equal(t, 53.49, *r.Humidity)
+	assert.Equal(t, 95.0, *r.Battery) // 2977 mV
+}
+
+func TestParseRuuviDecoded(t *testing.T) {
+	r, err := Parse(FormatRuuvi, readFixture(t, "ruuviDecoded.json"), receivedAt)
+	require.NoError(t, err)
+	assert.True(t, r.Time.Equal(time.Unix(1730570400, 0)))
+	assert.Equal(t, 19.85, *r.Temperature)
+	assert.Equal(t, 41.2, *r.Humidity)
+	assert.Equal(t, 60.0, *r.Battery)
+}
+
+func TestParseRuuviInvalidValues(t *testing.T) {
+	// The invalid values test vector: every value is marked not available
+	r, err := Parse(FormatRuuvi, []byte(`{"data":"058000FFFFFFFF800080008000FFFFFFFFFFFFFFFFFFFFFF"}`), receivedAt)
+	assert.ErrorIs(t, err, ErrNoValues)
+	assert.Nil(t, r.Temperature)
+
+	_, err = Parse(FormatRuuvi, []byte(`{"data":"0201061BFF990403"}`), receivedAt)
+	assert.Error(t, err)
+	_, err = Parse(FormatRuuvi, []byte(`{"data":"zz"}`), receivedAt)
+	assert.Error(t, err)
+}
+
+func TestParseNoValues(t *testing.T) {
+	_, err := Parse(FormatZigbee2MQTT, readFixture(t, "zigbee2mqttAvailability.json"), receivedAt)
+	assert.ErrorIs(t, err, ErrNoValues)
+	_, err = Parse(FormatZigbee2MQTT, []byte(`online`), receivedAt)
+	assert.Error(t, err)
+	_, err = Parse("homie", []byte(`{}`), receivedAt)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/rs/zerolog/log"
+)
+
+// recordTimeout limits storing one reading.
+const recordTimeout = 5 * time.Second
+
+// Subscriber records the readings published on the routed topics.
+type Subscriber struct {
+	config   Config
+	recorder Recorder
+	client   paho.Client
+	now      func() time.Time
+}
+
+func NewSubscriber(config Config, recorder Recorder) *Subscriber {
+	if config.ClientID == "" {
+		config.ClientID = DefaultClientID
+	}
+	if config.ConnectRetryInterval == 0 {
+		config.ConnectRetryInterval = DefaultConnectRetryInterval
+	}
+	if config.MaxReconnectInterval == 0 {
+		config.MaxReconnectInterval = DefaultMaxReconnectInterval
+	}
+	return &Subscriber{config: config, recorder: recorder, now: time.Now}
+}
+
+// Start connects to the broker in the background. The connection is retried
+// until it succeeds and restored whenever it is lost. Subscriptions are made
+// again on every connect, so they survive broker restarts.
+func (s *Subscriber) Start() {
+	opts := paho.NewClientOptions().
+		AddBroker(s.config.Broker).
+		SetClientID(s.config.ClientID).
+		SetUsername(s.config.Username).
+		SetPassword(s.config.Password).
+		SetCleanSession(true).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(s.config.ConnectRetryInterval).
+		SetMaxReconnectInterval(s.config.MaxReconnectInterval).
+		SetOnConnectHandler(s.subscribe).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			log.Warn().Err(err).Str("event", "mqtt_connection_lost").Str("broker", s.config.Broker).Msg("reconnecting")
+		}).
+		SetReconnectingHandler(func(_ paho.Client, _ *paho.ClientOptions) {
+			log.Debug().Str("event", "mqtt_reconnecting").Str("broker", s.config.Broker).Msg("")
+		})
+	s.client = paho.NewClient(opts)
+	s.client.Connect()
+	log.Info().Str("event", "mqtt_start").Str("broker", s.config.Broker).Int("routes", len(s.config.Routes)).Msg("mqtt subscriber started")
+}
+
+// Stop disconnects from the broker.
+func (s *Subscriber) Stop() {
+	if s.client != nil {
+		s.client.Disconnect(250)
+	}
+}
+
+// subscribe is called by the client in its own goroutine after each connect.
+func (s *Subscriber) subscribe(c paho.Client) {
+	log.Info().Str("event", "mqtt_connected").Str("broker", s.config.Broker).Msg("subscribing")
+	for _, route := range s.config.Routes {
+		token := c.Subscribe(route.Topic, 1, s.handler(route))
+		if !token.WaitTimeout(10*time.Second) || token.Error() != nil {
+			log.Error().Err(token.Error()).Str("event", "mqtt_subscribe_failed").Str("topic", route.Topic).Msg("")
+		}
+	}
+}
+
+func (s *Subscriber) handler(route Route) paho.MessageHandler {
+	return func(_ paho.Client, msg paho.Message) {
+		s.handle(route, msg.Topic(), msg.Payload())
+	}
+}
+
+func (s *Subscriber) handle(route Route, topic string, payload []byte) {
+	reading, err := Parse(route.Format, payload, s.now())
+	if errors.Is(err, ErrNoValues) {
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("event", "mqtt_payload_invalid").Str("topic", topic).Msg("")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+	defer cancel()
+	if err := s.recorder.Record(ctx, route.Sensor, reading); err != nil {
+		log.Error().Err(err).Str("event", "mqtt_record_failed").Str("topic", topic).Str("sensor", route.Sensor).Msg("")
+	}
+}
@@ -0,0 +1,123 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/indoor"
+	broker "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBroker runs an in-process broker on addr. Publishing goes through
+// the broker's inline client.
+func startBroker(t *testing.T, addr string) *broker.Server {
+	server := broker.New(&broker.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(t, server.AddHook(new(auth.AllowHook), nil))
+	require.NoError(t, server.AddListener(listeners.NewTCP(listeners.Config{ID: "tcp", Address: addr})))
+	require.NoError(t, server.Serve())
+	return server
+}
+
+func freeAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func newTestSubscriber(t *testing.T, addr string) (*Subscriber, *indoor.Registry) {
+	registry := indoor.NewRegistry(indoor.NewMemoryStore())
+	require.NoError(t, registry.Register(context.Background(),
+		indoor.Sensor{ID: "dev_upstairs"},
+		indoor.Sensor{ID: "balcony"},
+	))
+	s := NewSubscriber(Config{
+		Broker: "tcp://" + addr,
+		Routes: []Route{
+			{Topic: "zigbee2mqtt/Upstairs", Sensor: "dev_upstairs", Format: FormatZigbee2MQTT},
+			{Topic: "ruuvi/+/D1:2B:3C:4D:5E:6F", Sensor: "balcony", Format: FormatRuuvi},
+		},
+		ConnectRetryInterval: 100 * time.Millisecond,
+		MaxReconnectInterval: 200 * time.Millisecond,
+	}, registry)
+	s.Start()
+	t.Cleanup(s.Stop)
+	return s, registry
+}
+
+// eventuallyRecorded publishes until the subscriber has recorded the wanted
+// temperature for the sensor, as subscribing happens in the background.
+func eventuallyRecorded(t *testing.T, server *broker.Server, registry *indoor.Registry, topic, sensor string, payload string, want float64) {
+	require.Eventually(t, func() bool {
+		if err := server.Publish(topic, []byte(payload), false, 1); err != nil {
+			return false
+		}
+		time.Sleep(20 * time.Millisecond)
+		r, err := registry.Latest(context.Background(), sensor)
+		return err == nil && r.Temperature != nil && *r.Temperature == want
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestSubscriberRecordsReadings(t *testing.T) {
+	addr := freeAddr(t)
+	server := startBroker(t, addr)
+	defer server.Close()
+	_, registry := newTestSubscriber(t, addr)
+	updates, cancel := registry.Subscribe()
+	defer cancel()
+
+	eventuallyRecorded(t, server, registry, "zigbee2mqtt/Upstairs", "dev_upstairs", `{"temperature":22.5,"humidity":27.4,"battery":90}`, 22.5)
+	eventuallyRecorded(t, server, registry, "ruuvi/C8:25:2D:8E:9C:2C/D1:2B:3C:4D:5E:6F", "balcony", string(readFixture(t, "ruuviGateway.json")), 24.3)
+
+	// Readings also reach the live stream
+	select {
+	case u := <-updates:
+		assert.Equal(t, "dev_upstairs", u.Sensor)
+	case <-time.After(time.Second):
+		t.Fatal("Expected an update on the stream")
+	}
+
+	// Other topics and availability messages are ignored
+	require.NoError(t, server.Publish("zigbee2mqtt/Upstairs/availability", []byte(`{"state":"offline"}`), false, 1))
+	require.NoError(t, server.Publish("zigbee2mqtt/Upstairs", []byte(`{"state":"online"}`), false, 1))
+	time.Sleep(100 * time.Millisecond)
+	r, err := registry.Latest(context.Background(), "dev_upstairs")
+	require.NoError(t, err)
+	assert.Equal(t, 22.5, *r.Temperature)
+}
+
+func TestSubscriberReconnects(t *testing.T) {
+	addr := freeAddr(t)
+	server := startBroker(t, addr)
+	_, registry := newTestSubscriber(t, addr)
+	eventuallyRecorded(t, server, registry, "zigbee2mqtt/Upstairs", "dev_upstairs", `{"temperature":21.0}`, 21.0)
+
+	// Restart the broker, it forgets the subscriptions
+	require.NoError(t, server.Close())
+	server = startBroker(t, addr)
+	defer server.Close()
+
+	eventuallyRecorded(t, server, registry, "zigbee2mqtt/Upstairs", "dev_upstairs", `{"temperature":21.5}`, 21.5)
+}
+
+func TestSubscriberRetriesFirstConnect(t *testing.T) {
+	addr := freeAddr(t)
+	// The broker is not up yet when the subscriber starts
+	_, registry := newTestSubscriber(t, addr)
+	time.Sleep(300 * time.Millisecond)
+	server := startBroker(t, addr)
+	defer server.Close()
+
+	eventuallyRecorded(t, server, registry, "zigbee2mqtt/Upstairs", "dev_upstairs", `{"temperature":20.5}`, 20.5)
+}
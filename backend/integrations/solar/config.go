@@ -0,0 +1,107 @@
+package solar
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config of the devices read.
+type Config struct {
+	Address      string // Modbus TCP host:port of the inverter
+	InverterUnit byte
+	MeterUnit    byte // 0 without a grid meter
+	PollInterval time.Duration
+}
+
+// Enabled reports whether there is an inverter to read.
+func (c Config) Enabled() bool {
+	return c.Address != ""
+}
+
+// MaxAge is how old the latest sample may be to still be current.
+func (c Config) MaxAge() time.Duration {
+	return max(5*time.Minute, 3*c.PollInterval)
+}
+
+// LoadConfig reads the inverter from the environment:
+//
+//	SOLAR_MODBUS_ADDRESS=192.168.1.50:502
+//	SOLAR_INVERTER_UNIT=1
+//	SOLAR_METER_UNIT=240
+//	SOLAR_POLL_INTERVAL=30s
+//
+// The inverter unit defaults to 1. Without SOLAR_METER_UNIT there is no grid
+// meter and self-consumption is not known.
+func LoadConfig() (Config, error) {
+	config := Config{
+		Address:      os.Getenv("SOLAR_MODBUS_ADDRESS"),
+		InverterUnit: 1,
+		PollInterval: DefaultPollInterval,
+	}
+	var err error
+	if unit := os.Getenv("SOLAR_INVERTER_UNIT"); unit != "" {
+		if config.InverterUnit, err = parseUnit(unit); err != nil {
+			return Config{}, fmt.Errorf("invalid SOLAR_INVERTER_UNIT: %w", err)
+		}
+	}
+	if unit := os.Getenv("SOLAR_METER_UNIT"); unit != "" {
+		if config.MeterUnit, err = parseUnit(unit); err != nil {
+			return Config{}, fmt.Errorf("invalid SOLAR_METER_UNIT: %w", err)
+		}
+	}
+	if interval := os.Getenv("SOLAR_POLL_INTERVAL"); interval != "" {
+		config.PollInterval, err = time.ParseDuration(interval)
+		if err != nil || config.PollInterval < time.Second {
+			return Config{}, fmt.Errorf("invalid SOLAR_POLL_INTERVAL %q, use e.g. 30s", interval)
+		}
+	}
+	return config, nil
+}
+
+func parseUnit(s string) (byte, error) {
+	unit, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || unit == 0 || unit > 247 {
+		return 0, fmt.Errorf("unit %q not in 1-247", s)
+	}
+	return byte(unit), nil
+}
+
+// LoadSurplus reads the surplus that switches a load on from the
+// environment:
+//
+//	SOLAR_SURPLUS_ON_W=1500
+//	SOLAR_SURPLUS_ON_FOR=10m
+//	SOLAR_SURPLUS_OFF_W=-200
+//	SOLAR_SURPLUS_OFF_FOR=5m
+//
+// Returns nil without SOLAR_SURPLUS_ON_W. The off threshold defaults to 0 W
+// and the durations to 10 and 5 minutes.
+func LoadSurplus(source HistorySource) (*Surplus, error) {
+	on := os.Getenv("SOLAR_SURPLUS_ON_W")
+	if on == "" {
+		return nil, nil
+	}
+	surplus := &Surplus{Source: source, OnFor: 10 * time.Minute, OffFor: 5 * time.Minute}
+	var err error
+	if surplus.OnAboveW, err = strconv.ParseFloat(on, 64); err != nil {
+		return nil, fmt.Errorf("invalid SOLAR_SURPLUS_ON_W %q", on)
+	}
+	if off := os.Getenv("SOLAR_SURPLUS_OFF_W"); off != "" {
+		if surplus.OffBelowW, err = strconv.ParseFloat(off, 64); err != nil {
+			return nil, fmt.Errorf("invalid SOLAR_SURPLUS_OFF_W %q", off)
+		}
+	}
+	if surplus.OffBelowW >= surplus.OnAboveW {
+		return nil, fmt.Errorf("SOLAR_SURPLUS_OFF_W must be below SOLAR_SURPLUS_ON_W")
+	}
+	for key, d := range map[string]*time.Duration{"SOLAR_SURPLUS_ON_FOR": &surplus.OnFor, "SOLAR_SURPLUS_OFF_FOR": &surplus.OffFor} {
+		if v := os.Getenv(key); v != "" {
+			if *d, err = time.ParseDuration(v); err != nil || *d < time.Minute {
+				return nil, fmt.Errorf("invalid %s %q, use e.g. 10m", key, v)
+			}
+		}
+	}
+	return surplus, nil
+}
@@ -0,0 +1,33 @@
+package solar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SOLAR_MODBUS_ADDRESS", "")
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	assert.False(t, config.Enabled())
+
+	t.Setenv("SOLAR_MODBUS_ADDRESS", "192.168.1.50:502")
+	t.Setenv("SOLAR_METER_UNIT", "240")
+	t.Setenv("SOLAR_POLL_INTERVAL", "10s")
+	config, err = LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, Config{Address: "192.168.1.50:502", InverterUnit: 1, MeterUnit: 240, PollInterval: 10 * time.Second}, config)
+	assert.Equal(t, 5*time.Minute, config.MaxAge())
+
+	t.Setenv("SOLAR_METER_UNIT", "0")
+	_, err = LoadConfig()
+	assert.Error(t, err)
+
+	t.Setenv("SOLAR_METER_UNIT", "")
+	t.Setenv("SOLAR_POLL_INTERVAL", "30")
+	_, err = LoadConfig()
+	assert.Error(t, err)
+}
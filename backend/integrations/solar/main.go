@@ -0,0 +1,77 @@
+// Package solar reads the solar inverter and the grid meter over Modbus TCP
+// using the SunSpec information models, records the production and reports
+// how much of it was used at home against the spot price.
+package solar
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoSamples = errors.New("no solar samples")
+	ErrStale     = errors.New("latest solar sample is stale")
+)
+
+// MaxHistoryRange is the longest range of raw samples served at once.
+const MaxHistoryRange = 7 * 24 * time.Hour
+
+// Sample is a reading of the inverter and, when there is one, the grid meter.
+// Power is in watts and energy counters in watt-hours. Grid power is positive
+// when importing and negative when exporting.
+type Sample struct {
+	Time       time.Time `json:"time"`
+	PowerW     float64   `json:"powerw"`
+	EnergyWh   *float64  `json:"energywh,omitempty"` // Lifetime production
+	GridW      *float64  `json:"gridw,omitempty"`
+	ExportedWh *float64  `json:"exportedwh,omitempty"` // Lifetime export to the grid
+	ImportedWh *float64  `json:"importedwh,omitempty"` // Lifetime import from the grid
+	Status     Status    `json:"status,omitempty"`
+}
+
+// ConsumptionW returns the power used at home, known only with a grid meter.
+func (s Sample) ConsumptionW() *float64 {
+	if s.GridW == nil {
+		return nil
+	}
+	c := s.PowerW + *s.GridW
+	return &c
+}
+
+// ExportW returns the power exported to the grid, zero when importing.
+func (s Sample) ExportW() *float64 {
+	if s.GridW == nil {
+		return nil
+	}
+	e := max(-*s.GridW, 0)
+	return &e
+}
+
+// Status is the operating state of the inverter, SunSpec St.
+type Status string
+
+const (
+	StatusOff          Status = "off"
+	StatusSleeping     Status = "sleeping"
+	StatusStarting     Status = "starting"
+	StatusProducing    Status = "producing"
+	StatusThrottled    Status = "throttled"
+	StatusShuttingDown Status = "shutting_down"
+	StatusFault        Status = "fault"
+	StatusStandby      Status = "standby"
+)
+
+// Reader reads a sample from the devices.
+type Reader interface {
+	Read(ctx context.Context) (Sample, error)
+}
+
+// Store persists samples.
+type Store interface {
+	Save(ctx context.Context, sample Sample) error
+	// Latest returns ErrNoSamples when nothing has been recorded.
+	Latest(ctx context.Context) (Sample, error)
+	// History returns the samples in [from, to) oldest first.
+	History(ctx context.Context, from, to time.Time) ([]Sample, error)
+}
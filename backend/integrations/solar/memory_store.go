@@ -0,0 +1,54 @@
+package solar
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps samples in memory, for tests and for running without a
+// database.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	samples []Sample // oldest first
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) Save(ctx context.Context, sample Sample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := sort.Search(len(m.samples), func(i int) bool { return !m.samples[i].Time.Before(sample.Time) })
+	if i < len(m.samples) && m.samples[i].Time.Equal(sample.Time) {
+		m.samples[i] = sample
+		return nil
+	}
+	m.samples = append(m.samples, Sample{})
+	copy(m.samples[i+1:], m.samples[i:])
+	m.samples[i] = sample
+	return nil
+}
+
+func (m *MemoryStore) Latest(ctx context.Context) (Sample, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.samples) == 0 {
+		return Sample{}, ErrNoSamples
+	}
+	return m.samples[len(m.samples)-1], nil
+}
+
+func (m *MemoryStore) History(ctx context.Context, from, to time.Time) ([]Sample, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	history := []Sample{}
+	for _, s := range m.samples {
+		if !s.Time.Before(from) && s.Time.Before(to) {
+			history = append(history, s)
+		}
+	}
+	return history, nil
+}
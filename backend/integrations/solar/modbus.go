@@ -0,0 +1,148 @@
+package solar
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	funcReadHoldingRegisters = 0x03
+	// maxRegisters is the most registers one read may return.
+	maxRegisters = 125
+	// DefaultModbusTimeout limits connecting and each request.
+	DefaultModbusTimeout = 5 * time.Second
+)
+
+// ModbusError is an exception returned by the device.
+type ModbusError struct {
+	Function  byte
+	Exception byte
+}
+
+func (e *ModbusError) Error() string {
+	return fmt.Sprintf("modbus exception %d for function %d", e.Exception, e.Function)
+}
+
+// ModbusClient reads holding registers over Modbus TCP. The connection is
+// opened on first use and again after any error, so a device that sleeps at
+// night is picked up again in the morning.
+type ModbusClient struct {
+	address string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	tid  uint16
+}
+
+func NewModbusClient(address string, timeout time.Duration) *ModbusClient {
+	if timeout == 0 {
+		timeout = DefaultModbusTimeout
+	}
+	return &ModbusClient{address: address, timeout: timeout}
+}
+
+// ReadHoldingRegisters reads count registers of the unit starting at the
+// zero-based address, reading in chunks if needed.
+func (c *ModbusClient) ReadHoldingRegisters(ctx context.Context, unit byte, address, count uint16) ([]uint16, error) {
+	registers := make([]uint16, 0, count)
+	for count > 0 {
+		n := min(count, maxRegisters)
+		chunk, err := c.read(ctx, unit, address, n)
+		if err != nil {
+			return nil, err
+		}
+		registers = append(registers, chunk...)
+		address += n
+		count -= n
+	}
+	return registers, nil
+}
+
+func (c *ModbusClient) read(ctx context.Context, unit byte, address, count uint16) ([]uint16, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		dialer := net.Dialer{Timeout: c.timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", c.address)
+		if err != nil {
+			return nil, fmt.Errorf("connect to %s: %w", c.address, err)
+		}
+		c.conn = conn
+	}
+	registers, err := c.request(ctx, unit, address, count)
+	var modbusErr *ModbusError
+	if err != nil && !errors.As(err, &modbusErr) {
+		// The connection is in an unknown state
+		c.conn.Close()
+		c.conn = nil
+	}
+	return registers, err
+}
+
+func (c *ModbusClient) request(ctx context.Context, unit byte, address, count uint16) ([]uint16, error) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+
+	c.tid++
+	// MBAP header followed by the PDU
+	req := make([]byte, 12)
+	binary.BigEndian.PutUint16(req[0:], c.tid)
+	binary.BigEndian.PutUint16(req[2:], 0) // Modbus protocol
+	binary.BigEndian.PutUint16(req[4:], 6) // unit and PDU
+	req[6] = unit
+	req[7] = funcReadHoldingRegisters
+	binary.BigEndian.PutUint16(req[8:], address)
+	binary.BigEndian.PutUint16(req[10:], count)
+	if _, err := c.conn.Write(req); err != nil {
+		return nil, fmt.Errorf("modbus write: %w", err)
+	}
+
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(c.conn, header); err != nil {
+		return nil, fmt.Errorf("modbus read: %w", err)
+	}
+	length := binary.BigEndian.Uint16(header[4:])
+	if length < 2 || length > 260 {
+		return nil, fmt.Errorf("modbus response length %d", length)
+	}
+	pdu := make([]byte, length-1)
+	if _, err := io.ReadFull(c.conn, pdu); err != nil {
+		return nil, fmt.Errorf("modbus read: %w", err)
+	}
+	if tid := binary.BigEndian.Uint16(header[0:]); tid != c.tid {
+		return nil, fmt.Errorf("modbus transaction %d, expected %d", tid, c.tid)
+	}
+	if pdu[0] == funcReadHoldingRegisters|0x80 {
+		return nil, &ModbusError{Function: funcReadHoldingRegisters, Exception: pdu[1]}
+	}
+	if pdu[0] != funcReadHoldingRegisters || int(pdu[1]) != 2*int(count) || len(pdu) != 2+2*int(count) {
+		return nil, fmt.Errorf("unexpected modbus response % x", pdu)
+	}
+	registers := make([]uint16, count)
+	for i := range registers {
+		registers[i] = binary.BigEndian.Uint16(pdu[2+2*i:])
+	}
+	return registers, nil
+}
+
+// Close closes the connection, a later read opens it again.
+func (c *ModbusClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
@@ -0,0 +1,62 @@
+package solar
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultPollInterval is how often the devices are read by default.
+const DefaultPollInterval = 30 * time.Second
+
+// Poller reads the devices at an interval and records the samples.
+type Poller struct {
+	reader   Reader
+	store    Store
+	interval time.Duration
+	failing  bool
+}
+
+func NewPoller(reader Reader, store Store, interval time.Duration) *Poller {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	return &Poller{reader: reader, store: store, interval: interval}
+}
+
+// Run polls until the context is done. The inverter may not answer at night,
+// so failures are logged as warnings only when they start and end.
+func (p *Poller) Run(ctx context.Context) {
+	log.Info().Str("event", "solar_poll_start").Dur("interval", p.interval).Msg("solar poller started")
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll reads and records one sample.
+func (p *Poller) Poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+	sample, err := p.reader.Read(ctx)
+	if err == nil {
+		err = p.store.Save(ctx, sample)
+	}
+	switch {
+	case err != nil && !p.failing:
+		p.failing = true
+		log.Warn().Err(err).Str("event", "solar_poll_failed").Msg("retrying every interval")
+	case err != nil:
+		log.Debug().Err(err).Str("event", "solar_poll_failed").Msg("")
+	case p.failing:
+		p.failing = false
+		log.Info().Str("event", "solar_poll_recovered").Float64("powerw", sample.PowerW).Msg("")
+	}
+}
@@ -0,0 +1,111 @@
+package solar
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SensorID identifies the solar samples in the measurements table.
+const SensorID = "solar"
+
+// PostgresStore keeps the samples in measurements with the production power
+// as the main value.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// sampleValue is the JSON stored in measurements.value.
+type sampleValue struct {
+	PowerW     float64  `json:"powerw"`
+	EnergyWh   *float64 `json:"energywh,omitempty"`
+	GridW      *float64 `json:"gridw,omitempty"`
+	ExportedWh *float64 `json:"exportedwh,omitempty"`
+	ImportedWh *float64 `json:"importedwh,omitempty"`
+	Status     Status   `json:"status,omitempty"`
+}
+
+func (p *PostgresStore) Save(ctx context.Context, sample Sample) error {
+	value, err := json.Marshal(sampleValue{
+		PowerW:     sample.PowerW,
+		EnergyWh:   sample.EnergyWh,
+		GridW:      sample.GridW,
+		ExportedWh: sample.ExportedWh,
+		ImportedWh: sample.ImportedWh,
+		Status:     sample.Status,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO measurements (timestamp, sensor_id, main_value, value) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (timestamp, sensor_id) DO UPDATE SET main_value = EXCLUDED.main_value, value = EXCLUDED.value`,
+		sample.Time, SensorID, sample.PowerW, value)
+	if err != nil {
+		return fmt.Errorf("save solar sample: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresStore) Latest(ctx context.Context) (Sample, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT timestamp, value FROM measurements
+		WHERE sensor_id = $1 ORDER BY timestamp DESC LIMIT 1`, SensorID)
+	s, err := scanSample(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Sample{}, ErrNoSamples
+	}
+	if err != nil {
+		return Sample{}, fmt.Errorf("query latest solar sample: %w", err)
+	}
+	return s, nil
+}
+
+func (p *PostgresStore) History(ctx context.Context, from, to time.Time) ([]Sample, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT timestamp, value FROM measurements
+		WHERE sensor_id = $1 AND timestamp >= $2 AND timestamp < $3
+		ORDER BY timestamp`, SensorID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query solar history: %w", err)
+	}
+	defer rows.Close()
+	history := []Sample{}
+	for rows.Next() {
+		s, err := scanSample(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan solar sample: %w", err)
+		}
+		history = append(history, s)
+	}
+	return history, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSample(s scanner) (Sample, error) {
+	var (
+		sample Sample
+		value  []byte
+		v      sampleValue
+	)
+	if err := s.Scan(&sample.Time, &value); err != nil {
+		return sample, err
+	}
+	if err := json.Unmarshal(value, &v); err != nil {
+		return sample, err
+	}
+	sample.Time = sample.Time.UTC()
+	sample.PowerW, sample.EnergyWh, sample.GridW = v.PowerW, v.EnergyWh, v.GridW
+	sample.ExportedWh, sample.ImportedWh, sample.Status = v.ExportedWh, v.ImportedWh, v.Status
+	return sample, nil
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package solar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/config"
+	"github.com/mikahozz/gohome/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresStore(t *testing.T) {
+	config.LoadEnv()
+	conn, err := db.Open()
+	if err != nil {
+		t.Skipf("database not available: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	store := NewPostgresStore(conn)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, store.Save(ctx, Sample{Time: now.Add(-time.Minute), PowerW: 1200, EnergyWh: float(1000), Status: StatusProducing}))
+	require.NoError(t, store.Save(ctx, Sample{Time: now, PowerW: 1300, GridW: float(-200), ExportedWh: float(50), ImportedWh: float(70)}))
+
+	latest, err := store.Latest(ctx)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(latest.Time))
+	assert.Equal(t, 1300.0, latest.PowerW)
+	assert.Equal(t, -200.0, *latest.GridW)
+	assert.Nil(t, latest.EnergyWh)
+
+	history, err := store.History(ctx, now.Add(-time.Hour), now.Add(time.Second))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(history), 2)
+	assert.Equal(t, StatusProducing, history[len(history)-2].Status)
+}
@@ -0,0 +1,118 @@
+package solar
+
+import (
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/spot"
+)
+
+// maxSampleGap is the longest gap between samples still integrated over.
+// Longer gaps are missing data rather than steady production.
+const maxSampleGap = 5 * time.Minute
+
+// Hour is the production of an hour. Energy is in watt-hours, the price in
+// c/kWh and the values in cents. Export and self-consumption are known only
+// with a grid meter, the values only with a spot price.
+type Hour struct {
+	Time           time.Time `json:"time"`
+	ProducedWh     float64   `json:"producedwh"`
+	ExportedWh     *float64  `json:"exportedwh"`
+	SelfConsumedWh *float64  `json:"selfconsumedwh"`
+	Price          *float64  `json:"price"`
+	Savings        *float64  `json:"savings"`     // Self-consumed energy at the spot price
+	ExportValue    *float64  `json:"exportvalue"` // Exported energy at the spot price
+}
+
+// SelfConsumption is the hourly production with totals over the range.
+type SelfConsumption struct {
+	Hours              []Hour   `json:"hours"`
+	ProducedWh         float64  `json:"producedwh"`
+	ExportedWh         *float64 `json:"exportedwh"`
+	SelfConsumedWh     *float64 `json:"selfconsumedwh"`
+	SelfConsumptionPct *float64 `json:"selfconsumptionpct"`
+	Savings            *float64 `json:"savings"`
+	ExportValue        *float64 `json:"exportvalue"`
+}
+
+// Summarize integrates the samples into hours from from to to, both on the
+// hour, and values the energy at the average spot price of each hour.
+func Summarize(samples []Sample, prices []spot.SpotPrice, from, to time.Time) SelfConsumption {
+	hours := []Hour{}
+	index := map[int64]int{}
+	for t := from; t.Before(to); t = t.Add(time.Hour) {
+		index[t.Unix()] = len(hours)
+		hours = append(hours, Hour{Time: t})
+	}
+
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		gap := cur.Time.Sub(prev.Time)
+		if gap <= 0 || gap > maxSampleGap {
+			continue
+		}
+		h, ok := index[prev.Time.Truncate(time.Hour).Unix()]
+		if !ok {
+			continue
+		}
+		hour := &hours[h]
+		// Trapezoids between samples
+		hour.ProducedWh += (prev.PowerW + cur.PowerW) / 2 * gap.Hours()
+		if prev.GridW != nil && cur.GridW != nil {
+			exported := (*prev.ExportW() + *cur.ExportW()) / 2 * gap.Hours()
+			hour.ExportedWh = add(hour.ExportedWh, exported)
+		}
+	}
+
+	priceSum, priceCount := make([]float64, len(hours)), make([]int, len(hours))
+	for _, p := range prices {
+		if h, ok := index[p.DateTime.Truncate(time.Hour).Unix()]; ok {
+			priceSum[h] += p.PriceCkwh
+			priceCount[h]++
+		}
+	}
+
+	summary := SelfConsumption{Hours: hours}
+	for i := range hours {
+		hour := &hours[i]
+		if hour.ExportedWh != nil {
+			// Export cannot exceed production, the meter and inverter are
+			// sampled a moment apart
+			*hour.ExportedWh = min(*hour.ExportedWh, hour.ProducedWh)
+			selfConsumed := hour.ProducedWh - *hour.ExportedWh
+			hour.SelfConsumedWh = &selfConsumed
+		}
+		if priceCount[i] > 0 {
+			price := priceSum[i] / float64(priceCount[i])
+			hour.Price = &price
+			if hour.SelfConsumedWh != nil {
+				savings := *hour.SelfConsumedWh / 1000 * price
+				hour.Savings = &savings
+				exportValue := *hour.ExportedWh / 1000 * price
+				hour.ExportValue = &exportValue
+			}
+		}
+
+		summary.ProducedWh += hour.ProducedWh
+		if hour.ExportedWh != nil {
+			summary.ExportedWh = add(summary.ExportedWh, *hour.ExportedWh)
+			summary.SelfConsumedWh = add(summary.SelfConsumedWh, *hour.SelfConsumedWh)
+		}
+		if hour.Savings != nil {
+			summary.Savings = add(summary.Savings, *hour.Savings)
+			summary.ExportValue = add(summary.ExportValue, *hour.ExportValue)
+		}
+	}
+	if summary.SelfConsumedWh != nil && summary.ProducedWh > 0 {
+		pct := *summary.SelfConsumedWh / summary.ProducedWh * 100
+		summary.SelfConsumptionPct = &pct
+	}
+	return summary
+}
+
+func add(sum *float64, v float64) *float64 {
+	if sum == nil {
+		return &v
+	}
+	*sum += v
+	return sum
+}
@@ -0,0 +1,82 @@
+package solar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/spot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestSummarize(t *testing.T) {
+	from := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	var samples []Sample
+	// 3 kW for two hours with 1 kW exported, sampled every minute
+	for m := 0; m < 120; m++ {
+		samples = append(samples, Sample{Time: from.Add(time.Duration(m) * time.Minute), PowerW: 3000, GridW: float(-1000)})
+	}
+	// After a gap the third hour imports
+	for m := 140; m < 180; m++ {
+		samples = append(samples, Sample{Time: from.Add(time.Duration(m) * time.Minute), PowerW: 600, GridW: float(400)})
+	}
+	prices := []spot.SpotPrice{
+		{DateTime: from, PriceCkwh: 10},
+		// 15 minute prices average to 20
+		{DateTime: from.Add(time.Hour), PriceCkwh: 10},
+		{DateTime: from.Add(75 * time.Minute), PriceCkwh: 30},
+		{DateTime: from.Add(90 * time.Minute), PriceCkwh: 15},
+		{DateTime: from.Add(105 * time.Minute), PriceCkwh: 25},
+	}
+	s := Summarize(samples, prices, from, to)
+	require.Len(t, s.Hours, 3)
+
+	first := s.Hours[0]
+	assert.InDelta(t, 3000, first.ProducedWh, 1e-6)
+	assert.InDelta(t, 1000, *first.ExportedWh, 1e-6)
+	assert.InDelta(t, 2000, *first.SelfConsumedWh, 1e-6)
+	assert.Equal(t, 10.0, *first.Price)
+	assert.InDelta(t, 20, *first.Savings, 1e-6)
+	assert.InDelta(t, 10, *first.ExportValue, 1e-6)
+
+	second := s.Hours[1]
+	// The last sample has no successor, one minute short
+	assert.InDelta(t, 2950, second.ProducedWh, 1e-6)
+	assert.Equal(t, 20.0, *second.Price)
+
+	third := s.Hours[2]
+	assert.InDelta(t, 390, third.ProducedWh, 1e-6, "the gap is not integrated")
+	assert.InDelta(t, 0, *third.ExportedWh, 1e-6)
+	assert.InDelta(t, 390, *third.SelfConsumedWh, 1e-6)
+	assert.Nil(t, third.Price)
+	assert.Nil(t, third.Savings)
+
+	assert.InDelta(t, 6340, s.ProducedWh, 1e-6)
+	assert.InDelta(t, 1983.33, *s.ExportedWh, 0.01)
+	assert.InDelta(t, 68.7, *s.SelfConsumptionPct, 0.1)
+	assert.InDelta(t, 20+39.33, *s.Savings, 0.01)
+}
+
+func TestSummarizeWithoutMeter(t *testing.T) {
+	from := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		{Time: from, PowerW: 1000},
+		{Time: from.Add(30 * time.Minute), PowerW: 2000},
+	}
+	s := Summarize(samples, []spot.SpotPrice{{DateTime: from, PriceCkwh: 5}}, from, from.Add(time.Hour))
+	require.Len(t, s.Hours, 1)
+	assert.Equal(t, 0.0, s.ProducedWh, "30 minute gap is missing data")
+
+	samples[1].Time = from.Add(5 * time.Minute)
+	s = Summarize(samples, nil, from, from.Add(time.Hour))
+	assert.InDelta(t, 125, s.ProducedWh, 1e-6)
+	assert.Nil(t, s.Hours[0].ExportedWh)
+	assert.Nil(t, s.SelfConsumedWh)
+	assert.Nil(t, s.SelfConsumptionPct)
+	assert.Nil(t, s.Savings)
+}
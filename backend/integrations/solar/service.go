@@ -0,0 +1,77 @@
+package solar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/spot"
+)
+
+// PriceSource returns the spot prices in [start, end).
+type PriceSource func(start, end time.Time) ([]spot.SpotPrice, error)
+
+// Service serves the recorded production.
+type Service struct {
+	store  Store
+	prices PriceSource
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewService returns a service that considers samples older than maxAge
+// stale. Without prices self-consumption is reported without its value.
+func NewService(store Store, prices PriceSource, maxAge time.Duration) *Service {
+	return &Service{store: store, prices: prices, maxAge: maxAge, now: time.Now}
+}
+
+// Current is the latest production, in the form of the former electricity
+// service.
+type Current struct {
+	DateTime     time.Time `json:"datetime"`
+	PowerW       float64   `json:"powerw"`
+	GridW        *float64  `json:"gridw,omitempty"`
+	ConsumptionW *float64  `json:"consumptionw,omitempty"`
+	Status       Status    `json:"status,omitempty"`
+}
+
+// Current returns the latest sample, ErrStale if it is older than the
+// maximum age.
+func (s *Service) Current(ctx context.Context) (Current, error) {
+	sample, err := s.store.Latest(ctx)
+	if err != nil {
+		return Current{}, err
+	}
+	if s.now().Sub(sample.Time) > s.maxAge {
+		return Current{}, fmt.Errorf("%w: from %s", ErrStale, sample.Time.Format(time.RFC3339))
+	}
+	return Current{
+		DateTime:     sample.Time,
+		PowerW:       sample.PowerW,
+		GridW:        sample.GridW,
+		ConsumptionW: sample.ConsumptionW(),
+		Status:       sample.Status,
+	}, nil
+}
+
+func (s *Service) History(ctx context.Context, from, to time.Time) ([]Sample, error) {
+	return s.store.History(ctx, from, to)
+}
+
+// SelfConsumption returns the hourly production in [from, to) and how much
+// of it was used at home, valued at the spot price.
+func (s *Service) SelfConsumption(ctx context.Context, from, to time.Time) (SelfConsumption, error) {
+	from, to = from.Truncate(time.Hour), to.Truncate(time.Hour)
+	// Samples past the end close the last interval of the range
+	samples, err := s.store.History(ctx, from, to.Add(maxSampleGap))
+	if err != nil {
+		return SelfConsumption{}, err
+	}
+	var prices []spot.SpotPrice
+	if s.prices != nil {
+		if prices, err = s.prices(from, to); err != nil {
+			return SelfConsumption{}, fmt.Errorf("spot prices: %w", err)
+		}
+	}
+	return Summarize(samples, prices, from, to), nil
+}
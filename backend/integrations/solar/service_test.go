@@ -0,0 +1,74 @@
+package solar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/spot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReader struct {
+	samples []Sample
+	errs    []error
+}
+
+func (f *fakeReader) Read(ctx context.Context) (Sample, error) {
+	sample, err := f.samples[0], f.errs[0]
+	f.samples, f.errs = f.samples[1:], f.errs[1:]
+	return sample, err
+}
+
+func TestPollerAndCurrent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	reader := &fakeReader{
+		samples: []Sample{{}, {Time: now, PowerW: 1500, GridW: float(-300)}},
+		errs:    []error{errors.New("connection refused"), nil},
+	}
+	poller := NewPoller(reader, store, time.Minute)
+	service := NewService(store, nil, 5*time.Minute)
+	service.now = func() time.Time { return now.Add(time.Minute) }
+
+	poller.Poll(ctx)
+	_, err := service.Current(ctx)
+	assert.ErrorIs(t, err, ErrNoSamples)
+
+	poller.Poll(ctx)
+	current, err := service.Current(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, now, current.DateTime)
+	assert.Equal(t, 1500.0, current.PowerW)
+	assert.Equal(t, 1200.0, *current.ConsumptionW)
+
+	service.now = func() time.Time { return now.Add(10 * time.Minute) }
+	_, err = service.Current(ctx)
+	assert.ErrorIs(t, err, ErrStale)
+}
+
+func TestServiceSelfConsumption(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	for m := 0; m <= 60; m++ {
+		require.NoError(t, store.Save(ctx, Sample{Time: from.Add(time.Duration(m) * time.Minute), PowerW: 2000, GridW: float(0)}))
+	}
+	var start, end time.Time
+	prices := func(s, e time.Time) ([]spot.SpotPrice, error) {
+		start, end = s, e
+		return []spot.SpotPrice{{DateTime: from, PriceCkwh: 8}}, nil
+	}
+	service := NewService(store, prices, 5*time.Minute)
+
+	s, err := service.SelfConsumption(ctx, from.Add(10*time.Minute), from.Add(70*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, from, start, "range is truncated to the hour")
+	assert.Equal(t, from.Add(time.Hour), end)
+	require.Len(t, s.Hours, 1)
+	assert.InDelta(t, 2000, s.ProducedWh, 1e-6)
+	assert.InDelta(t, 16, *s.Savings, 1e-6)
+}
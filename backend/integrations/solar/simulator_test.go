@@ -0,0 +1,146 @@
+package solar
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+)
+
+// simulator is a Modbus TCP server answering reads of holding registers from
+// a register map per unit. Unset registers are illegal addresses.
+type simulator struct {
+	t        *testing.T
+	listener net.Listener
+	mu       sync.Mutex
+	units    map[byte]map[uint16]uint16
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+func newSimulator(t *testing.T, address string) *simulator {
+	t.Helper()
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &simulator{t: t, listener: l, units: map[byte]map[uint16]uint16{}, conns: map[net.Conn]struct{}{}}
+	s.wg.Add(1)
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *simulator) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Set writes registers from the address on.
+func (s *simulator) Set(unit byte, address uint16, values ...uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.units[unit] == nil {
+		s.units[unit] = map[uint16]uint16{}
+	}
+	for i, v := range values {
+		s.units[unit][address+uint16(i)] = v
+	}
+}
+
+// Close stops listening and drops the connections.
+func (s *simulator) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *simulator) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *simulator) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+	for {
+		req := make([]byte, 12)
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		unit, function := req[6], req[7]
+		address, count := binary.BigEndian.Uint16(req[8:]), binary.BigEndian.Uint16(req[10:])
+		pdu := s.respond(unit, function, address, count)
+		resp := make([]byte, 7, 7+len(pdu))
+		copy(resp, req[:4])
+		binary.BigEndian.PutUint16(resp[4:], uint16(len(pdu)+1))
+		resp[6] = unit
+		if _, err := conn.Write(append(resp, pdu...)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *simulator) respond(unit, function byte, address, count uint16) []byte {
+	if function != funcReadHoldingRegisters {
+		return []byte{function | 0x80, 1} // Illegal function
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pdu := []byte{function, byte(2 * count)}
+	for i := uint16(0); i < count; i++ {
+		v, ok := s.units[unit][address+i]
+		if !ok {
+			return []byte{function | 0x80, 2} // Illegal data address
+		}
+		pdu = binary.BigEndian.AppendUint16(pdu, v)
+	}
+	return pdu
+}
+
+// setSunSpec writes a SunSpec map at 40000 with the common model followed by
+// the model.
+func (s *simulator) setSunSpec(unit byte, id uint16, model []uint16) {
+	s.Set(unit, 40000, sunSpecMarker[0], sunSpecMarker[1])
+	s.Set(unit, 40002, append([]uint16{1, 65}, make([]uint16, 65)...)...)
+	address := uint16(40002 + 67)
+	s.Set(unit, address, append([]uint16{id, uint16(len(model))}, model...)...)
+	s.Set(unit, address+2+uint16(len(model)), modelEnd, 0)
+}
+
+// inverterModel returns model 103 producing the power in watts.
+func inverterModel(powerDW int16, energyWh uint32, status uint16) []uint16 {
+	m := make([]uint16, 50)
+	// Offsets from the model ID, less the ID and length
+	m[14-2] = uint16(powerDW)
+	m[15-2] = 0xFFFF // W_SF -1, deciwatts
+	m[24-2], m[25-2] = uint16(energyWh>>16), uint16(energyWh)
+	m[26-2] = 0 // WH_SF
+	m[38-2] = status
+	return m
+}
+
+// meterModel returns model 203 with the grid power in watts.
+func meterModel(gridW int16, exportedWh, importedWh uint32) []uint16 {
+	m := make([]uint16, 105)
+	m[18-2] = uint16(gridW)
+	m[22-2] = 0 // W_SF
+	m[38-2], m[39-2] = uint16(exportedWh>>16), uint16(exportedWh)
+	m[46-2], m[47-2] = uint16(importedWh>>16), uint16(importedWh)
+	m[54-2] = 1 // TotWh_SF, tens of watt-hours
+	return m
+}
@@ -0,0 +1,209 @@
+package solar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// SunSpec models read. Only the integer models with scale factors are
+// supported, the floating point variants are not.
+const (
+	modelInverterSinglePhase = 101
+	modelInverterThreePhase  = 103
+	modelMeterSinglePhase    = 201
+	modelMeterWye            = 204
+	modelEnd                 = 0xFFFF
+)
+
+// sunSpecMarker is "SunS" at the start of the SunSpec register map.
+var sunSpecMarker = [2]uint16{0x5375, 0x6e53}
+
+// sunSpecBases are the addresses the map may start at, the usual first.
+var sunSpecBases = []uint16{40000, 0, 50000}
+
+var ErrNotSunSpec = errors.New("device has no SunSpec register map")
+
+// RegisterReader reads holding registers, implemented by ModbusClient.
+type RegisterReader interface {
+	ReadHoldingRegisters(ctx context.Context, unit byte, address, count uint16) ([]uint16, error)
+}
+
+// SunSpecReader reads the production from the inverter model and the grid
+// power from the meter model. The meter is optional and may be on another
+// unit of the same device, as with a Fronius Smart Meter behind the inverter.
+type SunSpecReader struct {
+	client       RegisterReader
+	inverterUnit byte
+	meterUnit    byte // 0 when there is no meter
+	now          func() time.Time
+
+	mu       sync.Mutex
+	inverter *model
+	meter    *model
+}
+
+// model is the location of a SunSpec model block, its address pointing at
+// the model ID.
+type model struct {
+	id      uint16
+	address uint16
+	length  uint16
+}
+
+func NewSunSpecReader(client RegisterReader, inverterUnit, meterUnit byte) *SunSpecReader {
+	return &SunSpecReader{client: client, inverterUnit: inverterUnit, meterUnit: meterUnit, now: time.Now}
+}
+
+func (r *SunSpecReader) Read(ctx context.Context) (Sample, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sample, err := r.read(ctx)
+	if err != nil {
+		// The device may have restarted with another map, look it up again
+		r.inverter, r.meter = nil, nil
+	}
+	return sample, err
+}
+
+func (r *SunSpecReader) read(ctx context.Context) (Sample, error) {
+	if r.inverter == nil {
+		m, err := r.discover(ctx, r.inverterUnit, modelInverterSinglePhase, modelInverterThreePhase)
+		if err != nil {
+			return Sample{}, fmt.Errorf("inverter: %w", err)
+		}
+		r.inverter = m
+	}
+	if r.meterUnit != 0 && r.meter == nil {
+		m, err := r.discover(ctx, r.meterUnit, modelMeterSinglePhase, modelMeterWye)
+		if err != nil {
+			return Sample{}, fmt.Errorf("meter: %w", err)
+		}
+		r.meter = m
+	}
+
+	sample := Sample{Time: r.now().UTC()}
+	regs, err := r.client.ReadHoldingRegisters(ctx, r.inverterUnit, r.inverter.address, r.inverter.length+2)
+	if err != nil {
+		return Sample{}, fmt.Errorf("read inverter: %w", err)
+	}
+	if len(regs) < 39 {
+		return Sample{}, fmt.Errorf("inverter model %d too short", r.inverter.id)
+	}
+	// Offsets from the model ID, SunSpec models 101-103
+	power := scaledInt16(regs[14], regs[15])
+	if power == nil {
+		return Sample{}, fmt.Errorf("inverter power not available")
+	}
+	sample.PowerW = max(*power, 0)
+	sample.EnergyWh = scaledAcc32(regs[24], regs[25], regs[26])
+	sample.Status = inverterStatus(regs[38])
+
+	if r.meter != nil {
+		regs, err := r.client.ReadHoldingRegisters(ctx, r.meterUnit, r.meter.address, r.meter.length+2)
+		if err != nil {
+			return Sample{}, fmt.Errorf("read meter: %w", err)
+		}
+		if len(regs) < 55 {
+			return Sample{}, fmt.Errorf("meter model %d too short", r.meter.id)
+		}
+		// Offsets from the model ID, SunSpec models 201-204
+		sample.GridW = scaledInt16(regs[18], regs[22])
+		sample.ExportedWh = scaledAcc32(regs[38], regs[39], regs[54])
+		sample.ImportedWh = scaledAcc32(regs[46], regs[47], regs[54])
+	}
+	return sample, nil
+}
+
+// discover walks the SunSpec model list of the unit and returns the first
+// model with an ID in minID-maxID.
+func (r *SunSpecReader) discover(ctx context.Context, unit byte, minID, maxID uint16) (*model, error) {
+	for _, base := range sunSpecBases {
+		marker, err := r.client.ReadHoldingRegisters(ctx, unit, base, 2)
+		var modbusErr *ModbusError
+		if errors.As(err, &modbusErr) {
+			// Illegal address, try the next base
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if marker[0] != sunSpecMarker[0] || marker[1] != sunSpecMarker[1] {
+			continue
+		}
+		address := base + 2
+		for range 64 {
+			header, err := r.client.ReadHoldingRegisters(ctx, unit, address, 2)
+			if err != nil {
+				return nil, err
+			}
+			id, length := header[0], header[1]
+			if id == modelEnd {
+				break
+			}
+			if id >= minID && id <= maxID {
+				return &model{id: id, address: address, length: length}, nil
+			}
+			address += 2 + length
+		}
+		return nil, fmt.Errorf("no SunSpec model %d-%d on unit %d", minID, maxID, unit)
+	}
+	return nil, ErrNotSunSpec
+}
+
+// SunSpec marks values a device does not provide.
+const (
+	notImplementedInt16 = 0x8000
+	notAccumulated      = 0
+)
+
+func scale(sf uint16) (float64, bool) {
+	if sf == notImplementedInt16 {
+		return 0, false
+	}
+	return math.Pow10(int(int16(sf))), true
+}
+
+func scaledInt16(value, sf uint16) *float64 {
+	factor, ok := scale(sf)
+	if value == notImplementedInt16 || !ok {
+		return nil
+	}
+	v := float64(int16(value)) * factor
+	return &v
+}
+
+func scaledAcc32(high, low, sf uint16) *float64 {
+	acc := uint32(high)<<16 | uint32(low)
+	factor, ok := scale(sf)
+	if acc == notAccumulated || !ok {
+		return nil
+	}
+	v := float64(acc) * factor
+	return &v
+}
+
+func inverterStatus(st uint16) Status {
+	switch st {
+	case 1:
+		return StatusOff
+	case 2:
+		return StatusSleeping
+	case 3:
+		return StatusStarting
+	case 4:
+		return StatusProducing
+	case 5:
+		return StatusThrottled
+	case 6:
+		return StatusShuttingDown
+	case 7:
+		return StatusFault
+	case 8:
+		return StatusStandby
+	}
+	return ""
+}
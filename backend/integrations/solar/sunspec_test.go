@@ -0,0 +1,99 @@
+package solar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSunSpecReader(t *testing.T) {
+	sim := newSimulator(t, "127.0.0.1:0")
+	sim.setSunSpec(1, 103, inverterModel(23456, 12_345_678, 4))
+	sim.setSunSpec(240, 203, meterModel(-1200, 50_000, 70_000))
+
+	client := NewModbusClient(sim.Addr(), time.Second)
+	defer client.Close()
+	reader := NewSunSpecReader(client, 1, 240)
+	sample, err := reader.Read(context.Background())
+	require.NoError(t, err)
+
+	assert.InDelta(t, 2345.6, sample.PowerW, 1e-9)
+	require.NotNil(t, sample.EnergyWh)
+	assert.Equal(t, 12_345_678.0, *sample.EnergyWh)
+	assert.Equal(t, StatusProducing, sample.Status)
+	require.NotNil(t, sample.GridW)
+	assert.Equal(t, -1200.0, *sample.GridW)
+	assert.Equal(t, 500_000.0, *sample.ExportedWh)
+	assert.Equal(t, 700_000.0, *sample.ImportedWh)
+	assert.InDelta(t, 1145.6, *sample.ConsumptionW(), 1e-9)
+	assert.Equal(t, 1200.0, *sample.ExportW())
+}
+
+func TestSunSpecReaderWithoutMeter(t *testing.T) {
+	sim := newSimulator(t, "127.0.0.1:0")
+	model := inverterModel(0, 0, 2)
+	model[15-2] = notImplementedInt16 // no W_SF
+	sim.setSunSpec(1, 101, model)
+
+	reader := NewSunSpecReader(NewModbusClient(sim.Addr(), time.Second), 1, 0)
+	_, err := reader.Read(context.Background())
+	assert.Error(t, err, "power not implemented")
+
+	sim.setSunSpec(1, 101, inverterModel(0, 0, 2))
+	sample, err := reader.Read(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sample.PowerW)
+	assert.Nil(t, sample.EnergyWh, "zero accumulator is not implemented")
+	assert.Nil(t, sample.GridW)
+	assert.Nil(t, sample.ConsumptionW())
+	assert.Equal(t, StatusSleeping, sample.Status)
+}
+
+func TestSunSpecReaderNotSunSpec(t *testing.T) {
+	sim := newSimulator(t, "127.0.0.1:0")
+	sim.Set(1, 0, 1, 2, 3)
+	reader := NewSunSpecReader(NewModbusClient(sim.Addr(), time.Second), 1, 0)
+	_, err := reader.Read(context.Background())
+	assert.ErrorIs(t, err, ErrNotSunSpec)
+}
+
+func TestModbusException(t *testing.T) {
+	sim := newSimulator(t, "127.0.0.1:0")
+	client := NewModbusClient(sim.Addr(), time.Second)
+	defer client.Close()
+	_, err := client.ReadHoldingRegisters(context.Background(), 1, 100, 2)
+	var modbusErr *ModbusError
+	require.True(t, errors.As(err, &modbusErr))
+	assert.Equal(t, byte(2), modbusErr.Exception)
+
+	// Long reads are split
+	sim.Set(1, 0, make([]uint16, 300)...)
+	regs, err := client.ReadHoldingRegisters(context.Background(), 1, 0, 300)
+	require.NoError(t, err)
+	assert.Len(t, regs, 300)
+}
+
+func TestSunSpecReaderReconnects(t *testing.T) {
+	sim := newSimulator(t, "127.0.0.1:0")
+	address := sim.Addr()
+	sim.setSunSpec(1, 103, inverterModel(10000, 1000, 4))
+	reader := NewSunSpecReader(NewModbusClient(address, time.Second), 1, 0)
+	_, err := reader.Read(context.Background())
+	require.NoError(t, err)
+
+	// The inverter goes to sleep for the night
+	sim.Close()
+	_, err = reader.Read(context.Background())
+	require.Error(t, err)
+
+	sim = newSimulator(t, address)
+	sim.setSunSpec(1, 103, inverterModel(500, 1001, 3))
+	sample, err := reader.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, sample.PowerW)
+	assert.Equal(t, StatusStarting, sample.Status)
+}
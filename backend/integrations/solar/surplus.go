@@ -0,0 +1,100 @@
+package solar
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HistorySource returns recorded samples, implemented by the stores and
+// Service.
+type HistorySource interface {
+	History(ctx context.Context, from, to time.Time) ([]Sample, error)
+}
+
+// Surplus is solar power exported to the grid for long enough to be worth
+// switching a load on. It turns on when the export stays above OnAboveW for
+// OnFor and off again when it stays below OffBelowW for OffFor. Export is
+// negative while importing. Switching the load on lowers the export by its
+// power, so OffBelowW is usually zero or below to not switch it straight
+// back off.
+type Surplus struct {
+	Source    HistorySource
+	OnAboveW  float64
+	OnFor     time.Duration
+	OffBelowW float64
+	OffFor    time.Duration
+
+	mu sync.Mutex
+	on bool
+}
+
+// Active reports whether there is a surplus at now. The state changes only
+// once the new level has been sustained, in between the previous state
+// holds. Missing meter data never turns the surplus on and turns it off
+// after OffFor.
+func (s *Surplus) Active(now time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Source == nil {
+		return s.on, fmt.Errorf("surplus condition has no source")
+	}
+	if s.OffBelowW >= s.OnAboveW {
+		return s.on, fmt.Errorf("surplus off threshold %.0f W must be below the on threshold %.0f W", s.OffBelowW, s.OnAboveW)
+	}
+	window := s.OnFor
+	if s.on {
+		window = s.OffFor
+	}
+	from := now.Add(-window)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// From one gap earlier to know the export at the start of the window
+	samples, err := s.Source.History(ctx, from.Add(-maxSampleGap), now.Add(time.Second))
+	if err != nil {
+		return s.on, err
+	}
+	if s.on {
+		s.on = !sustainedBelow(samples, from, s.OffBelowW)
+	} else {
+		s.on = sustainedAbove(samples, from, now, s.OnAboveW)
+	}
+	return s.on, nil
+}
+
+// sustainedAbove reports whether the export was above the threshold all the
+// time from from to to: in the last sample at or before from and every
+// sample after it, without gaps in the samples.
+func sustainedAbove(samples []Sample, from, to time.Time, thresholdW float64) bool {
+	start := -1
+	for i, sample := range samples {
+		if !sample.Time.After(from) {
+			start = i
+		}
+	}
+	if start < 0 || to.Sub(samples[len(samples)-1].Time) > maxSampleGap {
+		return false
+	}
+	for i := start; i < len(samples); i++ {
+		sample := samples[i]
+		if sample.GridW == nil || -*sample.GridW <= thresholdW {
+			return false
+		}
+		if i > start && sample.Time.Sub(samples[i-1].Time) > maxSampleGap {
+			return false
+		}
+	}
+	return true
+}
+
+// sustainedBelow reports whether no sample since from exported at or above
+// the threshold.
+func sustainedBelow(samples []Sample, from time.Time, thresholdW float64) bool {
+	for _, sample := range samples {
+		if !sample.Time.Before(from) && sample.GridW != nil && -*sample.GridW >= thresholdW {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package solar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exporting records a sample a minute from the start with the export.
+func exporting(t *testing.T, store *MemoryStore, start time.Time, minutes int, exportW float64) time.Time {
+	for m := 0; m < minutes; m++ {
+		grid := -exportW
+		require.NoError(t, store.Save(context.Background(), Sample{Time: start.Add(time.Duration(m) * time.Minute), PowerW: 3000, GridW: &grid}))
+	}
+	return start.Add(time.Duration(minutes-1) * time.Minute)
+}
+
+func TestSurplus(t *testing.T) {
+	store := NewMemoryStore()
+	surplus := &Surplus{Source: store, OnAboveW: 1500, OnFor: 10 * time.Minute, OffBelowW: 0, OffFor: 5 * time.Minute}
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// Eight minutes is not sustained yet
+	now := exporting(t, store, start, 8, 2000)
+	active, err := surplus.Active(now)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	// A dip restarts the period
+	now = exporting(t, store, now.Add(time.Minute), 1, 1400)
+	now = exporting(t, store, now.Add(time.Minute), 9, 2000)
+	active, _ = surplus.Active(now)
+	assert.False(t, active)
+
+	now = exporting(t, store, now.Add(time.Minute), 2, 2000)
+	active, _ = surplus.Active(now)
+	assert.True(t, active, "above 1.5 kW for 10 minutes")
+
+	// With the load on the export falls but stays above the off threshold
+	now = exporting(t, store, now.Add(time.Minute), 20, 300)
+	active, _ = surplus.Active(now)
+	assert.True(t, active, "hysteresis keeps it on")
+
+	// Importing for less than the off period
+	now = exporting(t, store, now.Add(time.Minute), 3, -500)
+	active, _ = surplus.Active(now)
+	assert.True(t, active)
+
+	now = exporting(t, store, now.Add(time.Minute), 3, -500)
+	active, _ = surplus.Active(now)
+	assert.False(t, active, "below 0 W for 5 minutes")
+}
+
+func TestSurplusMissingData(t *testing.T) {
+	store := NewMemoryStore()
+	surplus := &Surplus{Source: store, OnAboveW: 1500, OnFor: 10 * time.Minute, OffBelowW: 0, OffFor: 5 * time.Minute}
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// A gap in the samples breaks the period
+	exporting(t, store, start, 4, 2000)
+	now := exporting(t, store, start.Add(10*time.Minute), 5, 2000)
+	active, _ := surplus.Active(now)
+	assert.False(t, active)
+
+	now = exporting(t, store, now.Add(time.Minute), 6, 2000)
+	active, _ = surplus.Active(now)
+	require.True(t, active)
+
+	// The inverter stops answering
+	active, _ = surplus.Active(now.Add(4 * time.Minute))
+	assert.True(t, active)
+	active, _ = surplus.Active(now.Add(6 * time.Minute))
+	assert.False(t, active)
+
+	_, err := (&Surplus{Source: store, OnAboveW: 100, OffBelowW: 100}).Active(now)
+	assert.Error(t, err)
+}
+
+func TestLoadSurplus(t *testing.T) {
+	t.Setenv("SOLAR_SURPLUS_ON_W", "")
+	surplus, err := LoadSurplus(NewMemoryStore())
+	require.NoError(t, err)
+	assert.Nil(t, surplus)
+
+	t.Setenv("SOLAR_SURPLUS_ON_W", "1500")
+	t.Setenv("SOLAR_SURPLUS_OFF_W", "-200")
+	t.Setenv("SOLAR_SURPLUS_ON_FOR", "15m")
+	surplus, err = LoadSurplus(NewMemoryStore())
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, surplus.OnAboveW)
+	assert.Equal(t, -200.0, surplus.OffBelowW)
+	assert.Equal(t, 15*time.Minute, surplus.OnFor)
+	assert.Equal(t, 5*time.Minute, surplus.OffFor)
+
+	t.Setenv("SOLAR_SURPLUS_OFF_W", "2000")
+	_, err = LoadSurplus(NewMemoryStore())
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package warnings
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AtomFeed is the FMI warning feed. Each entry carries one CAP alert, either
+// inline in the content or behind a link.
+type AtomFeed struct {
+	Updated string      `xml:"updated"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomEntry struct {
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Links   []AtomLink `xml:"link"`
+	Alert   *Alert     `xml:"content>alert"`
+}
+
+type AtomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr"`
+}
+
+// capLink returns the address of the entry's CAP document, if any.
+func (e AtomEntry) capLink() string {
+	for _, l := range e.Links {
+		if l.Type == "application/cap+xml" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+// Alert is a CAP 1.2 alert message.
+type Alert struct {
+	Identifier string `xml:"identifier"`
+	Sender     string `xml:"sender"`
+	Sent       string `xml:"sent"`
+	Status     string `xml:"status"`  // Actual, Exercise, System, Test, Draft
+	MsgType    string `xml:"msgType"` // Alert, Update, Cancel
+	References string `xml:"references"`
+	Infos      []Info `xml:"info"`
+}
+
+// Info is the alert content in one language.
+type Info struct {
+	Language    string      `xml:"language"`
+	Event       string      `xml:"event"`
+	Urgency     string      `xml:"urgency"`
+	Severity    string      `xml:"severity"`
+	Certainty   string      `xml:"certainty"`
+	EventCodes  []NamedItem `xml:"eventCode"`
+	Effective   string      `xml:"effective"`
+	Onset       string      `xml:"onset"`
+	Expires     string      `xml:"expires"`
+	Headline    string      `xml:"headline"`
+	Description string      `xml:"description"`
+	Instruction string      `xml:"instruction"`
+	Web         string      `xml:"web"`
+	Areas       []CapArea   `xml:"area"`
+}
+
+type NamedItem struct {
+	ValueName string `xml:"valueName"`
+	Value     string `xml:"value"`
+}
+
+type CapArea struct {
+	AreaDesc string      `xml:"areaDesc"`
+	Polygons []string    `xml:"polygon"`
+	Geocodes []NamedItem `xml:"geocode"`
+}
+
+func parseFeed(body []byte) (*AtomFeed, error) {
+	feed := &AtomFeed{}
+	if err := xml.Unmarshal(body, feed); err != nil {
+		return nil, fmt.Errorf("error parsing warning feed: %w", err)
+	}
+	return feed, nil
+}
+
+func parseAlert(body []byte) (*Alert, error) {
+	alert := &Alert{}
+	if err := xml.Unmarshal(body, alert); err != nil {
+		return nil, fmt.Errorf("error parsing CAP alert: %w", err)
+	}
+	if alert.Identifier == "" {
+		return nil, fmt.Errorf("CAP alert without identifier")
+	}
+	return alert, nil
+}
+
+// referencedIDs returns the identifiers of the earlier alerts an update or
+// cancel message replaces. References are "sender,identifier,sent" triplets
+// separated by whitespace.
+func (a Alert) referencedIDs() []string {
+	var ids []string
+	for _, ref := range strings.Fields(a.References) {
+		parts := strings.Split(ref, ",")
+		if len(parts) == 3 {
+			ids = append(ids, parts[1])
+		}
+	}
+	return ids
+}
+
+// info returns the info block in the requested language, matched by prefix so
+// "en" selects "en-GB". Falls back to the first block.
+func (a Alert) info(lang string) (Info, bool) {
+	if len(a.Infos) == 0 {
+		return Info{}, false
+	}
+	for _, info := range a.Infos {
+		if lang != "" && strings.HasPrefix(strings.ToLower(info.Language), strings.ToLower(lang)) {
+			return info, true
+		}
+	}
+	return a.Infos[0], true
+}
+
+// parseTime parses CAP date times, returning the zero time for empty values.
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// parsePolygon parses a CAP polygon of "lat,lon" pairs separated by spaces.
+func parsePolygon(s string) (Polygon, error) {
+	var p Polygon
+	for _, pair := range strings.Fields(s) {
+		latStr, lonStr, found := strings.Cut(pair, ",")
+		if !found {
+			return nil, fmt.Errorf("invalid polygon point %q", pair)
+		}
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid polygon latitude %q: %w", latStr, err)
+		}
+		lon, err := strconv.ParseFloat(lonStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid polygon longitude %q: %w", lonStr, err)
+		}
+		p = append(p, Point{Lat: lat, Lon: lon})
+	}
+	if len(p) < 3 {
+		return nil, fmt.Errorf("polygon needs at least 3 points, got %d", len(p))
+	}
+	return p, nil
+}
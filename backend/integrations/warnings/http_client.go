@@ -0,0 +1,38 @@
+package warnings
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type HTTPClient interface {
+	Get(url string) ([]byte, error)
+}
+
+type DefaultHTTPClient struct {
+	http *http.Client
+}
+
+func NewDefaultHTTPClient() *DefaultHTTPClient {
+	return &DefaultHTTPClient{http: &http.Client{}}
+}
+
+func (c *DefaultHTTPClient) Get(url string) ([]byte, error) {
+	resp, err := c.http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching warnings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading warnings response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("warnings request failed with status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,8 @@
+// Package warnings reads FMI weather warnings from the CAP (Common Alerting
+// Protocol) Atom feed and matches them against our locations.
+// Feed reference: https://en.ilmatieteenlaitos.fi/cap-feeds
+package warnings
+
+const (
+	FeedURL = "https://alerts.fmi.fi/cap/feed/atom_fi-FI.xml"
+)
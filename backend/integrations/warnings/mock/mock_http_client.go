@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"os"
+)
+
+type MockHTTPClient struct {
+	GetFunc func(url string) ([]byte, error)
+}
+
+func (m *MockHTTPClient) Get(url string) ([]byte, error) {
+	return m.GetFunc(url)
+}
+
+func NewMockHTTPClient(filename string) *MockHTTPClient {
+	return &MockHTTPClient{
+		GetFunc: func(url string) ([]byte, error) {
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			return content, nil
+		},
+	}
+}
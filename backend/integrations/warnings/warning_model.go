@@ -0,0 +1,232 @@
+package warnings
+
+import (
+	"strings"
+	"time"
+)
+
+// Warning is a CAP alert flattened to one language.
+type Warning struct {
+	ID          string    `json:"id"`
+	Event       string    `json:"event"`
+	Severity    Severity  `json:"severity"`
+	Urgency     string    `json:"urgency"`
+	Certainty   string    `json:"certainty"`
+	Headline    string    `json:"headline"`
+	Description string    `json:"description"`
+	Instruction string    `json:"instruction,omitempty"`
+	Web         string    `json:"web,omitempty"`
+	Language    string    `json:"language"`
+	Onset       time.Time `json:"onset"`
+	Expires     time.Time `json:"expires"`
+	Areas       []string  `json:"areas"`
+	Locations   []string  `json:"locations,omitempty"` // Our locations the warning covers
+	events      []string  // Event names in all languages, for matching
+}
+
+// Severity is the CAP severity of a warning.
+type Severity string
+
+const (
+	SeverityUnknown  Severity = "Unknown"
+	SeverityMinor    Severity = "Minor"
+	SeverityModerate Severity = "Moderate"
+	SeveritySevere   Severity = "Severe"
+	SeverityExtreme  Severity = "Extreme"
+)
+
+// Rank orders severities from Unknown (0) to Extreme (4).
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityMinor:
+		return 1
+	case SeverityModerate:
+		return 2
+	case SeveritySevere:
+		return 3
+	case SeverityExtreme:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Area is a place warnings are matched against. A warning covers the area if
+// one of its CAP areas is the municipality or its polygon contains the point.
+type Area struct {
+	Name         string
+	Municipality string
+	Lat          float64
+	Lon          float64
+}
+
+func (a Area) hasCoordinates() bool {
+	return a.Lat != 0 || a.Lon != 0
+}
+
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+type Polygon []Point
+
+// Contains reports whether the point is inside the polygon, using ray casting.
+func (p Polygon) Contains(pt Point) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Lat > pt.Lat) != (b.Lat > pt.Lat) &&
+			pt.Lon < (b.Lon-a.Lon)*(pt.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Match selects warnings by event and severity. Events are matched as case
+// insensitive substrings of the event name in any language, so "wind"
+// matches "Wind warning". Empty fields match everything.
+type Match struct {
+	Events      []string
+	MinSeverity Severity
+}
+
+func (m Match) Matches(w Warning) bool {
+	return m.matches(w.Severity, append([]string{w.Event}, w.events...))
+}
+
+// MatchesAlert matches the alert like Matches matches its warning in any
+// language.
+func (m Match) MatchesAlert(a Alert) bool {
+	severity := SeverityUnknown
+	if info, ok := a.info(""); ok && info.Severity != "" {
+		severity = Severity(info.Severity)
+	}
+	var events []string
+	for _, info := range a.Infos {
+		events = append(events, info.Event)
+	}
+	return m.matches(severity, events)
+}
+
+func (m Match) matches(severity Severity, events []string) bool {
+	if severity.Rank() < m.MinSeverity.Rank() {
+		return false
+	}
+	if len(m.Events) == 0 {
+		return true
+	}
+	for _, want := range m.Events {
+		for _, event := range events {
+			if strings.Contains(strings.ToLower(event), strings.ToLower(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ConvertToWarning flattens the alert to the requested language.
+func ConvertToWarning(a Alert, lang string) (Warning, error) {
+	info, _ := a.info(lang)
+	w := Warning{
+		ID:          a.Identifier,
+		Event:       info.Event,
+		Severity:    Severity(info.Severity),
+		Urgency:     info.Urgency,
+		Certainty:   info.Certainty,
+		Headline:    info.Headline,
+		Description: strings.TrimSpace(info.Description),
+		Instruction: strings.TrimSpace(info.Instruction),
+		Web:         info.Web,
+		Language:    info.Language,
+	}
+	if w.Severity == "" {
+		w.Severity = SeverityUnknown
+	}
+	for _, area := range info.Areas {
+		w.Areas = append(w.Areas, area.AreaDesc)
+	}
+	for _, i := range a.Infos {
+		w.events = append(w.events, i.Event)
+	}
+	var err error
+	if w.Onset, err = a.onset(); err != nil {
+		return w, err
+	}
+	if w.Expires, err = a.expires(); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// onset is when the warned conditions begin. CAP allows leaving out onset and
+// effective, in which case the alert is in effect when sent.
+func (a Alert) onset() (time.Time, error) {
+	if len(a.Infos) > 0 {
+		if a.Infos[0].Onset != "" {
+			return parseTime(a.Infos[0].Onset)
+		}
+		if a.Infos[0].Effective != "" {
+			return parseTime(a.Infos[0].Effective)
+		}
+	}
+	return parseTime(a.Sent)
+}
+
+func (a Alert) expires() (time.Time, error) {
+	if len(a.Infos) == 0 {
+		return time.Time{}, nil
+	}
+	return parseTime(a.Infos[0].Expires)
+}
+
+// isActive reports whether an actual, non-cancelled alert is in effect at now.
+// Alerts without an expiry stay in effect until cancelled.
+func (a Alert) isActive(now time.Time) bool {
+	if a.Status != "Actual" || a.MsgType == "Cancel" {
+		return false
+	}
+	onset, err := a.onset()
+	if err != nil || now.Before(onset) {
+		return false
+	}
+	expires, err := a.expires()
+	if err != nil {
+		return false
+	}
+	return expires.IsZero() || now.Before(expires)
+}
+
+// covers reports whether any of the alert's areas, in any language, covers
+// the given area.
+func (a Alert) covers(area Area) bool {
+	for _, info := range a.Infos {
+		for _, capArea := range info.Areas {
+			if area.Municipality != "" {
+				if strings.EqualFold(capArea.AreaDesc, area.Municipality) {
+					return true
+				}
+				for _, geocode := range capArea.Geocodes {
+					if strings.EqualFold(geocode.Value, area.Municipality) {
+						return true
+					}
+				}
+			}
+			if !area.hasCoordinates() {
+				continue
+			}
+			for _, s := range capArea.Polygons {
+				polygon, err := parsePolygon(s)
+				if err != nil {
+					continue
+				}
+				if polygon.Contains(Point{Lat: area.Lat, Lon: area.Lon}) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
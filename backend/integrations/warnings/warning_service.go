@@ -0,0 +1,177 @@
+package warnings
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Source returns the warnings in effect for an area at the given time.
+type Source interface {
+	Active(area Area, now time.Time, lang string) ([]Warning, error)
+}
+
+// WarningService caches the CAP feed. The feed is refreshed after ttl has
+// passed, and a failed refresh keeps serving the previously loaded alerts.
+type WarningService struct {
+	client   HTTPClient
+	feedURL  string
+	ttl      time.Duration
+	mu       sync.Mutex
+	alerts   []Alert
+	loadedAt time.Time
+}
+
+func NewWarningService(client HTTPClient, feedURL string, ttl time.Duration) *WarningService {
+	return &WarningService{
+		client:  client,
+		feedURL: feedURL,
+		ttl:     ttl,
+	}
+}
+
+// Alerts returns the current alerts of the feed. Alerts replaced by a later
+// update or cancel message are left out.
+func (s *WarningService) Alerts() ([]Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.alerts != nil && time.Since(s.loadedAt) < s.ttl {
+		return s.alerts, nil
+	}
+	alerts, err := s.load()
+	if err == nil {
+		s.alerts = alerts
+		s.loadedAt = time.Now()
+		return s.alerts, nil
+	}
+	if s.alerts != nil {
+		log.Warn().Err(err).Str("event", "warnings_refresh_failed").Msg("using previously loaded warnings")
+		return s.alerts, nil
+	}
+	return nil, err
+}
+
+func (s *WarningService) load() ([]Alert, error) {
+	body, err := s.client.Get(s.feedURL)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := parseFeed(body)
+	if err != nil {
+		return nil, err
+	}
+	alerts := []Alert{}
+	for _, entry := range feed.Entries {
+		if entry.Alert != nil {
+			alerts = append(alerts, *entry.Alert)
+			continue
+		}
+		link := entry.capLink()
+		if link == "" {
+			continue
+		}
+		// One broken alert document shouldn't hide the others
+		alert, err := s.loadAlert(link)
+		if err != nil {
+			log.Warn().Err(err).Str("event", "warning_alert_failed").Str("url", link).Msg("skipping warning")
+			continue
+		}
+		alerts = append(alerts, *alert)
+	}
+	return currentAlerts(alerts), nil
+}
+
+func (s *WarningService) loadAlert(url string) (*Alert, error) {
+	body, err := s.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	return parseAlert(body)
+}
+
+// currentAlerts drops alerts that a later message references.
+func currentAlerts(alerts []Alert) []Alert {
+	replaced := map[string]bool{}
+	for _, a := range alerts {
+		for _, id := range a.referencedIDs() {
+			replaced[id] = true
+		}
+	}
+	current := []Alert{}
+	for _, a := range alerts {
+		if !replaced[a.Identifier] {
+			current = append(current, a)
+		}
+	}
+	return current
+}
+
+// InEffect returns the alerts in effect at now that cover the area.
+func (s *WarningService) InEffect(area Area, now time.Time) ([]Alert, error) {
+	alerts, err := s.Alerts()
+	if err != nil {
+		return nil, err
+	}
+	found := []Alert{}
+	for _, a := range alerts {
+		if a.isActive(now) && a.covers(area) {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+// Active returns the warnings in effect at now that cover the area, ordered
+// by onset. lang selects the language of the texts, e.g. "fi" or "en".
+func (s *WarningService) Active(area Area, now time.Time, lang string) ([]Warning, error) {
+	alerts, err := s.InEffect(area, now)
+	if err != nil {
+		return nil, err
+	}
+	warnings := []Warning{}
+	for _, a := range alerts {
+		w, err := ConvertToWarning(a, lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert %s: %w", a.Identifier, err)
+		}
+		warnings = append(warnings, w)
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Onset.Before(warnings[j].Onset)
+	})
+	return warnings, nil
+}
+
+// AlertSource returns the alerts in effect for an area at the given time,
+// with their texts in all languages.
+type AlertSource interface {
+	InEffect(area Area, now time.Time) ([]Alert, error)
+}
+
+// Condition describes warnings a scheduled action reacts to, e.g. wind
+// warnings at home.
+type Condition struct {
+	Source AlertSource
+	Area   Area
+	Match  Match
+}
+
+// Active reports whether a matching warning is in effect at now.
+func (c Condition) Active(now time.Time) (bool, error) {
+	if c.Source == nil {
+		return false, fmt.Errorf("warning condition for %q has no source", c.Area.Name)
+	}
+	alerts, err := c.Source.InEffect(c.Area, now)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range alerts {
+		if c.Match.MatchesAlert(a) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,163 @@
+package warnings
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/warnings/mock"
+)
+
+var home = Area{Name: "home", Municipality: "Helsinki", Lat: 60.2626, Lon: 25.0308}
+
+// newFeedClient serves the feed fixture and the linked CAP alert.
+func newFeedClient() *mock.MockHTTPClient {
+	client := mock.NewMockHTTPClient("testdata/exampleFeed.xml")
+	feed := client.GetFunc
+	client.GetFunc = func(url string) ([]byte, error) {
+		if strings.HasSuffix(url, "/traffic-1.xml") {
+			return os.ReadFile("testdata/exampleAlert.xml")
+		}
+		return feed(url)
+	}
+	return client
+}
+
+func ids(warnings []Warning) []string {
+	var ids []string
+	for _, w := range warnings {
+		ids = append(ids, w.ID)
+	}
+	return ids
+}
+
+func TestAlerts(t *testing.T) {
+	service := NewWarningService(newFeedClient(), FeedURL, time.Hour)
+	alerts, err := service.Alerts()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// rain-1 is replaced by rain-2 and thunder-1 cancelled by thunder-2
+	var got []string
+	for _, a := range alerts {
+		got = append(got, a.Identifier)
+	}
+	want := "wind-1,fire-1,thunder-2,rain-2,traffic-1"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Alerts, got %v, want %s", got, want)
+	}
+}
+
+func TestActive(t *testing.T) {
+	service := NewWarningService(newFeedClient(), FeedURL, time.Hour)
+
+	now := time.Date(2024, 11, 2, 14, 0, 0, 0, time.UTC)
+	warnings, err := service.Active(home, now, "en")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Wind by polygon, rain by area name. Fire is in Rovaniemi and traffic starts later.
+	if got := strings.Join(ids(warnings), ","); got != "wind-1,rain-2" {
+		t.Fatalf("Active warnings, got %s, want %s", got, "wind-1,rain-2")
+	}
+	wind := warnings[0]
+	if wind.Event != "Wind warning for land areas" || wind.Language != "en-GB" {
+		t.Errorf("English info, got %q (%s)", wind.Event, wind.Language)
+	}
+	if wind.Severity != SeverityModerate {
+		t.Errorf("Severity, got %s, want %s", wind.Severity, SeverityModerate)
+	}
+	if want := time.Date(2024, 11, 2, 10, 0, 0, 0, time.UTC); !wind.Onset.Equal(want) {
+		t.Errorf("Onset, got %s, want %s", wind.Onset, want)
+	}
+	if len(wind.Areas) != 1 || wind.Areas[0] != "Uusimaa" {
+		t.Errorf("Areas, got %v, want [Uusimaa]", wind.Areas)
+	}
+
+	// The linked traffic warning is found by its municipality geocode
+	evening := time.Date(2024, 11, 2, 17, 0, 0, 0, time.UTC)
+	warnings, err = service.Active(Area{Municipality: "helsinki"}, evening, "fi")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(ids(warnings), ","); got != "rain-2,traffic-1" {
+		t.Fatalf("Evening warnings, got %s, want %s", got, "rain-2,traffic-1")
+	}
+	if warnings[1].Event != "Liikennesäävaroitus" {
+		t.Errorf("Finnish event, got %q", warnings[1].Event)
+	}
+
+	// Everything has expired the next afternoon
+	warnings, err = service.Active(home, time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC), "en")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", ids(warnings))
+	}
+}
+
+func TestAlertsKeepsStaleDataOnError(t *testing.T) {
+	client := newFeedClient()
+	service := NewWarningService(client, FeedURL, 0)
+	if _, err := service.Alerts(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client.GetFunc = func(url string) ([]byte, error) {
+		return nil, errors.New("feed down")
+	}
+	alerts, err := service.Alerts()
+	if err != nil {
+		t.Fatalf("Expected stale alerts, got %v", err)
+	}
+	if len(alerts) != 5 {
+		t.Errorf("Alerts length, got %d, want %d", len(alerts), 5)
+	}
+}
+
+func TestCondition(t *testing.T) {
+	service := NewWarningService(newFeedClient(), FeedURL, time.Hour)
+	now := time.Date(2024, 11, 2, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		match Match
+		want  bool
+	}{
+		{"any", Match{}, true},
+		{"wind", Match{Events: []string{"WIND"}}, true},
+		{"wind in Finnish", Match{Events: []string{"tuulivaroitus"}}, true},
+		{"thunder cancelled", Match{Events: []string{"thunder"}}, false},
+		{"severe", Match{MinSeverity: SeveritySevere}, false},
+		{"moderate rain", Match{Events: []string{"rain"}, MinSeverity: SeverityModerate}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Condition{Source: service, Area: home, Match: tt.match}
+			got, err := c.Active(now)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Active, got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	p, err := parsePolygon("60.10,24.50 60.10,25.60 60.50,25.60 60.50,24.50 60.10,24.50")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !p.Contains(Point{Lat: 60.2626, Lon: 25.0308}) {
+		t.Errorf("Expected Helsinki to be inside")
+	}
+	if p.Contains(Point{Lat: 61.5, Lon: 23.8}) {
+		t.Errorf("Expected Tampere to be outside")
+	}
+	if _, err := parsePolygon("60.1,24.5 60.2"); err == nil {
+		t.Errorf("Expected error for invalid polygon")
+	}
+}
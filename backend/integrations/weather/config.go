@@ -0,0 +1,147 @@
+package weather
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocation is used when WEATHER_LOCATIONS is not configured.
+var DefaultLocation = Location{
+	Name:         "home",
+	FMISID:       "101004",
+	Place:        "Tapanila,Helsinki",
+	Municipality: "Helsinki",
+	Lat:          60.2626,
+	Lon:          25.0308,
+	Provider:     "fmi",
+	Fallback:     true,
+}
+
+// Locations holds the configured weather locations by name.
+type Locations struct {
+	defaultName string
+	names       []string
+	byName      map[string]Location
+}
+
+func NewLocations(defaultName string, locations ...Location) (*Locations, error) {
+	l := &Locations{defaultName: defaultName, byName: make(map[string]Location, len(locations))}
+	for _, loc := range locations {
+		if loc.Name == "" {
+			return nil, fmt.Errorf("weather location without a name")
+		}
+		if _, ok := l.byName[loc.Name]; ok {
+			return nil, fmt.Errorf("duplicate weather location %q", loc.Name)
+		}
+		l.names = append(l.names, loc.Name)
+		l.byName[loc.Name] = loc
+	}
+	if len(l.names) == 0 {
+		return nil, fmt.Errorf("no weather locations configured")
+	}
+	if l.defaultName == "" {
+		l.defaultName = l.names[0]
+	}
+	if _, ok := l.byName[l.defaultName]; !ok {
+		return nil, fmt.Errorf("default weather location %q is not configured", l.defaultName)
+	}
+	return l, nil
+}
+
+func (l *Locations) Get(name string) (Location, bool) {
+	loc, ok := l.byName[name]
+	return loc, ok
+}
+
+func (l *Locations) Default() Location {
+	return l.byName[l.defaultName]
+}
+
+// All returns the locations in configuration order.
+func (l *Locations) All() []Location {
+	all := make([]Location, 0, len(l.names))
+	for _, name := range l.names {
+		all = append(all, l.byName[name])
+	}
+	return all
+}
+
+// LoadLocations reads the weather locations from the environment:
+//
+//	WEATHER_LOCATIONS=home,cabin
+//	WEATHER_DEFAULT_LOCATION=home
+//	WEATHER_LOCATION_HOME_FMISID=101004
+//	WEATHER_LOCATION_HOME_PLACE=Tapanila,Helsinki
+//	WEATHER_LOCATION_HOME_MUNICIPALITY=Helsinki
+//	WEATHER_LOCATION_HOME_LATLON=60.2626,25.0308
+//	WEATHER_LOCATION_HOME_PROVIDER=fmi
+//	WEATHER_LOCATION_HOME_FALLBACK=true
+//
+// Without WEATHER_LOCATIONS only DefaultLocation is returned.
+func LoadLocations() (*Locations, error) {
+	names := os.Getenv("WEATHER_LOCATIONS")
+	if strings.TrimSpace(names) == "" {
+		return NewLocations("", DefaultLocation)
+	}
+	var locations []Location
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		loc, err := loadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+	return NewLocations(os.Getenv("WEATHER_DEFAULT_LOCATION"), locations...)
+}
+
+func loadLocation(name string) (Location, error) {
+	prefix := "WEATHER_LOCATION_" + strings.ToUpper(name) + "_"
+	loc := Location{
+		Name:         name,
+		FMISID:       os.Getenv(prefix + "FMISID"),
+		Place:        os.Getenv(prefix + "PLACE"),
+		Municipality: os.Getenv(prefix + "MUNICIPALITY"),
+		Provider:     os.Getenv(prefix + "PROVIDER"),
+	}
+	if latlon := os.Getenv(prefix + "LATLON"); latlon != "" {
+		lat, lon, err := ParseLatLon(latlon)
+		if err != nil {
+			return Location{}, fmt.Errorf("invalid %sLATLON: %w", prefix, err)
+		}
+		loc.Lat, loc.Lon = lat, lon
+	}
+	if fallback := os.Getenv(prefix + "FALLBACK"); fallback != "" {
+		b, err := strconv.ParseBool(fallback)
+		if err != nil {
+			return Location{}, fmt.Errorf("invalid %sFALLBACK: %w", prefix, err)
+		}
+		loc.Fallback = b
+	}
+	if loc.FMISID == "" && loc.Place == "" && !loc.HasCoordinates() {
+		return Location{}, fmt.Errorf("weather location %q needs an FMISID, place or latlon", name)
+	}
+	return loc, nil
+}
+
+// ParseLatLon parses coordinates in "lat,lon" form.
+func ParseLatLon(s string) (float64, float64, error) {
+	latStr, lonStr, found := strings.Cut(s, ",")
+	if !found {
+		return 0, 0, fmt.Errorf("expected lat,lon, got %q", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, fmt.Errorf("invalid latitude %q", latStr)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, fmt.Errorf("invalid longitude %q", lonStr)
+	}
+	return lat, lon, nil
+}
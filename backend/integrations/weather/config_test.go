@@ -0,0 +1,62 @@
+package weather
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocationsDefault(t *testing.T) {
+	t.Setenv("WEATHER_LOCATIONS", "")
+	locations, err := LoadLocations()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLocation, locations.Default())
+	assert.Len(t, locations.All(), 1)
+}
+
+func TestLoadLocations(t *testing.T) {
+	t.Setenv("WEATHER_LOCATIONS", "home, cabin")
+	t.Setenv("WEATHER_DEFAULT_LOCATION", "cabin")
+	t.Setenv("WEATHER_LOCATION_HOME_FMISID", "101004")
+	t.Setenv("WEATHER_LOCATION_HOME_PLACE", "Tapanila,Helsinki")
+	t.Setenv("WEATHER_LOCATION_HOME_MUNICIPALITY", "Helsinki")
+	t.Setenv("WEATHER_LOCATION_CABIN_FMISID", "101118")
+	t.Setenv("WEATHER_LOCATION_CABIN_LATLON", "61.4981,23.7610")
+	t.Setenv("WEATHER_LOCATION_CABIN_PROVIDER", "metno")
+	t.Setenv("WEATHER_LOCATION_CABIN_FALLBACK", "true")
+
+	locations, err := LoadLocations()
+	require.NoError(t, err)
+	assert.Equal(t, "cabin", locations.Default().Name)
+
+	all := locations.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "home", all[0].Name)
+	assert.Equal(t, "Tapanila,Helsinki", all[0].Place)
+	assert.Equal(t, "Helsinki", all[0].Municipality)
+	assert.False(t, all[0].Fallback)
+
+	cabin, ok := locations.Get("cabin")
+	require.True(t, ok)
+	assert.Equal(t, Location{Name: "cabin", FMISID: "101118", Lat: 61.4981, Lon: 23.7610, Provider: "metno", Fallback: true}, cabin)
+
+	_, ok = locations.Get("grandparents")
+	assert.False(t, ok)
+}
+
+func TestLoadLocationsInvalid(t *testing.T) {
+	t.Setenv("WEATHER_LOCATIONS", "cabin")
+	t.Setenv("WEATHER_LOCATION_CABIN_LATLON", "61.4981")
+	_, err := LoadLocations()
+	assert.Error(t, err)
+
+	t.Setenv("WEATHER_LOCATION_CABIN_LATLON", "")
+	_, err = LoadLocations()
+	assert.Error(t, err, "location without FMISID, place or latlon")
+
+	t.Setenv("WEATHER_LOCATION_CABIN_FMISID", "101118")
+	t.Setenv("WEATHER_DEFAULT_LOCATION", "home")
+	_, err = LoadLocations()
+	assert.Error(t, err, "unknown default location")
+}
@@ -0,0 +1,50 @@
+package weather
+
+import (
+	"errors"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+	"github.com/rs/zerolog/log"
+)
+
+// FallbackProvider tries each provider in order and returns the first
+// successful result.
+type FallbackProvider struct {
+	providers []WeatherProvider
+}
+
+func NewFallbackProvider(providers ...WeatherProvider) *FallbackProvider {
+	return &FallbackProvider{providers: providers}
+}
+
+func (f *FallbackProvider) Name() string {
+	if len(f.providers) == 0 {
+		return "fallback"
+	}
+	return f.providers[0].Name()
+}
+
+func (f *FallbackProvider) Observations(loc Location) ([]fmi.WeatherData, error) {
+	return f.first(loc, "observations", WeatherProvider.Observations)
+}
+
+func (f *FallbackProvider) Forecast(loc Location) ([]fmi.WeatherData, error) {
+	return f.first(loc, "forecast", WeatherProvider.Forecast)
+}
+
+func (f *FallbackProvider) first(loc Location, kind string, get func(WeatherProvider, Location) ([]fmi.WeatherData, error)) ([]fmi.WeatherData, error) {
+	var errs []error
+	for _, p := range f.providers {
+		data, err := get(p, loc)
+		if err == nil {
+			return data, nil
+		}
+		log.Warn().Err(err).Str("event", "weather_provider_failed").Str("provider", p.Name()).
+			Str("location", loc.Name).Str("kind", kind).Msg("weather provider failed; trying next")
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no weather providers to fall back to")
+	}
+	return nil, errors.Join(errs...)
+}
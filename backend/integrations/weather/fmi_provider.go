@@ -0,0 +1,84 @@
+package weather
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+)
+
+type FMIProvider struct {
+	service *fmi.WeatherService
+}
+
+// NewFMIProvider creates an FMI backed provider. client may be nil (defaults applied).
+func NewFMIProvider(client fmi.HTTPClient) *FMIProvider {
+	if client == nil {
+		client = fmi.NewDefaultHTTPClient()
+	}
+	return &FMIProvider{service: fmi.NewWeatherService(client, fmi.APIEndpoint)}
+}
+
+func (p *FMIProvider) Name() string {
+	return "fmi"
+}
+
+func (p *FMIProvider) Observations(loc Location) ([]fmi.WeatherData, error) {
+	if loc.FMISID == "" {
+		return nil, fmt.Errorf("location %q has no FMISID: %w", loc.Name, ErrNotSupported)
+	}
+	w, err := p.service.GetWeatherData(fmi.StationId(loc.FMISID), fmi.Observations)
+	if err != nil {
+		return nil, err
+	}
+	return w.WeatherData, nil
+}
+
+func (p *FMIProvider) Forecast(loc Location) ([]fmi.WeatherData, error) {
+	return p.ModelForecast(loc, fmi.ForecastQuery{})
+}
+
+// ModelForecast prefers the place name and falls back to the coordinates.
+func (p *FMIProvider) ModelForecast(loc Location, query fmi.ForecastQuery) ([]fmi.WeatherData, error) {
+	var place fmi.StationId
+	switch {
+	case loc.Place != "":
+		place = fmi.StationId(loc.Place)
+	case loc.HasCoordinates():
+		place = fmi.LatLon(loc.Lat, loc.Lon)
+	default:
+		return nil, fmt.Errorf("location %q has no place or coordinates: %w", loc.Name, ErrNotSupported)
+	}
+	w, err := p.service.GetForecast(place, query)
+	if err != nil {
+		return nil, err
+	}
+	return w.WeatherData, nil
+}
+
+func (p *FMIProvider) History(loc Location, query fmi.ObservationQuery) ([]fmi.WeatherData, error) {
+	if loc.FMISID == "" {
+		return nil, fmt.Errorf("location %q has no FMISID: %w", loc.Name, ErrNotSupported)
+	}
+	w, err := p.service.GetObservations(fmi.StationId(loc.FMISID), query)
+	if err != nil {
+		return nil, err
+	}
+	return w.WeatherData, nil
+}
+
+func (p *FMIProvider) Daily(loc Location, start, end time.Time, base float64) ([]fmi.DailyWeather, error) {
+	if loc.FMISID == "" {
+		return nil, fmt.Errorf("location %q has no FMISID: %w", loc.Name, ErrNotSupported)
+	}
+	return p.service.GetDailyWeather(fmi.StationId(loc.FMISID), start, end, base)
+}
+
+// Lightning returns the strikes within radiusKm of the location during the
+// last minutes before now.
+func (p *FMIProvider) Lightning(loc Location, radiusKm float64, minutes int, now time.Time) (fmi.LightningReport, error) {
+	if !loc.HasCoordinates() {
+		return fmi.LightningReport{}, fmt.Errorf("location %q has no coordinates: %w", loc.Name, ErrNotSupported)
+	}
+	return p.service.GetLightning(fmi.LastMinutes(loc.Lat, loc.Lon, radiusKm, minutes, now))
+}
@@ -0,0 +1,146 @@
+package weather
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+	"github.com/mikahozz/gohome/integrations/metno"
+)
+
+type MetNoProvider struct {
+	service *metno.ForecastService
+}
+
+// NewMetNoProvider creates a MET Norway backed provider. client may be nil (defaults applied).
+func NewMetNoProvider(client metno.HTTPClient) *MetNoProvider {
+	if client == nil {
+		client = metno.NewDefaultHTTPClient()
+	}
+	return &MetNoProvider{service: metno.NewForecastService(client, metno.APIEndpoint)}
+}
+
+func (p *MetNoProvider) Name() string {
+	return "metno"
+}
+
+// Observations returns the current time step of the forecast since MET Norway
+// doesn't publish station observations for Finland. It is only meant as a
+// fallback when FMI is unavailable.
+func (p *MetNoProvider) Observations(loc Location) ([]fmi.WeatherData, error) {
+	data, err := p.Forecast(loc)
+	if err != nil {
+		return nil, err
+	}
+	return data[:1], nil
+}
+
+func (p *MetNoProvider) Forecast(loc Location) ([]fmi.WeatherData, error) {
+	if !loc.HasCoordinates() {
+		return nil, fmt.Errorf("location %q has no coordinates: %w", loc.Name, ErrNotSupported)
+	}
+	forecast, err := p.service.GetForecast(loc.Lat, loc.Lon)
+	if err != nil {
+		return nil, err
+	}
+	data := ConvertMetNoForecast(forecast)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no hourly forecast steps for location %q", loc.Name)
+	}
+	return data, nil
+}
+
+// ConvertMetNoForecast maps the hourly part of a locationforecast document to
+// WeatherData. Steps with only 6 or 12 hour periods are left out so the result
+// matches the hourly HARMONIE forecast from FMI.
+func ConvertMetNoForecast(forecast *metno.LocationforecastModel) []fmi.WeatherData {
+	var data []fmi.WeatherData
+	for _, step := range forecast.Properties.Timeseries {
+		if step.Data.Next1Hours == nil {
+			continue
+		}
+		d := step.Data.Instant.Details
+		w := fmi.WeatherData{
+			Time:              step.Time.UTC().Format(time.RFC3339),
+			Temp:              d.AirTemperature,
+			Humidity:          d.RelativeHumidity,
+			WindSpeed:         d.WindSpeed,
+			MaxWindSpeed:      d.WindSpeedOfGust,
+			WindDirection:     d.WindFromDirection,
+			Rain:              step.Data.Next1Hours.Details.PrecipitationAmount,
+			Pressure:          d.AirPressureAtSeaLevel,
+			Weather:           smartSymbol(step.Data.Next1Hours.Summary.SymbolCode),
+			DewPoint:          d.DewPointTemperature,
+			CloudCover:        d.CloudAreaFraction,
+			UVIndex:           d.UVIndexClearSky,
+			PrecipProbability: step.Data.Next1Hours.Details.ProbabilityOfPrecipitation,
+		}
+		if w.Weather != nil {
+			w.Condition = fmi.FromSmartSymbol(*w.Weather)
+		}
+		data = append(data, w)
+	}
+	return data
+}
+
+// metNoSymbols maps MET Norway symbol codes (without the _day/_night suffix)
+// to the FMI SmartSymbol codes the frontend has icons for. The "lights..."
+// spellings are the API's own.
+var metNoSymbols = map[string]int{
+	"clearsky":                     1,
+	"fair":                         2,
+	"partlycloudy":                 4,
+	"cloudy":                       7,
+	"fog":                          9,
+	"lightrainshowers":             21,
+	"rainshowers":                  24,
+	"heavyrainshowers":             27,
+	"lightrain":                    33,
+	"rain":                         36,
+	"heavyrain":                    39,
+	"lightsleetshowers":            41,
+	"sleetshowers":                 44,
+	"heavysleetshowers":            47,
+	"lightsleet":                   43,
+	"sleet":                        46,
+	"heavysleet":                   49,
+	"lightsnowshowers":             51,
+	"snowshowers":                  54,
+	"heavysnowshowers":             57,
+	"lightsnow":                    53,
+	"snow":                         56,
+	"heavysnow":                    59,
+	"lightrainshowersandthunder":   71,
+	"rainshowersandthunder":        74,
+	"heavyrainshowersandthunder":   77,
+	"lightrainandthunder":          71,
+	"rainandthunder":               74,
+	"heavyrainandthunder":          77,
+	"lightssleetshowersandthunder": 71,
+	"sleetshowersandthunder":       74,
+	"heavysleetshowersandthunder":  77,
+	"lightsleetandthunder":         71,
+	"sleetandthunder":              74,
+	"heavysleetandthunder":         77,
+	"lightssnowshowersandthunder":  71,
+	"snowshowersandthunder":        74,
+	"heavysnowshowersandthunder":   77,
+	"lightsnowandthunder":          71,
+	"snowandthunder":               74,
+	"heavysnowandthunder":          77,
+}
+
+// smartSymbol converts a MET Norway symbol code to a SmartSymbol. Night
+// variants are offset by 100 as in FMI's numbering. Unknown codes return nil.
+func smartSymbol(code string) *int {
+	base, variant, _ := strings.Cut(code, "_")
+	symbol, ok := metNoSymbols[base]
+	if !ok {
+		return nil
+	}
+	if variant == "night" {
+		symbol += 100
+	}
+	return &symbol
+}
@@ -0,0 +1,103 @@
+// Package weather provides a provider-neutral access to observations and
+// forecasts. Each backend (FMI, MET Norway) implements WeatherProvider and
+// returns the same normalized fmi.WeatherData rows the API has always served.
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+)
+
+// ErrNotSupported is returned when a provider cannot serve the requested data
+// for a location, e.g. FMI observations without a station id.
+var ErrNotSupported = errors.New("not supported by weather provider")
+
+// Location is a place we show weather for. FMISID selects the FMI observation
+// station and Place or Lat/Lon the forecast point. Municipality and Lat/Lon
+// are used to match weather warnings.
+type Location struct {
+	Name         string
+	FMISID       string
+	Place        string
+	Municipality string
+	Lat          float64
+	Lon          float64
+	Provider     string // preferred provider, defaults to the first registered one
+	Fallback     bool   // try the other providers when the preferred one fails
+}
+
+func (l Location) HasCoordinates() bool {
+	return l.Lat != 0 || l.Lon != 0
+}
+
+type WeatherProvider interface {
+	Name() string
+	Observations(loc Location) ([]fmi.WeatherData, error)
+	Forecast(loc Location) ([]fmi.WeatherData, error)
+}
+
+// HistoryProvider is implemented by providers that can return past
+// observations for a time range.
+type HistoryProvider interface {
+	History(loc Location, query fmi.ObservationQuery) ([]fmi.WeatherData, error)
+}
+
+// DailyProvider is implemented by providers that have daily statistics,
+// with heating degree days calculated against base.
+type DailyProvider interface {
+	Daily(loc Location, start, end time.Time, base float64) ([]fmi.DailyWeather, error)
+}
+
+// ModelForecastProvider is implemented by providers that can select the
+// forecast model, parameters and horizon.
+type ModelForecastProvider interface {
+	ModelForecast(loc Location, query fmi.ForecastQuery) ([]fmi.WeatherData, error)
+}
+
+// LightningProvider is implemented by providers with lightning observations.
+type LightningProvider interface {
+	Lightning(loc Location, radiusKm float64, minutes int, now time.Time) (fmi.LightningReport, error)
+}
+
+// Registry holds the available providers in fallback order.
+type Registry struct {
+	providers []WeatherProvider
+}
+
+func NewRegistry(providers ...WeatherProvider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// ForLocation returns the provider configured for the location. When the
+// location allows fallback the rest of the providers are tried in order.
+func (r *Registry) ForLocation(loc Location) (WeatherProvider, error) {
+	if len(r.providers) == 0 {
+		return nil, errors.New("no weather providers registered")
+	}
+	primary := -1
+	if loc.Provider == "" {
+		primary = 0
+	}
+	for i, p := range r.providers {
+		if p.Name() == loc.Provider {
+			primary = i
+			break
+		}
+	}
+	if primary < 0 {
+		return nil, fmt.Errorf("unknown weather provider %q for location %q", loc.Provider, loc.Name)
+	}
+	if !loc.Fallback || len(r.providers) == 1 {
+		return r.providers[primary], nil
+	}
+	chain := []WeatherProvider{r.providers[primary]}
+	for i, p := range r.providers {
+		if i != primary {
+			chain = append(chain, p)
+		}
+	}
+	return NewFallbackProvider(chain...), nil
+}
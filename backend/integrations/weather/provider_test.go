@@ -0,0 +1,175 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/fmi"
+	fmimock "github.com/mikahozz/gohome/integrations/fmi/mock"
+	metnomock "github.com/mikahozz/gohome/integrations/metno/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var home = Location{
+	Name:     "home",
+	FMISID:   "101004",
+	Place:    "Tapanila,Helsinki",
+	Lat:      60.2626,
+	Lon:      25.0308,
+	Fallback: true,
+}
+
+type failingProvider struct{ name string }
+
+func (p failingProvider) Name() string { return p.name }
+func (p failingProvider) Observations(loc Location) ([]fmi.WeatherData, error) {
+	return nil, errors.New(p.name + " is down")
+}
+func (p failingProvider) Forecast(loc Location) ([]fmi.WeatherData, error) {
+	return nil, errors.New(p.name + " is down")
+}
+
+func TestFMIProviderObservations(t *testing.T) {
+	p := NewFMIProvider(fmimock.NewMockHTTPClient("../fmi/testdata/exampleMinutes.xml"))
+	data, err := p.Observations(home)
+	require.NoError(t, err)
+	assert.Len(t, data, 73)
+	assert.Equal(t, "2022-10-10T02:50:00Z", data[0].Time)
+}
+
+func TestFMIProviderHistory(t *testing.T) {
+	var p HistoryProvider = NewFMIProvider(fmimock.NewMockHTTPClient("../fmi/testdata/exampleHistory.xml"))
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	data, err := p.History(home, fmi.ObservationQuery{StartTime: start, EndTime: start.AddDate(0, 0, 1), Timestep: 3 * time.Hour})
+	require.NoError(t, err)
+	assert.Len(t, data, 9)
+
+	_, err = p.History(Location{Name: "cabin", Place: "Tampere"}, fmi.ObservationQuery{StartTime: start, EndTime: start.AddDate(0, 0, 1)})
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestFMIProviderDaily(t *testing.T) {
+	var p DailyProvider = NewFMIProvider(fmimock.NewMockHTTPClient("../fmi/testdata/exampleDaily.xml"))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	days, err := p.Daily(home, start, start.AddDate(0, 0, 7), 20)
+	require.NoError(t, err)
+	require.Len(t, days, 7)
+	require.NotNil(t, days[0].HeatingDegreeDays)
+	assert.Equal(t, 28.4, *days[0].HeatingDegreeDays)
+}
+
+func TestFMIProviderModelForecast(t *testing.T) {
+	client := fmimock.NewMockHTTPClient("../fmi/testdata/exampleForecast.xml")
+	p := NewFMIProvider(client)
+
+	_, err := p.ModelForecast(home, fmi.ForecastQuery{Model: fmi.ECMWF, Parameters: []string{"Temperature", "PoP"}})
+	require.NoError(t, err)
+	assert.Contains(t, client.LastQuery, "storedquery_id=ecmwf::forecast::surface::point::multipointcoverage")
+	assert.Contains(t, client.LastQuery, "parameters=Temperature%2CPoP")
+	assert.Contains(t, client.LastQuery, "&place=Tapanila,Helsinki")
+
+	_, err = p.ModelForecast(home, fmi.ForecastQuery{Model: "gfs"})
+	assert.Error(t, err)
+}
+
+func TestFMIProviderLightning(t *testing.T) {
+	p := NewFMIProvider(fmimock.NewMockHTTPClient("../fmi/testdata/exampleLightning.xml"))
+	report, err := p.Lightning(home, 10, 60, time.Date(2024, 7, 15, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Count)
+	assert.Equal(t, "2024-07-15T14:00:00Z", report.From)
+
+	_, err = p.Lightning(Location{Name: "nowhere", FMISID: "101004"}, 10, 60, time.Now())
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestFMIProviderForecastWithoutPlace(t *testing.T) {
+	client := fmimock.NewMockHTTPClient("../fmi/testdata/exampleForecast.xml")
+	p := NewFMIProvider(client)
+
+	data, err := p.Forecast(Location{Name: "cabin", Lat: 61.5, Lon: 23.8})
+	require.NoError(t, err)
+	assert.Len(t, data, 50)
+	assert.Contains(t, client.LastQuery, "&latlon=61.5,23.8")
+
+	_, err = p.Forecast(Location{Name: "nowhere"})
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestMetNoProviderForecast(t *testing.T) {
+	p := NewMetNoProvider(metnomock.NewMockHTTPClient("../metno/testdata/exampleForecast.json"))
+	data, err := p.Forecast(home)
+	require.NoError(t, err)
+	// Only the four hourly steps are included
+	require.Len(t, data, 4)
+	assert.Equal(t, "2024-11-02T18:00:00Z", data[0].Time)
+	assert.Equal(t, 4.2, *data[0].Temp)
+	assert.Equal(t, 9.8, *data[0].MaxWindSpeed)
+	assert.Equal(t, 7, *data[0].Weather)
+	assert.Equal(t, 0.9, *data[2].Rain)
+	assert.Equal(t, 104, *data[3].Weather)
+	assert.Equal(t, fmi.ConditionPartlyCloudy, data[3].Condition.Condition)
+	assert.True(t, data[3].Condition.Night)
+	assert.Nil(t, data[0].SnowDepth)
+	assert.Equal(t, 0.0, *data[0].UVIndex)
+	assert.Equal(t, 12.0, *data[0].PrecipProbability)
+}
+
+func TestMetNoProviderObservations(t *testing.T) {
+	p := NewMetNoProvider(metnomock.NewMockHTTPClient("../metno/testdata/exampleForecast.json"))
+	data, err := p.Observations(home)
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	assert.Equal(t, "2024-11-02T18:00:00Z", data[0].Time)
+}
+
+func TestSmartSymbol(t *testing.T) {
+	assert.Equal(t, 1, *smartSymbol("clearsky_day"))
+	assert.Equal(t, 101, *smartSymbol("clearsky_night"))
+	assert.Equal(t, 7, *smartSymbol("cloudy"))
+	assert.Equal(t, 74, *smartSymbol("rainandthunder"))
+	assert.Nil(t, smartSymbol("unknown"))
+}
+
+func TestRegistryFallback(t *testing.T) {
+	metno := NewMetNoProvider(metnomock.NewMockHTTPClient("../metno/testdata/exampleForecast.json"))
+	registry := NewRegistry(failingProvider{name: "fmi"}, metno)
+
+	p, err := registry.ForLocation(home)
+	require.NoError(t, err)
+	assert.Equal(t, "fmi", p.Name())
+	data, err := p.Forecast(home)
+	require.NoError(t, err)
+	assert.Len(t, data, 4)
+
+	noFallback := home
+	noFallback.Fallback = false
+	p, err = registry.ForLocation(noFallback)
+	require.NoError(t, err)
+	_, err = p.Forecast(noFallback)
+	assert.Error(t, err)
+}
+
+func TestRegistryProviderSelection(t *testing.T) {
+	registry := NewRegistry(failingProvider{name: "fmi"}, failingProvider{name: "metno"})
+
+	loc := home
+	loc.Provider = "metno"
+	loc.Fallback = false
+	p, err := registry.ForLocation(loc)
+	require.NoError(t, err)
+	assert.Equal(t, "metno", p.Name())
+
+	loc.Provider = "yr"
+	_, err = registry.ForLocation(loc)
+	assert.Error(t, err)
+
+	// All providers failing returns every error
+	p, err = registry.ForLocation(home)
+	require.NoError(t, err)
+	_, err = p.Observations(home)
+	assert.ErrorContains(t, err, "fmi is down")
+	assert.ErrorContains(t, err, "metno is down")
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/mikahozz/gohome/integrations/cabin"
+)
+
+// CabinBookings returns an in-memory cabin booking service with a few
+// bookings around today, one of them cancelled.
+func CabinBookings() *cabin.Service {
+	s := cabin.NewService(cabin.NewMemoryStore(), "Mika", "Anna", "Elise")
+	ctx := context.Background()
+	today := s.Today()
+	for _, b := range []cabin.Booking{
+		{Name: "Mika", From: today.AddDays(-3), To: today.AddDays(-1)},
+		{Name: "Anna", From: today.AddDays(4), To: today.AddDays(6), Note: "Sauna weekend"},
+		{Name: "Elise", From: today.AddDays(18), To: today.AddDays(20)},
+		{Name: "Mika", From: today.AddDays(40), To: today.AddDays(54), Note: "Summer holiday"},
+	} {
+		s.Book(ctx, b)
+	}
+	cancelled, _ := s.Book(ctx, cabin.Booking{Name: "Anna", From: today.AddDays(11), To: today.AddDays(12)})
+	s.Cancel(ctx, cancelled.ID)
+	return s
+}
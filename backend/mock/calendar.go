@@ -1,35 +1,72 @@
 package mock
 
 import (
-	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/mikahozz/gohome/integrations/cal"
 )
 
-type Event struct {
-	Title     string    `json:"title"`
-	StartTime time.Time `json:"startTime"`
-	EndTime   time.Time `json:"endTime"`
+// CalendarEvents returns mock events in the week from now.
+func CalendarEvents(now time.Time) []cal.Event {
+	now = now.Truncate(time.Hour)
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	family := func(e cal.Event) cal.Event {
+		e.Calendar, e.Color = "Family", "#4e79a7"
+		return e
+	}
+	elise := func(e cal.Event) cal.Event {
+		e.Calendar, e.Color, e.Owner = "Elise", "#e15759", "Elise"
+		return e
+	}
+	return []cal.Event{
+		family(cal.Event{Uid: "mock-1", Summary: "Family dinner", Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour)}),
+		elise(cal.Event{Uid: "mock-4", Summary: "Elise's birthday", Start: today.AddDate(0, 0, 1), End: today.AddDate(0, 0, 2), AllDay: true}),
+		elise(cal.Event{Uid: "mock-2", Summary: "Soccer practice", Start: now.Add(24 * time.Hour), End: now.Add(25 * time.Hour), Location: "Kaleva sports park",
+			Alarms: []cal.Alarm{{Action: "display", Trigger: now.Add(24*time.Hour - 30*time.Minute)}}}),
+		family(cal.Event{Uid: "mock-3", Summary: "Grandma visiting", Start: now.Add(48 * time.Hour), End: now.Add(52 * time.Hour)}),
+		family(cal.Event{Uid: "mock-5", Summary: "Cabin trip", Start: today.AddDate(0, 0, 4).Add(17 * time.Hour), End: today.AddDate(0, 0, 6).Add(15 * time.Hour)}),
+	}
 }
 
-func Events() (string, error) {
-	now := time.Now()
-	events := []Event{
-		{
-			Title:     "Mock Event 1",
-			StartTime: now.Add(2 * time.Hour),
-			EndTime:   now.Add(3 * time.Hour),
-		},
-		{
-			Title:     "Mock Event 2",
-			StartTime: now.Add(24 * time.Hour),
-			EndTime:   now.Add(25 * time.Hour),
-		},
-	}
+// Calendar returns the mock events as a calendar like the synced store, with
+// the events placed around the time they are asked at and their times in loc.
+func Calendar(loc *time.Location) cal.EventSource {
+	return calendar{loc: loc}
+}
 
-	data, err := json.Marshal(events)
-	if err != nil {
-		return "", err
-	}
+type calendar struct {
+	loc *time.Location
+}
 
-	return string(data), nil
+func (c calendar) Events(start, end time.Time, includeCancelled bool, calendars ...string) ([]cal.Event, error) {
+	all := CalendarEvents(time.Now().In(c.loc))
+	selected := make(map[string]bool)
+	for _, name := range calendars {
+		found := false
+		for _, e := range all {
+			if strings.EqualFold(e.Calendar, name) {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q", cal.ErrUnknownCalendar, name)
+		}
+		selected[strings.ToLower(name)] = true
+	}
+	events := []cal.Event{}
+	for _, e := range all {
+		if len(selected) > 0 && !selected[strings.ToLower(e.Calendar)] {
+			continue
+		}
+		if e.Cancelled() && !includeCancelled {
+			continue
+		}
+		if e.Start.Before(end) && e.End.After(start) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
 }
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -8,3 +9,30 @@ import (
 func IndoorDevUpstairs() (string, error) {
 	return fmt.Sprintf(`{"battery":100.0,"humidity":27.4,"temperature":22.5,"time":"%s"}`, time.Now().UTC().Format(time.RFC3339Nano)), nil
 }
+
+func IndoorSensors() (string, error) {
+	return `[{"id":"Shelly","name":"Balcony","room":"balcony"},{"id":"dev_upstairs","name":"Upstairs","room":"upstairs"}]`, nil
+}
+
+// IndoorHistory returns readings every 10 minutes for the last 24 hours.
+func IndoorHistory() (string, error) {
+	type reading struct {
+		Time        string  `json:"time"`
+		Temperature float64 `json:"temperature"`
+		Humidity    float64 `json:"humidity"`
+		Battery     float64 `json:"battery"`
+	}
+	end := time.Now().UTC().Truncate(10 * time.Minute)
+	var readings []reading
+	for t := end.Add(-24 * time.Hour); !t.After(end); t = t.Add(10 * time.Minute) {
+		h := float64(t.Hour()) + float64(t.Minute())/60
+		readings = append(readings, reading{
+			Time:        t.Format(time.RFC3339),
+			Temperature: 21.5 + float64(int(h)%12)/10,
+			Humidity:    28 + float64(int(h)%6),
+			Battery:     100,
+		})
+	}
+	data, err := json.Marshal(readings)
+	return string(data), err
+}
@@ -1,7 +1,9 @@
 package mock
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -306,3 +308,167 @@ func OutdoorWeatherFore() (string, error) {
 	  ]	
 `, timeSubstitutes...), nil
 }
+
+func WeatherStations() (string, error) {
+	return `
+	[
+		{
+		  "id": "101004",
+		  "region": "Helsinki",
+		  "name": "Helsinki Kumpula",
+		  "lat": 60.203071,
+		  "lon": 24.961305,
+		  "networks": ["Automaattinen sääasema"],
+		  "temperature": true,
+		  "wind": true,
+		  "snow": true,
+		  "distance_km": 7.3
+		},
+		{
+		  "id": "100968",
+		  "region": "Vantaa",
+		  "name": "Vantaa Helsinki-Vantaan lentoasema",
+		  "lat": 60.326700,
+		  "lon": 24.956450,
+		  "networks": ["IL:n hallinnoima lentosääasema"],
+		  "temperature": true,
+		  "wind": true,
+		  "snow": false,
+		  "distance_km": 8.6
+		}
+	  ]
+	  `, nil
+}
+
+func WeatherWarnings() (string, error) {
+	now := time.Now().UTC().Truncate(time.Hour)
+	return fmt.Sprintf(`
+	[
+		{
+		  "id": "wind-1",
+		  "event": "Tuulivaroitus maa-alueille",
+		  "severity": "Moderate",
+		  "urgency": "Expected",
+		  "certainty": "Likely",
+		  "headline": "Tuulivaroitus maa-alueille: Uusimaa",
+		  "description": "Puuskat 20-25 m/s.",
+		  "instruction": "Kiinnitä irtaimisto.",
+		  "language": "fi-FI",
+		  "onset": "%s",
+		  "expires": "%s",
+		  "areas": ["Uusimaa"],
+		  "locations": ["home"]
+		}
+	  ]
+	  `, now.Add(-2*time.Hour).Format(time.RFC3339), now.Add(10*time.Hour).Format(time.RFC3339)), nil
+}
+
+func WeatherLightning() (string, error) {
+	now := time.Now().UTC().Truncate(time.Minute)
+	return fmt.Sprintf(`
+	{
+		"from": "%s",
+		"to": "%s",
+		"radius_km": 30,
+		"count": 2,
+		"nearest_km": 12.4,
+		"strikes": [
+			{
+			  "datetime": "%s",
+			  "lat": 60.3719,
+			  "lon": 25.0902,
+			  "peak_current": -14.2,
+			  "cloud_to_cloud": false,
+			  "multiplicity": 2,
+			  "distance_km": 12.4
+			},
+			{
+			  "datetime": "%s",
+			  "lat": 60.4101,
+			  "lon": 25.2433,
+			  "peak_current": 6.8,
+			  "cloud_to_cloud": true,
+			  "multiplicity": 1,
+			  "distance_km": 19.1
+			}
+		]
+	}
+	`, now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339),
+		now.Add(-7*time.Minute).Format(time.RFC3339), now.Add(-18*time.Minute).Format(time.RFC3339)), nil
+}
+
+func OutdoorWeatherHistory() (string, error) {
+	type historyRow struct {
+		Time     string  `json:"datetime"`
+		Temp     float64 `json:"temperature"`
+		Humidity float64 `json:"humidity"`
+	}
+	end := time.Now().UTC().Truncate(time.Hour)
+	var rows []historyRow
+	for t := end.AddDate(0, 0, -7); !t.After(end); t = t.Add(time.Hour) {
+		// Simple daily cycle peaking in the afternoon
+		hour := float64(t.Hour())
+		rows = append(rows, historyRow{
+			Time:     t.Format(time.RFC3339),
+			Temp:     math.Round((4+3*math.Sin((hour-9)/24*2*math.Pi))*10) / 10,
+			Humidity: 80,
+		})
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func OutdoorWeatherDaily() (string, error) {
+	return `
+	[
+		{
+		  "date": "2025-01-01",
+		  "temp_mean": -8.4,
+		  "temp_min": -12.1,
+		  "temp_max": -5.2,
+		  "precipitation": 0.0,
+		  "snow": 12.0,
+		  "heating_degree_days": 25.4
+		},
+		{
+		  "date": "2025-01-02",
+		  "temp_mean": -3.1,
+		  "temp_min": -6.0,
+		  "temp_max": -1.4,
+		  "precipitation": 2.3,
+		  "snow": 14.0,
+		  "heating_degree_days": 20.1
+		}
+	  ]
+	  `, nil
+}
+
+func OutdoorWeatherMonthly() (string, error) {
+	return `
+	[
+		{
+		  "month": "2024-12",
+		  "days": 31,
+		  "temp_mean": -2.3,
+		  "temp_min": -14.8,
+		  "temp_max": 5.1,
+		  "precipitation": 48.2,
+		  "snow_max": 9.0,
+		  "heating_degree_days": 598.3
+		},
+		{
+		  "month": "2025-01",
+		  "days": 31,
+		  "temp_mean": -5.7,
+		  "temp_min": -21.3,
+		  "temp_max": 3.1,
+		  "precipitation": 39.6,
+		  "snow_max": 15.0,
+		  "heating_degree_days": 703.7
+		}
+	  ]
+	  `, nil
+}
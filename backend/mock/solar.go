@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mikahozz/gohome/integrations/solar"
+	"github.com/mikahozz/gohome/integrations/spot"
+)
+
+func SolarCurrent() (string, error) {
+	return fmt.Sprintf(`{"datetime":"%s","powerw":2500,"gridw":-1700,"consumptionw":800,"status":"producing"}`, time.Now().UTC().Format(time.RFC3339Nano)), nil
+}
+
+// Solar returns a solar service with two days of production sampled every
+// minute, peaking at 4.5 kW at noon, and spot prices varying by the hour.
+func Solar() *solar.Service {
+	store := solar.NewMemoryStore()
+	ctx := context.Background()
+	end := time.Now().UTC().Truncate(time.Minute)
+	for t := end.Add(-48 * time.Hour); !t.After(end); t = t.Add(time.Minute) {
+		hour := float64(t.Hour()) + float64(t.Minute())/60
+		power := math.Max(0, 4500*math.Sin((hour-4)/16*math.Pi))
+		grid := 600 - power
+		store.Save(ctx, solar.Sample{Time: t, PowerW: math.Round(power), GridW: &grid})
+	}
+	prices := func(start, end time.Time) ([]spot.SpotPrice, error) {
+		var prices []spot.SpotPrice
+		for t := start; t.Before(end); t = t.Add(time.Hour) {
+			prices = append(prices, spot.SpotPrice{DateTime: t, PriceCkwh: float64(t.Hour()%12) + 2})
+		}
+		return prices, nil
+	}
+	return solar.NewService(store, prices, 5*time.Minute)
+}
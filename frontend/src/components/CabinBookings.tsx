@@ -12,6 +12,7 @@ export interface Bookings {
 interface BookingItem {
   date: string;
   booked: boolean;
+  name?: string;
   updated?: string;
 }
 
@@ -88,7 +89,7 @@ export function CabinBookings() {
                   <div
                     className={renderBookingClasses(bookingitem)}
                     key={bookingitem.date}
-                    title={bookingitem.date}
+                    title={renderBookingTitle(bookingitem)}
                   >
                     {new Date(bookingitem.date).getDate()}
                   </div>
@@ -137,7 +138,7 @@ export function CabinBookings() {
                         <div
                           className={renderBookingClasses(bookingitem)}
                           key={bookingitem.date}
-                          title={bookingitem.date}
+                          title={renderBookingTitle(bookingitem)}
                         >
                           {new Date(bookingitem.date).getDate()}
                         </div>
@@ -173,6 +174,11 @@ export function CabinBookings() {
     return cssClass;
   };
 
+  const renderBookingTitle = (bookingItem: BookingItem) =>
+    bookingItem.name
+      ? `${bookingItem.date} ${bookingItem.name}`
+      : bookingItem.date;
+
   const renderUpdatedClasses = (date: number) => {
     const diff = Math.abs(new Date().getTime() - date);
     let cssClass = "bookingsUpdated";
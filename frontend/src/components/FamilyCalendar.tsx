@@ -7,6 +7,16 @@ interface CalendarEvent {
   summary: string;
   start: string;
   end: string;
+  allDay?: boolean;
+  calendar?: string;
+  color?: string;
+  owner?: string;
+  location?: string;
+  status?: string;
+  // The day of the segment and which of the event's days it is
+  date: string;
+  day: number;
+  days: number;
 }
 
 interface GroupedEvents {
@@ -28,14 +38,10 @@ export function FamilyCalendar() {
 
   const populateCalendarData = async () => {
     try {
-      const response = await fetch("/api/events");
+      const response = await fetch("/api/events/days");
       const data = await response.json();
-      const sorted = _.sortBy(
-        data,
-        (element: CalendarEvent) => new Date(element.start)
-      );
-      const grouped = _.groupBy(sorted, (element: CalendarEvent) =>
-        new Date(element.start).setHours(0, 0, 0, 0).toString()
+      const grouped = _.groupBy(data, (element: CalendarEvent) =>
+        moment(element.date).valueOf().toString()
       );
 
       setCalendardata(grouped);
@@ -51,16 +57,21 @@ export function FamilyCalendar() {
         <h3>{renderDate(calitem)}</h3>
         {calendardata[calitem].map((eventItem) => (
           <div
-            key={eventItem.uid}
-            className={renderEventClasses(eventItem.summary)}
+            key={`${eventItem.calendar}-${eventItem.uid}-${eventItem.start}-${eventItem.day}`}
+            className={renderEventClasses(eventItem)}
+            style={
+              eventItem.color ? { borderLeftColor: eventItem.color } : undefined
+            }
+            title={eventItem.calendar}
           >
             <div className="eventTitle">
-              {eventItem.summary} {renderDots(eventItem.summary)}
-              <span className="eventTime">
-                {moment(eventItem.start).format("HH:mm")} -{" "}
-                {moment(eventItem.end).format("HH:mm")}
-              </span>
+              {eventItem.summary}{" "}
+              {renderDots(`${eventItem.summary} ${eventItem.owner ?? ""}`)}
+              <span className="eventTime">{renderTime(eventItem)}</span>
             </div>
+            {eventItem.location && (
+              <div className="eventLocation">{eventItem.location}</div>
+            )}
           </div>
         ))}
       </div>
@@ -90,8 +101,23 @@ export function FamilyCalendar() {
     return classes;
   };
 
-  const renderEventClasses = (value: string) => {
-    return value[0] === "#" ? "calendarBox lowPrio" : "calendarBox";
+  const renderTime = (eventItem: CalendarEvent) => {
+    const days =
+      eventItem.days > 1 ? ` (${eventItem.day}/${eventItem.days})` : "";
+    if (eventItem.allDay) {
+      return `All day${days}`;
+    }
+    const start = moment(eventItem.start);
+    const end = moment(eventItem.end);
+    // A segment running to midnight ends at 00:00 the next day
+    const endTime = end.isAfter(start, "day") ? "24:00" : end.format("HH:mm");
+    return `${start.format("HH:mm")} - ${endTime}${days}`;
+  };
+
+  const renderEventClasses = (eventItem: CalendarEvent) => {
+    return eventItem.summary[0] === "#" || eventItem.status === "tentative"
+      ? "calendarBox lowPrio"
+      : "calendarBox";
   };
 
   const renderDate = (dateNumber: string) => {
@@ -3,13 +3,21 @@ import moment from "moment";
 import { DateTime } from "luxon";
 import { useDayTime } from "../hooks/useDayTime";
 
+export interface WeatherCondition {
+  code: string;
+  night: boolean;
+  symbol: number;
+  description: { fi: string; en: string };
+}
+
 interface ForecastItem {
   datetime: string;
-  weather: string;
-  temperature: number;
-  wind_dir: number;
-  wind_speed: number;
-  rain: number;
+  weather: number | null;
+  condition: WeatherCondition | null;
+  temperature: number | null;
+  wind_dir: number | null;
+  wind_speed: number | null;
+  rain: number | null;
 }
 
 export function Forecast() {
@@ -76,34 +84,41 @@ export function Forecast() {
                       {moment(forecastitem.datetime).format("HH:mm")}
                     </td>
                     <td>
-                      <img
-                        alt=""
-                        width="55"
-                        height="55"
-                        src={`/img/${forecastitem.weather}.svg`}
-                      />
+                      {forecastitem.condition && (
+                        <img
+                          alt={forecastitem.condition.description.en}
+                          title={forecastitem.condition.description.en}
+                          width="55"
+                          height="55"
+                          src={`/img/${forecastitem.condition.symbol}.svg`}
+                        />
+                      )}
                     </td>
                     <td className="temperature-col">
-                      {Math.round(forecastitem.temperature)}°
+                      {forecastitem.temperature !== null
+                        ? `${Math.round(forecastitem.temperature)}°`
+                        : "-"}
                     </td>
                     <td>
                       <div className="wind-container">
                         <img
                           alt=""
-                          style={renderRotate(forecastitem.wind_dir - 180)}
+                          style={renderRotate((forecastitem.wind_dir ?? 180) - 180)}
                           src="/img/arrow.svg"
                           width="40px"
                           height="40px"
                         />
                         <span className="wind-text">
-                          {Math.round(forecastitem.wind_speed)}
+                          {forecastitem.wind_speed !== null
+                            ? Math.round(forecastitem.wind_speed)
+                            : "-"}
                         </span>
                       </div>
                     </td>
                     <td>
                       <div
                         className="rainBox"
-                        style={{ width: `${forecastitem.rain * 10}px` }}
+                        style={{ width: `${(forecastitem.rain ?? 0) * 10}px` }}
                       ></div>
                     </td>
                   </tr>
@@ -10,8 +10,12 @@ export function WeatherNow() {
     setModal(!modal);
   };
 
+  const temperature = weatherdata?.[weatherdata.length - 1]?.temperature;
+  const condition = weatherdata?.[weatherdata.length - 1]?.condition;
   const content = weatherdata
-    ? `${weatherdata[weatherdata.length - 1].temperature}°`
+    ? temperature != null
+      ? `${temperature}°`
+      : "-"
     : isPending
     ? "..."
     : isError
@@ -40,6 +44,12 @@ export function WeatherNow() {
                 "-"}
             </span>
             <br />
+            {condition && (
+              <>
+                {condition.description.en}
+                <br />
+              </>
+            )}
             {error instanceof Error ? `Error:${error.message}` : ""}
           </p>
         </ModalBody>
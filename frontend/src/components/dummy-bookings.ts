@@ -1,4013 +0,0 @@
-export default {
-  lastupdated: "2025-03-04T06:00:15.000Z",
-  bookings: [
-    {
-      date: "2024-03-04T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-01T06:00:19.000Z",
-    },
-    {
-      date: "2024-03-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-08T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-21T06:00:16.000Z",
-    },
-    {
-      date: "2024-03-09T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-21T06:00:16.000Z",
-    },
-    {
-      date: "2024-03-10T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-09T06:00:18.000Z",
-    },
-    {
-      date: "2024-03-11T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-09T06:00:18.000Z",
-    },
-    {
-      date: "2024-03-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-12-24T06:00:16.000Z",
-    },
-    {
-      date: "2024-03-16T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-12-24T06:00:16.000Z",
-    },
-    {
-      date: "2024-03-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-22T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-23T06:00:19.000Z",
-    },
-    {
-      date: "2024-03-23T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-23T06:00:19.000Z",
-    },
-    {
-      date: "2024-03-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-03-25T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-26T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-27T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-28T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-29T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-30T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-11-20T06:00:22.000Z",
-    },
-    {
-      date: "2024-03-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-05T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-29T06:00:17.000Z",
-    },
-    {
-      date: "2024-04-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-01-29T06:00:17.000Z",
-    },
-    {
-      date: "2024-04-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-04-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-17T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-14T06:00:18.000Z",
-    },
-    {
-      date: "2024-05-18T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-14T06:00:18.000Z",
-    },
-    {
-      date: "2024-05-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-04-16T07:00:18.000Z",
-    },
-    {
-      date: "2024-05-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-05-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-14T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-15T06:00:18.000Z",
-    },
-    {
-      date: "2024-06-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-15T06:00:18.000Z",
-    },
-    {
-      date: "2024-06-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-20T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-06-27T07:00:21.000Z",
-    },
-    {
-      date: "2024-06-21T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-06-27T07:00:21.000Z",
-    },
-    {
-      date: "2024-06-22T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-06-27T07:00:21.000Z",
-    },
-    {
-      date: "2024-06-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-06-27T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-04T07:00:19.000Z",
-    },
-    {
-      date: "2024-06-28T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-04T07:00:19.000Z",
-    },
-    {
-      date: "2024-06-29T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-04T07:00:19.000Z",
-    },
-    {
-      date: "2024-06-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-04T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-12T07:00:23.000Z",
-    },
-    {
-      date: "2024-07-05T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-12T07:00:23.000Z",
-    },
-    {
-      date: "2024-07-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-12T07:00:23.000Z",
-    },
-    {
-      date: "2024-07-07T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-07-06T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-09T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-30T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-10T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-30T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-11T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-30T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-12T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-30T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-13T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2023-07-30T07:00:16.000Z",
-    },
-    {
-      date: "2024-07-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-30T06:00:19.000Z",
-    },
-    {
-      date: "2024-07-20T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-03-30T06:00:19.000Z",
-    },
-    {
-      date: "2024-07-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-07-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-01T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-05-30T07:00:17.000Z",
-    },
-    {
-      date: "2024-08-02T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-05-30T07:00:17.000Z",
-    },
-    {
-      date: "2024-08-03T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-05-30T07:00:17.000Z",
-    },
-    {
-      date: "2024-08-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-16T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-06-02T07:00:16.000Z",
-    },
-    {
-      date: "2024-08-17T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-06-02T07:00:16.000Z",
-    },
-    {
-      date: "2024-08-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-08-29T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-07-27T07:00:16.000Z",
-    },
-    {
-      date: "2024-08-30T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-07-27T07:00:16.000Z",
-    },
-    {
-      date: "2024-08-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-07-08T07:00:16.000Z",
-    },
-    {
-      date: "2024-09-07T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "2024-07-08T07:00:16.000Z",
-    },
-    {
-      date: "2024-09-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:34.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-13T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-08T07:00:15.000Z",
-    },
-    {
-      date: "2024-09-14T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-08T07:00:15.000Z",
-    },
-    {
-      date: "2024-09-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-20T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-19T07:00:17.000Z",
-    },
-    {
-      date: "2024-09-21T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-19T07:00:17.000Z",
-    },
-    {
-      date: "2024-09-22T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-19T07:00:17.000Z",
-    },
-    {
-      date: "2024-09-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-09-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-12T07:00:17.000Z",
-    },
-    {
-      date: "2024-10-03T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-05T07:00:16.000Z",
-    },
-    {
-      date: "2024-10-04T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-05T07:00:16.000Z",
-    },
-    {
-      date: "2024-10-05T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-05T07:00:16.000Z",
-    },
-    {
-      date: "2024-10-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-12T07:00:17.000Z",
-    },
-    {
-      date: "2024-10-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-07T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-16T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-07T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-17T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-07T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-18T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-07T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-07T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-20T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-20T07:00:15.000Z",
-    },
-    {
-      date: "2024-10-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-10-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-11-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2024-12-22T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-14T07:00:17.000Z",
-    },
-    {
-      date: "2024-12-23T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-14T07:00:17.000Z",
-    },
-    {
-      date: "2024-12-24T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-14T07:00:17.000Z",
-    },
-    {
-      date: "2024-12-25T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-14T07:00:17.000Z",
-    },
-    {
-      date: "2024-12-26T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-14T07:00:17.000Z",
-    },
-    {
-      date: "2024-12-27T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2024-12-28T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2024-12-29T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2024-12-30T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2024-12-31T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2025-01-01T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2025-01-02T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-10-17T07:00:16.000Z",
-    },
-    {
-      date: "2025-01-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-05T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-07T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-08T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-09T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-10T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-11T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-08T07:00:18.000Z",
-    },
-    {
-      date: "2025-01-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-24T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-31T07:00:17.000Z",
-    },
-    {
-      date: "2025-01-25T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-31T07:00:17.000Z",
-    },
-    {
-      date: "2025-01-26T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-23T06:00:16.000Z",
-    },
-    {
-      date: "2025-01-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-01-31T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-12T07:00:15.000Z",
-    },
-    {
-      date: "2025-02-01T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-12T07:00:15.000Z",
-    },
-    {
-      date: "2025-02-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-07T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-25T07:00:15.000Z",
-    },
-    {
-      date: "2025-02-08T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-09-25T07:00:15.000Z",
-    },
-    {
-      date: "2025-02-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-12T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-02-04T06:00:17.000Z",
-    },
-    {
-      date: "2025-02-13T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-02-04T06:00:17.000Z",
-    },
-    {
-      date: "2025-02-14T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-12-18T06:00:16.000Z",
-    },
-    {
-      date: "2025-02-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-12-18T06:00:16.000Z",
-    },
-    {
-      date: "2025-02-16T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-02-07T06:00:17.000Z",
-    },
-    {
-      date: "2025-02-17T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-02-07T06:00:17.000Z",
-    },
-    {
-      date: "2025-02-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-29T06:00:16.000Z",
-    },
-    {
-      date: "2025-02-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-09T07:00:19.000Z",
-    },
-    {
-      date: "2025-02-20T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-09T07:00:19.000Z",
-    },
-    {
-      date: "2025-02-21T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-09T07:00:19.000Z",
-    },
-    {
-      date: "2025-02-22T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-05-09T07:00:19.000Z",
-    },
-    {
-      date: "2025-02-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-02-24T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-21T06:00:15.000Z",
-    },
-    {
-      date: "2025-02-25T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-21T06:00:15.000Z",
-    },
-    {
-      date: "2025-02-26T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-21T06:00:15.000Z",
-    },
-    {
-      date: "2025-02-27T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-21T06:00:15.000Z",
-    },
-    {
-      date: "2025-02-28T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-11-29T06:00:16.000Z",
-    },
-    {
-      date: "2025-03-01T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-11-29T06:00:16.000Z",
-    },
-    {
-      date: "2025-03-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-07T06:00:19.000Z",
-    },
-    {
-      date: "2025-03-07T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-07T06:00:19.000Z",
-    },
-    {
-      date: "2025-03-08T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-07T06:00:19.000Z",
-    },
-    {
-      date: "2025-03-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-14T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-07T06:00:19.000Z",
-    },
-    {
-      date: "2025-03-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-07T06:00:19.000Z",
-    },
-    {
-      date: "2025-03-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-03-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-04-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-05-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-10T06:00:16.000Z",
-    },
-    {
-      date: "2025-06-20T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-10T06:00:16.000Z",
-    },
-    {
-      date: "2025-06-21T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2025-01-10T06:00:16.000Z",
-    },
-    {
-      date: "2025-06-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-26T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-03T07:00:17.000Z",
-    },
-    {
-      date: "2025-06-27T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-03T07:00:17.000Z",
-    },
-    {
-      date: "2025-06-28T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-03T07:00:17.000Z",
-    },
-    {
-      date: "2025-06-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-06-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-03T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-09T07:00:17.000Z",
-    },
-    {
-      date: "2025-07-04T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-09T07:00:17.000Z",
-    },
-    {
-      date: "2025-07-05T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-09T07:00:17.000Z",
-    },
-    {
-      date: "2025-07-06T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-07-09T07:00:17.000Z",
-    },
-    {
-      date: "2025-07-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-15T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-23T07:00:16.000Z",
-    },
-    {
-      date: "2025-07-16T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-23T07:00:16.000Z",
-    },
-    {
-      date: "2025-07-17T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-23T07:00:16.000Z",
-    },
-    {
-      date: "2025-07-18T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-23T07:00:16.000Z",
-    },
-    {
-      date: "2025-07-19T16:00:00.000Z",
-      booked: 1,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "2024-08-23T07:00:16.000Z",
-    },
-    {
-      date: "2025-07-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-07-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:35.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-08-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-09-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-10-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-11-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-01T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-02T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-03T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-04T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-05T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-06T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-07T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-08T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-09T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-10T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-11T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-12T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-13T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-14T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-15T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-16T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-17T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-18T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-19T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-20T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-21T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-22T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-23T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-24T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-25T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-26T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-27T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-28T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-29T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-30T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-    {
-      date: "2025-12-31T16:00:00.000Z",
-      booked: 0,
-      created: "2021-09-01T22:54:36.000Z",
-      updated: "0000-00-00 00:00:00",
-    },
-  ],
-};
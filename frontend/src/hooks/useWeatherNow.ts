@@ -1,8 +1,10 @@
 import { useQuery } from "@tanstack/react-query";
+import type { WeatherCondition } from "../components/Forecast";
 
 export interface WeatherData {
-  temperature: number;
+  temperature: number | null;
   datetime: string;
+  condition?: WeatherCondition | null;
 }
 
 export default function useWeatherNow() {
@@ -145,7 +145,21 @@ export function getMockData(path: string): object | undefined {
         const date = now.plus({ hours: i });
         data.push({
           datetime: date.toISO(),
-          weather: i % 5 === 0 ? "1" : "2",
+          weather: i % 5 === 0 ? 1 : 2,
+          condition:
+            i % 5 === 0
+              ? {
+                  code: "clear",
+                  night: false,
+                  symbol: 1,
+                  description: { fi: "Selkeää", en: "Clear" },
+                }
+              : {
+                  code: "mostly_clear",
+                  night: false,
+                  symbol: 2,
+                  description: { fi: "Melko selkeää", en: "Mostly clear" },
+                },
           temperature: Math.round((Math.random() * 10 - 5) * 10) / 10,
           wind_dir: Math.round(Math.random() * 360),
           wind_speed: Math.round(Math.random() * 10),
@@ -162,12 +176,22 @@ export function getMockData(path: string): object | undefined {
       const events = [];
       const now = DateTime.now();
       const eventTypes = [
-        "Family dinner",
-        "Elise's soccer",
-        "Elias's hockey",
-        "Ella's dance",
-        "aiti's meeting",
-        "iska's work trip",
+        { summary: "Family dinner", calendar: "Family", color: "#4e79a7" },
+        {
+          summary: "Soccer",
+          calendar: "Elise",
+          color: "#e15759",
+          owner: "Elise",
+        },
+        {
+          summary: "Hockey",
+          calendar: "Elias",
+          color: "#f28e2b",
+          owner: "Elias",
+        },
+        { summary: "Dance", calendar: "Ella", color: "#b07aa1", owner: "Ella" },
+        { summary: "äiti's meeting", calendar: "Family", color: "#4e79a7" },
+        { summary: "iskä's work trip", calendar: "Family", color: "#4e79a7" },
       ];
 
       for (let i = 0; i < 10; i++) {
@@ -191,14 +215,74 @@ export function getMockData(path: string): object | undefined {
 
         events.push({
           uid: `event-${i}`,
-          summary: eventTypes[i % eventTypes.length],
+          ...eventTypes[i % eventTypes.length],
           start: start.toISO(),
           end: end.toISO(),
+          allDay: false,
         });
       }
       return events;
     }
 
+    case "/api/events/days": {
+      const days = [];
+      const now = DateTime.now();
+      const today = now.startOf("day");
+      days.push({
+        uid: "birthday",
+        summary: "Elise's birthday",
+        calendar: "Elise",
+        color: "#e15759",
+        owner: "Elise",
+        start: today.plus({ days: 1 }).toISO(),
+        end: today.plus({ days: 2 }).toISO(),
+        allDay: true,
+        date: today.plus({ days: 1 }).toISODate(),
+        day: 1,
+        days: 1,
+      });
+      for (let i = 0; i < 3; i++) {
+        const day = today.plus({ days: 3 + i });
+        days.push({
+          uid: "trip",
+          summary: "Cabin trip",
+          calendar: "Family",
+          color: "#4e79a7",
+          start: (i === 0 ? day.set({ hour: 17 }) : day).toISO(),
+          end: (i === 2
+            ? day.set({ hour: 15 })
+            : day.plus({ days: 1 })
+          ).toISO(),
+          allDay: false,
+          date: day.toISODate(),
+          day: i + 1,
+          days: 3,
+        });
+      }
+      for (let i = 0; i < 4; i++) {
+        const start = now
+          .plus({ days: i })
+          .set({ hour: 17, minute: 0, second: 0, millisecond: 0 });
+        days.push({
+          uid: `practice-${i}`,
+          summary: i % 2 === 0 ? "Soccer" : "Dance",
+          calendar: i % 2 === 0 ? "Elise" : "Ella",
+          color: i % 2 === 0 ? "#e15759" : "#b07aa1",
+          owner: i % 2 === 0 ? "Elise" : "Ella",
+          location: i % 2 === 0 ? "Kaleva sports park" : "Dance school",
+          start: start.toISO(),
+          end: start.plus({ hours: 1, minutes: 30 }).toISO(),
+          allDay: false,
+          date: start.toISODate(),
+          day: 1,
+          days: 1,
+        });
+      }
+      return days.sort((a, b) =>
+        (a.start ?? "").localeCompare(b.start ?? ""),
+      );
+    }
+
     case "/api/sun": {
       const today = DateTime.now();
       const tomorrow = today.plus({ days: 1 });